@@ -6,12 +6,15 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/iov-one/tutorial/x/orderbook"
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/app"
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/orm"
 	"github.com/iov-one/weave/store/iavl"
 	"github.com/iov-one/weave/x"
+	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/cron"
 	"github.com/iov-one/weave/x/msgfee"
 	"github.com/iov-one/weave/x/multisig"
 	"github.com/iov-one/weave/x/sigs"
@@ -24,11 +27,13 @@ func Authenticator() x.Authenticator {
 	return x.ChainAuth(sigs.Authenticate{}, multisig.Authenticate{})
 }
 
+// ctrl can be initialized with any implementation, but must be used
+// consistently everywhere.
+var ctrl = cash.NewController(cash.NewBucket())
+
 // Chain returns a chain of decorators, to handle authentication,
 // fees, logging, and recovery
 func Chain(authFn x.Authenticator, minFee coin.Coin) app.Decorators {
-
-	// TODO implement orderbook controller
 	return app.ChainDecorators(
 		utils.NewLogging(),
 		utils.NewRecovery(),
@@ -36,25 +41,37 @@ func Chain(authFn x.Authenticator, minFee coin.Coin) app.Decorators {
 		utils.NewSavepoint().OnCheck(),
 		sigs.NewDecorator(),
 		multisig.NewDecorator(authFn),
+		// Tx exposes the cash fees through cash.FeeTx
+		cash.NewFeeDecorator(authFn, ctrl),
+		// all changes of a failed orderbook message (ie. escrow and
+		// partial fills) are discarded together
 		utils.NewSavepoint().OnDeliver(),
 		msgfee.NewFeeDecorator(),
 	)
 }
 
-// Router returns a default router
+// Router returns a default router, dispatching to the cash
+// and orderbook handlers
 func Router(authFn x.Authenticator) *app.Router {
 	r := app.NewRouter()
-	// TODO implement orderbook router
+	scheduler := cron.NewScheduler(CronTaskMarshaler)
+
+	cash.RegisterRoutes(r, authFn, ctrl)
+	orderbook.RegisterRoutes(r, authFn, ctrl, scheduler)
 	return r
 }
 
 // QueryRouter returns a default query router,
-// allowing access to "/auth", "/contracts" and "/"
+// allowing access to "/auth", "/contracts", "/wallets",
+// the orderbook buckets, "/crontaskresults" and "/"
 func QueryRouter() weave.QueryRouter {
 	r := weave.NewQueryRouter()
 	r.RegisterAll(
 		sigs.RegisterQuery,
 		multisig.RegisterQuery,
+		cash.RegisterQuery,
+		orderbook.RegisterQuery,
+		cron.RegisterQuery,
 		orm.RegisterQuery,
 	)
 	return r
@@ -67,6 +84,24 @@ func Stack(minFee coin.Coin) weave.Handler {
 	return Chain(authFn, minFee).WithHandler(Router(authFn))
 }
 
+// CronStack wires up a router for the scheduled tasks with a cron specific
+// decorator chain. This is run by the ticker of the BaseApp.
+// Tasks are not signed and pay no fees, they are authenticated by the
+// conditions stored with the task.
+func CronStack() weave.Handler {
+	r := app.NewRouter()
+	scheduler := cron.NewScheduler(CronTaskMarshaler)
+	orderbook.RegisterCronRoutes(r, ctrl, scheduler)
+
+	decorators := app.ChainDecorators(
+		utils.NewLogging(),
+		utils.NewRecovery(),
+		utils.NewKeyTagger(),
+		// No fee decorators.
+	)
+	return decorators.WithHandler(r)
+}
+
 // CommitKVStore returns an initialized KVStore that persists
 // the data to the named path.
 func CommitKVStore(dbPath string) (weave.CommitKVStore, error) {
@@ -101,6 +136,7 @@ func Application(name string, h weave.Handler,
 		return app.BaseApp{}, err
 	}
 	store := app.NewStoreApp(name, kv, QueryRouter(), ctx)
-	base := app.NewBaseApp(store, tx, h, nil, debug)
+	ticker := cron.NewTicker(CronStack(), CronTaskMarshaler)
+	base := app.NewBaseApp(store, tx, h, ticker, debug)
 	return base, nil
 }
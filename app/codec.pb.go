@@ -8,6 +8,7 @@ import (
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	orderbook "github.com/iov-one/tutorial/x/orderbook"
+	github_com_iov_one_weave "github.com/iov-one/weave"
 	cash "github.com/iov-one/weave/x/cash"
 	sigs "github.com/iov-one/weave/x/sigs"
 	io "io"
@@ -39,6 +40,14 @@ type Tx struct {
 	//	*Tx_OrderbookCreateOrderbookMsg
 	//	*Tx_OrderbookCreateOrderMsg
 	//	*Tx_OrderbookCancelOrderMsg
+	//	*Tx_OrderbookCreateMarketMsg
+	//	*Tx_OrderbookUpdateMarketOwnerMsg
+	//	*Tx_OrderbookUpdateConfigurationMsg
+	//	*Tx_OrderbookCreateStopOrderMsg
+	//	*Tx_OrderbookCancelStopOrderMsg
+	//	*Tx_OrderbookReplaceOrderMsg
+	//	*Tx_OrderbookCreateOrdersMsg
+	//	*Tx_OrderbookCancelAllOrdersMsg
 	Sum isTx_Sum `protobuf_oneof:"sum"`
 }
 
@@ -93,11 +102,43 @@ type Tx_OrderbookCreateOrderMsg struct {
 type Tx_OrderbookCancelOrderMsg struct {
 	OrderbookCancelOrderMsg *orderbook.CancelOrderMsg `protobuf:"bytes,102,opt,name=orderbook_cancel_order_msg,json=orderbookCancelOrderMsg,proto3,oneof"`
 }
+type Tx_OrderbookCreateMarketMsg struct {
+	OrderbookCreateMarketMsg *orderbook.CreateMarketMsg `protobuf:"bytes,103,opt,name=orderbook_create_market_msg,json=orderbookCreateMarketMsg,proto3,oneof"`
+}
+type Tx_OrderbookUpdateMarketOwnerMsg struct {
+	OrderbookUpdateMarketOwnerMsg *orderbook.UpdateMarketOwnerMsg `protobuf:"bytes,104,opt,name=orderbook_update_market_owner_msg,json=orderbookUpdateMarketOwnerMsg,proto3,oneof"`
+}
+type Tx_OrderbookUpdateConfigurationMsg struct {
+	OrderbookUpdateConfigurationMsg *orderbook.UpdateConfigurationMsg `protobuf:"bytes,106,opt,name=orderbook_update_configuration_msg,json=orderbookUpdateConfigurationMsg,proto3,oneof"`
+}
+type Tx_OrderbookCreateStopOrderMsg struct {
+	OrderbookCreateStopOrderMsg *orderbook.CreateStopOrderMsg `protobuf:"bytes,107,opt,name=orderbook_create_stop_order_msg,json=orderbookCreateStopOrderMsg,proto3,oneof"`
+}
+type Tx_OrderbookCancelStopOrderMsg struct {
+	OrderbookCancelStopOrderMsg *orderbook.CancelStopOrderMsg `protobuf:"bytes,108,opt,name=orderbook_cancel_stop_order_msg,json=orderbookCancelStopOrderMsg,proto3,oneof"`
+}
+type Tx_OrderbookReplaceOrderMsg struct {
+	OrderbookReplaceOrderMsg *orderbook.ReplaceOrderMsg `protobuf:"bytes,109,opt,name=orderbook_replace_order_msg,json=orderbookReplaceOrderMsg,proto3,oneof"`
+}
+type Tx_OrderbookCreateOrdersMsg struct {
+	OrderbookCreateOrdersMsg *orderbook.CreateOrdersMsg `protobuf:"bytes,110,opt,name=orderbook_create_orders_msg,json=orderbookCreateOrdersMsg,proto3,oneof"`
+}
+type Tx_OrderbookCancelAllOrdersMsg struct {
+	OrderbookCancelAllOrdersMsg *orderbook.CancelAllOrdersMsg `protobuf:"bytes,111,opt,name=orderbook_cancel_all_orders_msg,json=orderbookCancelAllOrdersMsg,proto3,oneof"`
+}
 
-func (*Tx_CashSendMsg) isTx_Sum()                 {}
-func (*Tx_OrderbookCreateOrderbookMsg) isTx_Sum() {}
-func (*Tx_OrderbookCreateOrderMsg) isTx_Sum()     {}
-func (*Tx_OrderbookCancelOrderMsg) isTx_Sum()     {}
+func (*Tx_CashSendMsg) isTx_Sum()                     {}
+func (*Tx_OrderbookCreateOrderbookMsg) isTx_Sum()     {}
+func (*Tx_OrderbookCreateOrderMsg) isTx_Sum()         {}
+func (*Tx_OrderbookCancelOrderMsg) isTx_Sum()         {}
+func (*Tx_OrderbookCreateMarketMsg) isTx_Sum()        {}
+func (*Tx_OrderbookUpdateMarketOwnerMsg) isTx_Sum()   {}
+func (*Tx_OrderbookUpdateConfigurationMsg) isTx_Sum() {}
+func (*Tx_OrderbookCreateStopOrderMsg) isTx_Sum()     {}
+func (*Tx_OrderbookCancelStopOrderMsg) isTx_Sum()     {}
+func (*Tx_OrderbookReplaceOrderMsg) isTx_Sum()        {}
+func (*Tx_OrderbookCreateOrdersMsg) isTx_Sum()        {}
+func (*Tx_OrderbookCancelAllOrdersMsg) isTx_Sum()     {}
 
 func (m *Tx) GetSum() isTx_Sum {
 	if m != nil {
@@ -155,6 +196,62 @@ func (m *Tx) GetOrderbookCancelOrderMsg() *orderbook.CancelOrderMsg {
 	return nil
 }
 
+func (m *Tx) GetOrderbookCreateMarketMsg() *orderbook.CreateMarketMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookCreateMarketMsg); ok {
+		return x.OrderbookCreateMarketMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookUpdateMarketOwnerMsg() *orderbook.UpdateMarketOwnerMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookUpdateMarketOwnerMsg); ok {
+		return x.OrderbookUpdateMarketOwnerMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookUpdateConfigurationMsg() *orderbook.UpdateConfigurationMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookUpdateConfigurationMsg); ok {
+		return x.OrderbookUpdateConfigurationMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookCreateStopOrderMsg() *orderbook.CreateStopOrderMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookCreateStopOrderMsg); ok {
+		return x.OrderbookCreateStopOrderMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookCancelStopOrderMsg() *orderbook.CancelStopOrderMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookCancelStopOrderMsg); ok {
+		return x.OrderbookCancelStopOrderMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookReplaceOrderMsg() *orderbook.ReplaceOrderMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookReplaceOrderMsg); ok {
+		return x.OrderbookReplaceOrderMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookCreateOrdersMsg() *orderbook.CreateOrdersMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookCreateOrdersMsg); ok {
+		return x.OrderbookCreateOrdersMsg
+	}
+	return nil
+}
+
+func (m *Tx) GetOrderbookCancelAllOrdersMsg() *orderbook.CancelAllOrdersMsg {
+	if x, ok := m.GetSum().(*Tx_OrderbookCancelAllOrdersMsg); ok {
+		return x.OrderbookCancelAllOrdersMsg
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Tx_OneofMarshaler, _Tx_OneofUnmarshaler, _Tx_OneofSizer, []interface{}{
@@ -162,6 +259,14 @@ func (*Tx) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, fun
 		(*Tx_OrderbookCreateOrderbookMsg)(nil),
 		(*Tx_OrderbookCreateOrderMsg)(nil),
 		(*Tx_OrderbookCancelOrderMsg)(nil),
+		(*Tx_OrderbookCreateMarketMsg)(nil),
+		(*Tx_OrderbookUpdateMarketOwnerMsg)(nil),
+		(*Tx_OrderbookUpdateConfigurationMsg)(nil),
+		(*Tx_OrderbookCreateStopOrderMsg)(nil),
+		(*Tx_OrderbookCancelStopOrderMsg)(nil),
+		(*Tx_OrderbookReplaceOrderMsg)(nil),
+		(*Tx_OrderbookCreateOrdersMsg)(nil),
+		(*Tx_OrderbookCancelAllOrdersMsg)(nil),
 	}
 }
 
@@ -189,6 +294,46 @@ func _Tx_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.OrderbookCancelOrderMsg); err != nil {
 			return err
 		}
+	case *Tx_OrderbookCreateMarketMsg:
+		_ = b.EncodeVarint(103<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookCreateMarketMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookUpdateMarketOwnerMsg:
+		_ = b.EncodeVarint(104<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookUpdateMarketOwnerMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookUpdateConfigurationMsg:
+		_ = b.EncodeVarint(106<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookUpdateConfigurationMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookCreateStopOrderMsg:
+		_ = b.EncodeVarint(107<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookCreateStopOrderMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookCancelStopOrderMsg:
+		_ = b.EncodeVarint(108<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookCancelStopOrderMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookReplaceOrderMsg:
+		_ = b.EncodeVarint(109<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookReplaceOrderMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookCreateOrdersMsg:
+		_ = b.EncodeVarint(110<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookCreateOrdersMsg); err != nil {
+			return err
+		}
+	case *Tx_OrderbookCancelAllOrdersMsg:
+		_ = b.EncodeVarint(111<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookCancelAllOrdersMsg); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Tx.Sum has unexpected type %T", x)
@@ -231,6 +376,70 @@ func _Tx_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bo
 		err := b.DecodeMessage(msg)
 		m.Sum = &Tx_OrderbookCancelOrderMsg{msg}
 		return true, err
+	case 103: // sum.orderbook_create_market_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.CreateMarketMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookCreateMarketMsg{msg}
+		return true, err
+	case 104: // sum.orderbook_update_market_owner_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.UpdateMarketOwnerMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookUpdateMarketOwnerMsg{msg}
+		return true, err
+	case 106: // sum.orderbook_update_configuration_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.UpdateConfigurationMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookUpdateConfigurationMsg{msg}
+		return true, err
+	case 107: // sum.orderbook_create_stop_order_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.CreateStopOrderMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookCreateStopOrderMsg{msg}
+		return true, err
+	case 108: // sum.orderbook_cancel_stop_order_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.CancelStopOrderMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookCancelStopOrderMsg{msg}
+		return true, err
+	case 109: // sum.orderbook_replace_order_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.ReplaceOrderMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookReplaceOrderMsg{msg}
+		return true, err
+	case 110: // sum.orderbook_create_orders_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.CreateOrdersMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookCreateOrdersMsg{msg}
+		return true, err
+	case 111: // sum.orderbook_cancel_all_orders_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.CancelAllOrdersMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &Tx_OrderbookCancelAllOrdersMsg{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -260,6 +469,213 @@ func _Tx_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Tx_OrderbookCreateMarketMsg:
+		s := proto.Size(x.OrderbookCreateMarketMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookUpdateMarketOwnerMsg:
+		s := proto.Size(x.OrderbookUpdateMarketOwnerMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookUpdateConfigurationMsg:
+		s := proto.Size(x.OrderbookUpdateConfigurationMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookCreateStopOrderMsg:
+		s := proto.Size(x.OrderbookCreateStopOrderMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookCancelStopOrderMsg:
+		s := proto.Size(x.OrderbookCancelStopOrderMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookReplaceOrderMsg:
+		s := proto.Size(x.OrderbookReplaceOrderMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookCreateOrdersMsg:
+		s := proto.Size(x.OrderbookCreateOrdersMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Tx_OrderbookCancelAllOrdersMsg:
+		s := proto.Size(x.OrderbookCancelAllOrdersMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// CronTask is a format used by the CronTaskMarshaler to marshal and unmarshal
+// cron tasks.
+type CronTask struct {
+	// Authenticators contains a list of conditions that authenticate execution
+	// of this task. CronTask is created internally and is not signed, so all
+	// conditions required for execution are stored here.
+	Authenticators []github_com_iov_one_weave.Condition `protobuf:"bytes,1,rep,name=authenticators,proto3,casttype=github.com/iov-one/weave.Condition" json:"authenticators,omitempty"`
+	// Messages share the numbering of the Tx message, messages that can only be
+	// executed by cron are never added to Tx.
+	//
+	// Types that are valid to be assigned to Sum:
+	//	*CronTask_OrderbookExpireOrderMsg
+	//	*CronTask_OrderbookActivateStopsMsg
+	Sum isCronTask_Sum `protobuf_oneof:"sum"`
+}
+
+func (m *CronTask) Reset()         { *m = CronTask{} }
+func (m *CronTask) String() string { return proto.CompactTextString(m) }
+func (*CronTask) ProtoMessage()    {}
+func (*CronTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e43b82f4f03f64b8, []int{1}
+}
+func (m *CronTask) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CronTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CronTask.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CronTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CronTask.Merge(m, src)
+}
+func (m *CronTask) XXX_Size() int {
+	return m.Size()
+}
+func (m *CronTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_CronTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CronTask proto.InternalMessageInfo
+
+type isCronTask_Sum interface {
+	isCronTask_Sum()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type CronTask_OrderbookExpireOrderMsg struct {
+	OrderbookExpireOrderMsg *orderbook.ExpireOrderMsg `protobuf:"bytes,105,opt,name=orderbook_expire_order_msg,json=orderbookExpireOrderMsg,proto3,oneof"`
+}
+type CronTask_OrderbookActivateStopsMsg struct {
+	OrderbookActivateStopsMsg *orderbook.ActivateStopsMsg `protobuf:"bytes,112,opt,name=orderbook_activate_stops_msg,json=orderbookActivateStopsMsg,proto3,oneof"`
+}
+
+func (*CronTask_OrderbookExpireOrderMsg) isCronTask_Sum()   {}
+func (*CronTask_OrderbookActivateStopsMsg) isCronTask_Sum() {}
+
+func (m *CronTask) GetSum() isCronTask_Sum {
+	if m != nil {
+		return m.Sum
+	}
+	return nil
+}
+
+func (m *CronTask) GetAuthenticators() []github_com_iov_one_weave.Condition {
+	if m != nil {
+		return m.Authenticators
+	}
+	return nil
+}
+
+func (m *CronTask) GetOrderbookExpireOrderMsg() *orderbook.ExpireOrderMsg {
+	if x, ok := m.GetSum().(*CronTask_OrderbookExpireOrderMsg); ok {
+		return x.OrderbookExpireOrderMsg
+	}
+	return nil
+}
+
+func (m *CronTask) GetOrderbookActivateStopsMsg() *orderbook.ActivateStopsMsg {
+	if x, ok := m.GetSum().(*CronTask_OrderbookActivateStopsMsg); ok {
+		return x.OrderbookActivateStopsMsg
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CronTask) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CronTask_OneofMarshaler, _CronTask_OneofUnmarshaler, _CronTask_OneofSizer, []interface{}{
+		(*CronTask_OrderbookExpireOrderMsg)(nil),
+		(*CronTask_OrderbookActivateStopsMsg)(nil),
+	}
+}
+
+func _CronTask_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CronTask)
+	// sum
+	switch x := m.Sum.(type) {
+	case *CronTask_OrderbookExpireOrderMsg:
+		_ = b.EncodeVarint(105<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookExpireOrderMsg); err != nil {
+			return err
+		}
+	case *CronTask_OrderbookActivateStopsMsg:
+		_ = b.EncodeVarint(112<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrderbookActivateStopsMsg); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("CronTask.Sum has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CronTask_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CronTask)
+	switch tag {
+	case 105: // sum.orderbook_expire_order_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.ExpireOrderMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &CronTask_OrderbookExpireOrderMsg{msg}
+		return true, err
+	case 112: // sum.orderbook_activate_stops_msg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(orderbook.ActivateStopsMsg)
+		err := b.DecodeMessage(msg)
+		m.Sum = &CronTask_OrderbookActivateStopsMsg{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CronTask_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CronTask)
+	// sum
+	switch x := m.Sum.(type) {
+	case *CronTask_OrderbookExpireOrderMsg:
+		s := proto.Size(x.OrderbookExpireOrderMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CronTask_OrderbookActivateStopsMsg:
+		s := proto.Size(x.OrderbookActivateStopsMsg)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -269,35 +685,54 @@ func _Tx_OneofSizer(msg proto.Message) (n int) {
 
 func init() {
 	proto.RegisterType((*Tx)(nil), "app.Tx")
+	proto.RegisterType((*CronTask)(nil), "app.CronTask")
 }
 
 func init() { proto.RegisterFile("app/codec.proto", fileDescriptor_e43b82f4f03f64b8) }
 
 var fileDescriptor_e43b82f4f03f64b8 = []byte{
-	// 366 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcf, 0x4e, 0xe2, 0x40,
-	0x1c, 0xc7, 0xdb, 0xed, 0xee, 0x86, 0x1d, 0x16, 0x49, 0x26, 0x18, 0x2a, 0xc6, 0x86, 0x78, 0x22,
-	0x1a, 0xa7, 0x09, 0x1c, 0xbd, 0x61, 0x42, 0xf4, 0x60, 0x4c, 0x8a, 0x07, 0x6f, 0x4d, 0xdb, 0xf9,
-	0x31, 0x34, 0xd0, 0x4e, 0xd3, 0x69, 0x91, 0xc7, 0xf0, 0xb1, 0x3c, 0x72, 0xf4, 0xa6, 0x81, 0x17,
-	0x31, 0x33, 0x60, 0x2d, 0xa1, 0xf1, 0x36, 0xdf, 0x3f, 0xfd, 0x7c, 0xa7, 0xc9, 0xa0, 0xa6, 0x97,
-	0x24, 0x76, 0xc0, 0x29, 0x04, 0x24, 0x49, 0x79, 0xc6, 0xb1, 0xe1, 0x25, 0x49, 0xe7, 0x92, 0x85,
-	0xd9, 0x34, 0xf7, 0x49, 0xc0, 0x23, 0x3b, 0xe4, 0x8b, 0x2b, 0x1e, 0x83, 0xfd, 0x0c, 0xde, 0x02,
-	0xec, 0xa5, 0x1d, 0x78, 0x62, 0x5a, 0xfe, 0xe2, 0xc7, 0xb2, 0x08, 0x99, 0xd8, 0x2b, 0xb7, 0x18,
-	0x67, 0x5c, 0x1d, 0x6d, 0x79, 0xda, 0xb9, 0xed, 0xa5, 0xcd, 0x53, 0x0a, 0xa9, 0xcf, 0xf9, 0xac,
-	0x5c, 0x3f, 0x7f, 0x37, 0xd0, 0xaf, 0xc7, 0x25, 0xbe, 0x40, 0xff, 0xe4, 0xac, 0x3b, 0x01, 0x10,
-	0x66, 0xab, 0xab, 0xf7, 0xea, 0xfd, 0x06, 0x91, 0x0e, 0x19, 0x01, 0xdc, 0xc5, 0x13, 0xee, 0xd4,
-	0xa4, 0x1a, 0x01, 0x08, 0x7c, 0x8d, 0x9a, 0x72, 0xd5, 0x15, 0x21, 0x8b, 0xbd, 0x2c, 0x4f, 0x41,
-	0x98, 0xc7, 0x5d, 0xa3, 0x57, 0xef, 0x63, 0x22, 0x7d, 0x32, 0xce, 0xe8, 0xf8, 0x2b, 0x72, 0x8e,
-	0xa4, 0x55, 0x48, 0x81, 0x3b, 0xa8, 0x16, 0xe5, 0xf3, 0x2c, 0x14, 0x21, 0x33, 0x7f, 0x77, 0x8d,
-	0xde, 0x7f, 0xa7, 0xd0, 0x78, 0x80, 0x1a, 0xea, 0x12, 0x02, 0x62, 0xea, 0x46, 0x82, 0x99, 0x83,
-	0xf2, 0x45, 0xc6, 0x10, 0xd3, 0x7b, 0xc1, 0x6e, 0x35, 0xa7, 0x2e, 0xf5, 0x4e, 0x62, 0x8a, 0xac,
-	0xe2, 0xcf, 0xdc, 0x20, 0x05, 0x2f, 0x03, 0xf7, 0xdb, 0x90, 0x14, 0xaa, 0x28, 0x67, 0xa4, 0x70,
-	0xc9, 0x8d, 0xaa, 0x3d, 0x48, 0x3d, 0xe4, 0x7c, 0xb6, 0xa5, 0x9e, 0x16, 0x79, 0x29, 0xf6, 0xb7,
-	0x31, 0x7e, 0x42, 0x9d, 0xea, 0x15, 0xb5, 0x00, 0x6a, 0xe1, 0xa4, 0x7a, 0x61, 0x4b, 0x6f, 0x57,
-	0xd1, 0x0f, 0xc9, 0x5e, 0x1c, 0xc0, 0xbc, 0x44, 0x9e, 0x1c, 0x92, 0x55, 0xa5, 0x9a, 0xbc, 0x17,
-	0x0d, 0xff, 0x20, 0x43, 0xe4, 0xd1, 0xd0, 0x7c, 0x5d, 0x5b, 0xfa, 0x6a, 0x6d, 0xe9, 0x1f, 0x6b,
-	0x4b, 0x7f, 0xd9, 0x58, 0xda, 0x6a, 0x63, 0x69, 0x6f, 0x1b, 0x4b, 0xf3, 0xff, 0xaa, 0x27, 0x30,
-	0xf8, 0x0c, 0x00, 0x00, 0xff, 0xff, 0xa7, 0xd5, 0x97, 0x29, 0xa3, 0x02, 0x00, 0x00,
+	// 654 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0xc1, 0x6f, 0xd3, 0x3e,
+	0x18, 0x6d, 0xd7, 0xdf, 0x0f, 0x0d, 0x8f, 0x6d, 0x92, 0x35, 0xb4, 0xac, 0x63, 0xe9, 0xd6, 0x03,
+	0x9a, 0x40, 0x24, 0xd2, 0x76, 0xe4, 0xb4, 0x56, 0x4c, 0x70, 0x18, 0x93, 0xd2, 0x21, 0x21, 0x21,
+	0x51, 0xb9, 0x89, 0x9b, 0x9a, 0x24, 0xb6, 0x15, 0x3b, 0x5b, 0xff, 0x06, 0x4e, 0xdc, 0xf9, 0x87,
+	0x38, 0xee, 0xc8, 0x09, 0xa1, 0xed, 0xbf, 0xe0, 0x84, 0xec, 0x64, 0xa9, 0x9b, 0xa4, 0xdc, 0xf2,
+	0x7d, 0xef, 0xe5, 0xbd, 0xaf, 0x9f, 0x9f, 0x53, 0xb0, 0x8d, 0x38, 0x77, 0x7d, 0x16, 0x60, 0xdf,
+	0xe1, 0x29, 0x93, 0x0c, 0x76, 0x10, 0xe7, 0xdd, 0x97, 0x21, 0x91, 0xb3, 0x6c, 0xe2, 0xf8, 0x2c,
+	0x71, 0x09, 0xbb, 0x7e, 0xc5, 0x28, 0x76, 0x6f, 0x30, 0xba, 0xc6, 0xee, 0xdc, 0xf5, 0x91, 0x98,
+	0x99, 0x6f, 0xfc, 0x93, 0x2c, 0x48, 0x28, 0x96, 0xc8, 0x3b, 0x21, 0x0b, 0x99, 0x7e, 0x74, 0xd5,
+	0x53, 0xd1, 0xdd, 0x9d, 0xbb, 0x2c, 0x0d, 0x70, 0x3a, 0x61, 0x2c, 0x32, 0xe9, 0xfd, 0xaf, 0x00,
+	0xac, 0x5d, 0xcd, 0xe1, 0x0b, 0xf0, 0x58, 0xd9, 0x8e, 0xa7, 0x18, 0x0b, 0x6b, 0xe7, 0xb0, 0x7d,
+	0xbc, 0x71, 0xb2, 0xe9, 0xa8, 0x8e, 0x73, 0x8e, 0xf1, 0x3b, 0x3a, 0x65, 0xde, 0xba, 0xaa, 0xce,
+	0x31, 0x16, 0xf0, 0x35, 0xd8, 0x56, 0xae, 0x63, 0x41, 0x42, 0x8a, 0x64, 0x96, 0x62, 0x61, 0x3d,
+	0x3d, 0xec, 0x1c, 0x6f, 0x9c, 0x40, 0x47, 0xf5, 0x9d, 0x91, 0x0c, 0x46, 0x0f, 0x90, 0xb7, 0xa5,
+	0x5a, 0x65, 0x29, 0x60, 0x17, 0xac, 0x27, 0x59, 0x2c, 0x89, 0x20, 0xa1, 0xf5, 0xdf, 0x61, 0xe7,
+	0xf8, 0x89, 0x57, 0xd6, 0xf0, 0x14, 0x6c, 0xea, 0x21, 0x04, 0xa6, 0xc1, 0x38, 0x11, 0xa1, 0x75,
+	0x6a, 0x0e, 0x32, 0xc2, 0x34, 0xb8, 0x10, 0xe1, 0xdb, 0x96, 0xb7, 0xa1, 0xea, 0xa2, 0x84, 0x01,
+	0xb0, 0xcb, 0x5f, 0x36, 0xf6, 0x53, 0x8c, 0x24, 0x1e, 0x2f, 0x1a, 0x4a, 0x25, 0xd0, 0x2a, 0x07,
+	0x4e, 0xd9, 0x75, 0x86, 0x9a, 0x76, 0xa9, 0xea, 0x01, 0x63, 0x51, 0xae, 0xba, 0x5f, 0xe2, 0x06,
+	0x3c, 0xc9, 0x61, 0xf8, 0x11, 0x74, 0x9b, 0x5d, 0xb4, 0x03, 0xd6, 0x0e, 0x7b, 0xcd, 0x0e, 0xb9,
+	0xfa, 0x6e, 0x93, 0x7a, 0x5d, 0x19, 0x51, 0x1f, 0xc7, 0x86, 0xf2, 0xb4, 0xae, 0xac, 0x29, 0xcd,
+	0xca, 0x4b, 0x10, 0xfc, 0x04, 0xf6, 0x6b, 0x33, 0x27, 0x28, 0x8d, 0xb0, 0xd4, 0xd2, 0xa1, 0x96,
+	0xee, 0xd6, 0x86, 0xbe, 0xd0, 0x94, 0x5c, 0xdb, 0xaa, 0x4c, 0x5d, 0x62, 0x30, 0x02, 0x47, 0x0b,
+	0xf1, 0x8c, 0x07, 0x86, 0x38, 0xbb, 0xa1, 0xc5, 0xf4, 0x33, 0x6d, 0xd1, 0x33, 0x2c, 0x3e, 0xf0,
+	0xa0, 0x94, 0xb9, 0x54, 0xbc, 0xdc, 0xe7, 0xa0, 0x64, 0x34, 0x11, 0x20, 0x07, 0xfd, 0x9a, 0x99,
+	0xcf, 0xe8, 0x94, 0x84, 0x59, 0x8a, 0x24, 0x61, 0x54, 0xbb, 0x7d, 0xd1, 0x6e, 0x47, 0x35, 0xb7,
+	0xa1, 0xc9, 0xcc, 0xfd, 0x7a, 0x15, 0xbf, 0x2a, 0x05, 0x62, 0xd0, 0xab, 0xed, 0x4e, 0x48, 0xc6,
+	0x8d, 0xa3, 0x89, 0x56, 0xc4, 0x6a, 0x24, 0x19, 0x37, 0x8e, 0xa7, 0x1a, 0x2b, 0x13, 0xae, 0xd8,
+	0xe4, 0x87, 0x5f, 0xb1, 0x89, 0xeb, 0x36, 0x9a, 0xb7, 0xda, 0xa6, 0x06, 0x2f, 0x27, 0x21, 0xc5,
+	0x3c, 0x46, 0xbe, 0x19, 0xdf, 0xa4, 0x96, 0x04, 0x2f, 0xe7, 0x18, 0xfa, 0x8b, 0x24, 0x54, 0xb0,
+	0xc6, 0x98, 0xe9, 0x86, 0xd0, 0xe2, 0x74, 0x45, 0xcc, 0xf4, 0xfb, 0xa2, 0x39, 0x66, 0x25, 0xd6,
+	0xb8, 0x20, 0x14, 0xc7, 0xa6, 0x01, 0x5b, 0xb1, 0xa0, 0xb3, 0x38, 0x36, 0x3d, 0xaa, 0x0b, 0x32,
+	0xe1, 0xc1, 0xff, 0xa0, 0x23, 0xb2, 0xa4, 0xff, 0x7d, 0x0d, 0xac, 0x0f, 0x53, 0x46, 0xaf, 0x90,
+	0x88, 0xe0, 0x7b, 0xb0, 0x85, 0x32, 0x39, 0xc3, 0x54, 0x12, 0x1f, 0x49, 0x96, 0x0a, 0xab, 0xad,
+	0xbe, 0x57, 0x83, 0xe7, 0x7f, 0x7e, 0xf5, 0xfa, 0xab, 0xbe, 0xc8, 0xce, 0x90, 0xd1, 0x80, 0xa8,
+	0x14, 0x79, 0x95, 0xb7, 0x97, 0x2f, 0x3a, 0x9e, 0x73, 0x92, 0x9a, 0x67, 0x40, 0x6a, 0x17, 0xfd,
+	0x8d, 0xa6, 0x34, 0x5e, 0xf4, 0x65, 0x08, 0x7e, 0x06, 0xcf, 0x16, 0xca, 0xc8, 0x97, 0xe4, 0xfa,
+	0x21, 0xae, 0xf9, 0x86, 0xb8, 0xd6, 0xde, 0x37, 0xb4, 0xcf, 0x0a, 0x92, 0x4a, 0x49, 0xb1, 0x9f,
+	0xbd, 0x12, 0xad, 0x82, 0xc5, 0x76, 0x06, 0xd6, 0x8f, 0x3b, 0xbb, 0x7d, 0x7b, 0x67, 0xb7, 0x7f,
+	0xdf, 0xd9, 0xed, 0x6f, 0xf7, 0x76, 0xeb, 0xf6, 0xde, 0x6e, 0xfd, 0xbc, 0xb7, 0x5b, 0x93, 0x47,
+	0xfa, 0xbf, 0xe4, 0xf4, 0xef, 0x00, 0x0e, 0x0e, 0x8a, 0x4b, 0xec, 0x06, 0x00, 0x00,
 }
 
 func (m *Tx) Marshal() (dAtA []byte, err error) {
@@ -423,25 +858,218 @@ func (m *Tx_OrderbookCancelOrderMsg) MarshalTo(dAtA []byte) (int, error) {
 	}
 	return i, nil
 }
-func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Tx_OrderbookCreateMarketMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookCreateMarketMsg != nil {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookCreateMarketMsg.Size()))
+		n7, err := m.OrderbookCreateMarketMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return i, nil
 }
-func (m *Tx) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Tx_OrderbookUpdateMarketOwnerMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookUpdateMarketOwnerMsg != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookUpdateMarketOwnerMsg.Size()))
+		n8, err := m.OrderbookUpdateMarketOwnerMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	var l int
-	_ = l
-	if len(m.Multisig) > 0 {
-		for _, b := range m.Multisig {
-			l = len(b)
-			n += 1 + l + sovCodec(uint64(l))
+	return i, nil
+}
+func (m *Tx_OrderbookUpdateConfigurationMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookUpdateConfigurationMsg != nil {
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookUpdateConfigurationMsg.Size()))
+		n9, err := m.OrderbookUpdateConfigurationMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
+func (m *Tx_OrderbookCreateStopOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookCreateStopOrderMsg != nil {
+		dAtA[i] = 0xda
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookCreateStopOrderMsg.Size()))
+		n10, err := m.OrderbookCreateStopOrderMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
+func (m *Tx_OrderbookCancelStopOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookCancelStopOrderMsg != nil {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookCancelStopOrderMsg.Size()))
+		n11, err := m.OrderbookCancelStopOrderMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+func (m *Tx_OrderbookReplaceOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookReplaceOrderMsg != nil {
+		dAtA[i] = 0xea
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookReplaceOrderMsg.Size()))
+		n12, err := m.OrderbookReplaceOrderMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	return i, nil
+}
+func (m *Tx_OrderbookCreateOrdersMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookCreateOrdersMsg != nil {
+		dAtA[i] = 0xf2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookCreateOrdersMsg.Size()))
+		n13, err := m.OrderbookCreateOrdersMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+func (m *Tx_OrderbookCancelAllOrdersMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookCancelAllOrdersMsg != nil {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookCancelAllOrdersMsg.Size()))
+		n14, err := m.OrderbookCancelAllOrdersMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+func (m *CronTask) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CronTask) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Authenticators) > 0 {
+		for _, b := range m.Authenticators {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.Sum != nil {
+		nn15, err := m.Sum.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn15
+	}
+	return i, nil
+}
+
+func (m *CronTask_OrderbookExpireOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookExpireOrderMsg != nil {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookExpireOrderMsg.Size()))
+		n16, err := m.OrderbookExpireOrderMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	return i, nil
+}
+func (m *CronTask_OrderbookActivateStopsMsg) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OrderbookActivateStopsMsg != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderbookActivateStopsMsg.Size()))
+		n17, err := m.OrderbookActivateStopsMsg.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Tx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Multisig) > 0 {
+		for _, b := range m.Multisig {
+			l = len(b)
+			n += 1 + l + sovCodec(uint64(l))
 		}
 	}
 	if m.CashFees != nil {
@@ -508,6 +1136,144 @@ func (m *Tx_OrderbookCancelOrderMsg) Size() (n int) {
 	}
 	return n
 }
+func (m *Tx_OrderbookCreateMarketMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookCreateMarketMsg != nil {
+		l = m.OrderbookCreateMarketMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookUpdateMarketOwnerMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookUpdateMarketOwnerMsg != nil {
+		l = m.OrderbookUpdateMarketOwnerMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookUpdateConfigurationMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookUpdateConfigurationMsg != nil {
+		l = m.OrderbookUpdateConfigurationMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookCreateStopOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookCreateStopOrderMsg != nil {
+		l = m.OrderbookCreateStopOrderMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookCancelStopOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookCancelStopOrderMsg != nil {
+		l = m.OrderbookCancelStopOrderMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookReplaceOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookReplaceOrderMsg != nil {
+		l = m.OrderbookReplaceOrderMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookCreateOrdersMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookCreateOrdersMsg != nil {
+		l = m.OrderbookCreateOrdersMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *Tx_OrderbookCancelAllOrdersMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookCancelAllOrdersMsg != nil {
+		l = m.OrderbookCancelAllOrdersMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *CronTask) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Authenticators) > 0 {
+		for _, b := range m.Authenticators {
+			l = len(b)
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	return n
+}
+
+func (m *CronTask_OrderbookExpireOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookExpireOrderMsg != nil {
+		l = m.OrderbookExpireOrderMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+func (m *CronTask_OrderbookActivateStopsMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderbookActivateStopsMsg != nil {
+		l = m.OrderbookActivateStopsMsg.Size()
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
 
 func sovCodec(x uint64) (n int) {
 	for {
@@ -793,6 +1559,441 @@ func (m *Tx) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Tx_OrderbookCancelOrderMsg{v}
 			iNdEx = postIndex
+		case 103:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookCreateMarketMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.CreateMarketMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookCreateMarketMsg{v}
+			iNdEx = postIndex
+		case 104:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookUpdateMarketOwnerMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.UpdateMarketOwnerMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookUpdateMarketOwnerMsg{v}
+			iNdEx = postIndex
+		case 106:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookUpdateConfigurationMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.UpdateConfigurationMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookUpdateConfigurationMsg{v}
+			iNdEx = postIndex
+		case 107:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookCreateStopOrderMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.CreateStopOrderMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookCreateStopOrderMsg{v}
+			iNdEx = postIndex
+		case 108:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookCancelStopOrderMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.CancelStopOrderMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookCancelStopOrderMsg{v}
+			iNdEx = postIndex
+		case 109:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookReplaceOrderMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.ReplaceOrderMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookReplaceOrderMsg{v}
+			iNdEx = postIndex
+		case 110:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookCreateOrdersMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.CreateOrdersMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookCreateOrdersMsg{v}
+			iNdEx = postIndex
+		case 111:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookCancelAllOrdersMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.CancelAllOrdersMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Tx_OrderbookCancelAllOrdersMsg{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CronTask) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CronTask: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CronTask: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Authenticators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Authenticators = append(m.Authenticators, make([]byte, postIndex-iNdEx))
+			copy(m.Authenticators[len(m.Authenticators)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 105:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookExpireOrderMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.ExpireOrderMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &CronTask_OrderbookExpireOrderMsg{v}
+			iNdEx = postIndex
+		case 112:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderbookActivateStopsMsg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &orderbook.ActivateStopsMsg{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &CronTask_OrderbookActivateStopsMsg{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/iov-one/tutorial/x/orderbook"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// CronTaskMarshaler is a task marshaler implementation to be used by the dex
+// application when dealing with scheduled tasks.
+//
+// This implementation relies on the CronTask protobuf declaration.
+var CronTaskMarshaler = taskMarshaler{}
+
+type taskMarshaler struct{}
+
+// MarshalTask implements cron.TaskMarshaler interface.
+func (taskMarshaler) MarshalTask(auth []weave.Condition, msg weave.Msg) ([]byte, error) {
+	t := CronTask{
+		Authenticators: auth,
+	}
+
+	switch msg := msg.(type) {
+	default:
+		return nil, errors.Wrapf(errors.ErrType, "unsupported message type: %T", msg)
+
+	case *orderbook.ExpireOrderMsg:
+		t.Sum = &CronTask_OrderbookExpireOrderMsg{
+			OrderbookExpireOrderMsg: msg,
+		}
+	case *orderbook.ActivateStopsMsg:
+		t.Sum = &CronTask_OrderbookActivateStopsMsg{
+			OrderbookActivateStopsMsg: msg,
+		}
+	}
+
+	raw, err := t.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal")
+	}
+	return raw, nil
+}
+
+// UnmarshalTask implements cron.TaskMarshaler interface.
+func (taskMarshaler) UnmarshalTask(raw []byte) ([]weave.Condition, weave.Msg, error) {
+	var t CronTask
+	if err := t.Unmarshal(raw); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot unmarshal")
+	}
+	msg, err := weave.ExtractMsgFromSum(t.GetSum())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot extract message")
+	}
+	return t.Authenticators, msg, nil
+}
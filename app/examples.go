@@ -0,0 +1,120 @@
+package app
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/iov-one/tutorial/x/orderbook"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/commands"
+	"github.com/iov-one/weave/crypto"
+	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/sigs"
+)
+
+// we fix the private keys here for deterministic output with the same encoding
+// these are not secure at all, but the only point is to check the format,
+// which is easier when everything is reproduceable.
+var (
+	source = makePrivKey("1234567890")
+	dst    = makePrivKey("F00BA411").PublicKey().Address()
+)
+
+// makePrivKey repeats the string as long as needed to get 64 digits, then
+// parses it as hex. It uses this repeated string as a "random" seed
+// for the private key.
+//
+// nothing random about it, but at least it gives us variety
+func makePrivKey(seed string) *crypto.PrivateKey {
+	rep := 64/len(seed) + 1
+	in := strings.Repeat(seed, rep)[:64]
+	bin, err := hex.DecodeString(in)
+	if err != nil {
+		panic(err)
+	}
+	return crypto.PrivKeyEd25519FromSeed(bin)
+}
+
+// Examples generates some example structs to dump out with testgen
+func Examples() []commands.Example {
+	wallet := &cash.Set{
+		Metadata: &weave.Metadata{Schema: 1},
+		Coins: []*coin.Coin{
+			{Whole: 50000, Ticker: "DEX"},
+			{Whole: 150, Fractional: 567000, Ticker: "IDEX"},
+		},
+	}
+
+	pub := source.PublicKey()
+	addr := pub.Address()
+	user := &sigs.UserData{
+		Metadata: &weave.Metadata{Schema: 1},
+		Pubkey:   pub,
+		Sequence: 17,
+	}
+
+	amt := coin.NewCoin(250, 0, "DEX")
+	msg := &cash.SendMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Amount:      &amt,
+		Destination: dst,
+		Source:      addr,
+		Memo:        "Test payment",
+	}
+
+	unsigned := Tx{
+		Sum: &Tx_CashSendMsg{msg},
+	}
+	tx := unsigned
+	sig, err := sigs.SignTx(source, &tx, "test-123", 17)
+	if err != nil {
+		panic(err)
+	}
+	tx.SigsSignatures = []*sigs.StdSignature{sig}
+
+	offer := coin.NewCoin(100, 0, "DEX")
+	createOrderMsg := &orderbook.CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      addr,
+		OrderBookID: sequenceID(1),
+		Offer:       &offer,
+		Price:       &orderbook.Amount{Whole: 2, Fractional: 500000000},
+	}
+	createOrderTx := &Tx{
+		Sum: &Tx_OrderbookCreateOrderMsg{createOrderMsg},
+	}
+
+	cancelOrderMsg := &orderbook.CancelOrderMsg{
+		Metadata: &weave.Metadata{Schema: 1},
+		OrderID:  sequenceID(1),
+	}
+	cancelOrderTx := &Tx{
+		Sum: &Tx_OrderbookCancelOrderMsg{cancelOrderMsg},
+	}
+
+	fmt.Printf("Address: %s\n", addr)
+	return []commands.Example{
+		{Filename: "wallet", Obj: wallet},
+		{Filename: "priv_key", Obj: source},
+		{Filename: "pub_key", Obj: pub},
+		{Filename: "user", Obj: user},
+		{Filename: "send_msg", Obj: msg},
+		{Filename: "unsigned_tx", Obj: &unsigned},
+		{Filename: "signed_tx", Obj: &tx},
+		{Filename: "orderbook_create_order_msg", Obj: createOrderMsg},
+		{Filename: "orderbook_create_order_tx", Obj: createOrderTx},
+		{Filename: "orderbook_cancel_order_msg", Obj: cancelOrderMsg},
+		{Filename: "orderbook_cancel_order_tx", Obj: cancelOrderTx},
+	}
+}
+
+// sequenceID encodes n the same way as the bucket sequences that generate
+// orderbook and order IDs
+func sequenceID(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
@@ -1,11 +1,13 @@
 package app
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
 
+	"github.com/iov-one/tutorial/x/orderbook"
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/app"
 	"github.com/iov-one/weave/coin"
@@ -14,6 +16,7 @@ import (
 	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/migration"
 	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/cron"
 	"github.com/iov-one/weave/x/currency"
 	"github.com/iov-one/weave/x/msgfee"
 	"github.com/iov-one/weave/x/multisig"
@@ -22,6 +25,10 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 )
 
+// feeTicker is the native token of the exchange, paired with the
+// genesis code in the main market
+const feeTicker = "IDEX"
+
 // GenInitOptions will produce some basic options for one rich
 // account, to use for dev mode
 func GenInitOptions(args []string) (json.RawMessage, error) {
@@ -29,6 +36,10 @@ func GenInitOptions(args []string) (json.RawMessage, error) {
 	if len(args) > 0 {
 		code = args[0]
 	}
+	// the orderbook pairs the code with the fee ticker
+	if code == feeTicker {
+		return nil, errors.Wrapf(errors.ErrInput, "code must not be the fee ticker %s", feeTicker)
+	}
 
 	var addr string
 	if len(args) > 1 {
@@ -44,10 +55,21 @@ func GenInitOptions(args []string) (json.RawMessage, error) {
 		fmt.Println(phrase)
 	}
 
+	// orderbooks require ask_ticker < bid_ticker
+	askTicker, bidTicker := code, feeTicker
+	if bidTicker < askTicker {
+		askTicker, bidTicker = bidTicker, askTicker
+	}
+
 	type (
 		dict  map[string]interface{}
 		array []interface{}
 	)
+	// fees paid in the fee ticker are discounted by 25%, at 1 IDEX per
+	// unit of the other ticker
+	feePrices := array{
+		dict{"ticker": code, "price": dict{"whole": 1}},
+	}
 	collectorAddr, err := hex.DecodeString("3b11c732b8fc1f09beb34031302fe2ab347c5c14")
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot hex decode collector address")
@@ -66,10 +88,26 @@ func GenInitOptions(args []string) (json.RawMessage, error) {
 		},
 		"currencies": array{
 			dict{
-				"ticker": "IDEX",
+				"ticker": feeTicker,
 				"name":   "Native Exchange TOKEN",
 			},
 		},
+		"markets": array{
+			dict{
+				"owner": addr,
+				"name":  "main-market",
+				// 0.1% for makers and 0.2% for takers
+				"maker_fee":     dict{"fractional": 1000000},
+				"taker_fee":     dict{"fractional": 2000000},
+				"fee_collector": weave.Address(collectorAddr),
+				"orderbooks": array{
+					dict{
+						"ask_ticker": askTicker,
+						"bid_ticker": bidTicker,
+					},
+				},
+			},
+		},
 
 		"conf": dict{
 			"cash": cash.Configuration{
@@ -79,12 +117,22 @@ func GenInitOptions(args []string) (json.RawMessage, error) {
 			"migration": dict{
 				"admin": addr,
 			},
+			"orderbook": dict{
+				"owner":        addr,
+				"fee_ticker":   feeTicker,
+				"fee_discount": dict{"fractional": 250000000},
+				"fee_prices":   feePrices,
+			},
 		},
 		"initialize_schema": []dict{
 			{"pkg": "cash", "ver": 1},
 			{"pkg": "sigs", "ver": 1},
 			{"pkg": "validators", "ver": 1},
 			{"pkg": "utils", "ver": 1},
+			// required to load the currencies above
+			{"pkg": "currency", "ver": 1},
+			{"pkg": "orderbook", "ver": 1},
+			{"pkg": "cron", "ver": 1},
 		},
 	})
 }
@@ -115,11 +163,22 @@ func DecorateApp(application app.BaseApp, logger log.Logger) app.BaseApp {
 		&currency.Initializer{},
 		&validators.Initializer{},
 		&msgfee.Initializer{},
+		&orderbook.Initializer{},
 	))
 	application.WithLogger(logger)
 	return application
 }
 
+// InlineApp will take a previously prepared CommitStore and return a complete Application
+func InlineApp(kv weave.CommitKVStore, logger log.Logger, debug bool) abci.Application {
+	stack := Stack(coin.Coin{})
+	ctx := context.Background()
+	store := app.NewStoreApp("dex", kv, QueryRouter(), ctx)
+	ticker := cron.NewTicker(CronStack(), CronTaskMarshaler)
+	base := app.NewBaseApp(store, TxDecoder, stack, ticker, debug)
+	return DecorateApp(base, logger)
+}
+
 // GenerateCoinKey returns the address of a public key,
 // along with the secret phrase to recover the private key.
 // You can give coins to this address and return the recovery
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/iov-one/tutorial/x/orderbook"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/app"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store/iavl"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestGenInitOptions(t *testing.T) {
+	addr := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		code          string
+		wantErr       *errors.Error
+		wantAskTicker string
+		wantBidTicker string
+	}{
+		"code before the fee ticker": {
+			code:          "DEX",
+			wantAskTicker: "DEX",
+			wantBidTicker: "IDEX",
+		},
+		"code after the fee ticker": {
+			code:          "ZEC",
+			wantAskTicker: "IDEX",
+			wantBidTicker: "ZEC",
+		},
+		"fee ticker as code": {
+			code:    "IDEX",
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			genesis, err := GenInitOptions([]string{tc.code, addr.String()})
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			// InitChain panics if the genesis cannot be loaded
+			abciApp := InlineApp(iavl.MockCommitStore(), log.NewNopLogger(), false)
+			abciApp.InitChain(abci.RequestInitChain{
+				ChainId:       "test-chain",
+				AppStateBytes: genesis,
+			})
+			abciApp.Commit()
+
+			res := abciApp.Query(abci.RequestQuery{Path: "/orderbooks", Data: sequenceID(1)})
+			assert.Equal(t, uint32(0), res.Code)
+			var values app.ResultSet
+			assert.Nil(t, values.Unmarshal(res.Value))
+			assert.Equal(t, 1, len(values.Results))
+			var ob orderbook.OrderBook
+			assert.Nil(t, ob.Unmarshal(values.Results[0]))
+			assert.Equal(t, tc.wantAskTicker, ob.AskTicker)
+			assert.Equal(t, tc.wantBidTicker, ob.BidTicker)
+
+			res = abciApp.Query(abci.RequestQuery{Path: "/markets", Data: sequenceID(1)})
+			assert.Equal(t, uint32(0), res.Code)
+			var markets app.ResultSet
+			assert.Nil(t, markets.Unmarshal(res.Value))
+			assert.Equal(t, 1, len(markets.Results))
+			var market orderbook.Market
+			assert.Nil(t, market.Unmarshal(markets.Results[0]))
+			assert.Equal(t, orderbook.NewAmountp(0, 1000000), market.MakerFee)
+			assert.Equal(t, orderbook.NewAmountp(0, 2000000), market.TakerFee)
+			collector, err := weave.ParseAddress("3b11c732b8fc1f09beb34031302fe2ab347c5c14")
+			assert.Nil(t, err)
+			assert.Equal(t, collector, market.FeeCollector)
+		})
+	}
+}
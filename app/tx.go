@@ -2,6 +2,9 @@ package app
 
 import (
 	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/x/cash"
+	"github.com/iov-one/weave/x/multisig"
+	"github.com/iov-one/weave/x/sigs"
 )
 
 // TxDecoder creates a Tx and unmarshals bytes into it
@@ -16,8 +19,35 @@ func TxDecoder(bz []byte) (weave.Tx, error) {
 
 // make sure tx fulfills all interfaces
 var _ weave.Tx = (*Tx)(nil)
+var _ cash.FeeTx = (*Tx)(nil)
+var _ sigs.SignedTx = (*Tx)(nil)
+var _ multisig.MultiSigTx = (*Tx)(nil)
 
 // GetMsg switches over all types defined in the protobuf file
 func (tx *Tx) GetMsg() (weave.Msg, error) {
 	return weave.ExtractMsgFromSum(tx.GetSum())
 }
+
+// GetSignBytes returns the bytes to sign...
+func (tx *Tx) GetSignBytes() ([]byte, error) {
+	// temporarily unset the signatures, as the sign bytes
+	// should only come from the data itself, not previous signatures
+	sigs := tx.SigsSignatures
+	tx.SigsSignatures = nil
+
+	bz, err := tx.Marshal()
+
+	// reset the signatures after calculating the bytes
+	tx.SigsSignatures = sigs
+	return bz, err
+}
+
+// GetFees exposes the cash_fees field as required by cash.FeeTx
+func (tx *Tx) GetFees() *cash.FeeInfo {
+	return tx.GetCashFees()
+}
+
+// GetSignatures exposes the sigs_signatures field as required by sigs.SignedTx
+func (tx *Tx) GetSignatures() []*sigs.StdSignature {
+	return tx.GetSigsSignatures()
+}
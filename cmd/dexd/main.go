@@ -1,5 +1,83 @@
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dex "github.com/iov-one/tutorial/app"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/commands"
+	"github.com/iov-one/weave/commands/server"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var (
+	flagHome = "home"
+	varHome  *string
+)
+
+func init() {
+	defaultHome := filepath.Join(os.ExpandEnv("$HOME"), ".dex")
+	varHome = flag.String(flagHome, defaultHome, "directory to store files under")
+
+	flag.CommandLine.Usage = helpMessage
+}
+
+func helpMessage() {
+	fmt.Println("dexd")
+	fmt.Println("          Decentralized exchange node")
+	fmt.Println("")
+	fmt.Println("help      Print this message")
+	fmt.Println("init      Initialize app options in genesis file")
+	fmt.Println("start     Run the abci server")
+	fmt.Println("getblock  Extract a block from blockchain.db")
+	fmt.Println("retry     Run last block again to ensure it produces same result")
+	fmt.Println("testgen   Write sample data structures, json and binary, to a directory")
+	fmt.Println("version   Print the app version")
+	fmt.Println(`
+  -home string
+        directory to store files under (default "$HOME/.dex")`)
+}
+
 func main() {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).
+		With("module", "dex")
+
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Println("Missing command:")
+		helpMessage()
+		os.Exit(1)
+	}
+
+	cmd := flag.Arg(0)
+	rest := flag.Args()[1:]
+
+	var err error
+	switch cmd {
+	case "help":
+		helpMessage()
+	case "init":
+		err = server.InitCmd(dex.GenInitOptions, logger, *varHome, rest)
+	case "start":
+		err = server.StartCmd(dex.GenerateApp, logger, *varHome, rest)
+	case "getblock":
+		err = server.GetBlockCmd(rest)
+	case "retry":
+		err = server.RetryCmd(dex.InlineApp, logger, *varHome, rest)
+	case "testgen":
+		err = commands.TestGenCmd(dex.Examples(), rest)
+	case "version":
+		fmt.Println(weave.Version)
+	default:
+		err = fmt.Errorf("unknown command: %s", cmd)
+	}
 
+	if err != nil {
+		fmt.Printf("Error: %+v\n\n", err)
+		helpMessage()
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,205 @@
+package morm
+
+import (
+	"bytes"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/orm"
+)
+
+// index maintains a secondary index of a ModelBucket directly in the KVStore.
+//
+// The layout is the same as used by orm.Bucket: every index value is stored
+// under _i.<bucket>_<index>:<value>. For a unique index the stored value is
+// the primary key of the referenced model, otherwise it is an orm.MultiRef
+// holding all primary keys, sorted.
+//
+// A model can be indexed under any number of values.
+type index struct {
+	name string
+	// prefix is the kvstore prefix used for all items in the index
+	prefix  []byte
+	unique  bool
+	indexer orm.MultiKeyIndexer
+}
+
+func newIndex(bucketName, name string, indexer orm.MultiKeyIndexer, unique bool) *index {
+	return &index{
+		name:    name,
+		prefix:  indexPrefix(bucketName, name),
+		unique:  unique,
+		indexer: indexer,
+	}
+}
+
+func indexPrefix(bucketName, indexName string) []byte {
+	path := "_i." + bucketName + "_" + indexName + ":"
+	return []byte(path)
+}
+
+// singleKey turns an indexer into a MultiKeyIndexer, indexing a model under
+// one value, or none if the indexer returns nil
+func singleKey(indexer orm.Indexer) orm.MultiKeyIndexer {
+	return func(obj orm.Object) ([][]byte, error) {
+		value, err := indexer(obj)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		return [][]byte{value}, nil
+	}
+}
+
+// values returns the distinct index values of the given model, none for no
+// model
+func (i *index) values(key []byte, m Model) ([][]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	values, err := i.indexer(orm.NewSimpleObj(key, m))
+	if err != nil {
+		return nil, err
+	}
+	return subtract(values, nil), nil
+}
+
+// update moves the references to the model with the given primary key from
+// the index values of prev to the ones of next. Either of them can be nil when
+// a model is created or deleted.
+func (i *index) update(db weave.KVStore, key []byte, prev, next Model) error {
+	old, err := i.values(key, prev)
+	if err != nil {
+		return errors.Wrapf(err, "index %s", i.name)
+	}
+	now, err := i.values(key, next)
+	if err != nil {
+		return errors.Wrapf(err, "index %s", i.name)
+	}
+
+	for _, value := range subtract(old, now) {
+		if err := i.remove(db, value, key); err != nil {
+			return err
+		}
+	}
+	for _, value := range subtract(now, old) {
+		if err := i.insert(db, value, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subtract returns all distinct values of minuend that are not in subtrahend
+func subtract(minuend, subtrahend [][]byte) [][]byte {
+	var res [][]byte
+	for _, m := range minuend {
+		if !containsKey(subtrahend, m) && !containsKey(res, m) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *index) insert(db weave.KVStore, value, key []byte) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	dbKey := joinKey(i.prefix, value)
+	cur, err := db.Get(dbKey)
+	if err != nil {
+		return err
+	}
+	if i.unique {
+		if cur != nil {
+			return errors.Wrap(errors.ErrDuplicate, i.name)
+		}
+		return db.Set(dbKey, key)
+	}
+
+	refs := new(orm.MultiRef)
+	if cur != nil {
+		if err := refs.Unmarshal(cur); err != nil {
+			return errors.Wrap(err, "parsing index refs")
+		}
+	}
+	if err := refs.Add(key); err != nil {
+		return err
+	}
+	raw, err := refs.Marshal()
+	if err != nil {
+		return err
+	}
+	return db.Set(dbKey, raw)
+}
+
+func (i *index) remove(db weave.KVStore, value, key []byte) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	dbKey := joinKey(i.prefix, value)
+	cur, err := db.Get(dbKey)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return errors.Wrap(errors.ErrNotFound, "cannot remove index from nothing")
+	}
+	if i.unique {
+		if !bytes.Equal(cur, key) {
+			return errors.Wrap(errors.ErrNotFound, "cannot remove index from invalid object")
+		}
+		return db.Delete(dbKey)
+	}
+
+	refs := new(orm.MultiRef)
+	if err := refs.Unmarshal(cur); err != nil {
+		return errors.Wrap(err, "parsing index refs")
+	}
+	if err := refs.Remove(key); err != nil {
+		return err
+	}
+	if len(refs.Refs) == 0 {
+		return db.Delete(dbKey)
+	}
+	raw, err := refs.Marshal()
+	if err != nil {
+		return err
+	}
+	return db.Set(dbKey, raw)
+}
+
+// refs returns the primary keys of all models indexed by the given value
+func (i *index) refs(db weave.ReadOnlyKVStore, value []byte) ([][]byte, error) {
+	raw, err := db.Get(joinKey(i.prefix, value))
+	if err != nil {
+		return nil, err
+	}
+	return parseRefs(raw, i.unique)
+}
+
+// parseRefs takes a value stored in an index and parse it into a slice of
+// primary keys
+func parseRefs(raw []byte, unique bool) ([][]byte, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if unique {
+		return [][]byte{raw}, nil
+	}
+	refs := new(orm.MultiRef)
+	if err := refs.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+	return refs.GetRefs(), nil
+}
@@ -0,0 +1,216 @@
+package morm
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// Lexographic is implemented by values with an order preserving binary
+// encoding, such that A.Lexographic() < B.Lexographic() == A < B
+type Lexographic interface {
+	Lexographic() ([]byte, error)
+}
+
+// IndexKey builds a compound index value, in SQL parlance, out of fixed width
+// fields. As every field has a fixed width, the byte order of the built keys
+// is the order of their fields, first to last. That allows prefix and range
+// scans over the leading fields, for example all open orders of one side of
+// an orderbook, ordered by price:
+//
+//	key, err := morm.NewIndexKey().
+//		Bytes(order.OrderBookID, 8).
+//		Byte(byte(order.Side)).
+//		Lexographic(order.Price, 16).
+//		Key()
+//
+// The first error is kept and returned by Key, all fields added after it are
+// ignored. Use IndexKeyReader to decode the fields.
+type IndexKey struct {
+	buf []byte
+	err error
+}
+
+// NewIndexKey returns an empty IndexKey
+func NewIndexKey() *IndexKey {
+	return &IndexKey{}
+}
+
+// Key returns the index value, or the first error that occurred while
+// adding the fields
+func (k *IndexKey) Key() ([]byte, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+	return k.buf, nil
+}
+
+// Bytes appends b, padded with zeros on the right to the given width.
+// An ID is always a good fit for this, with a width of 8 for sequence IDs.
+func (k *IndexKey) Bytes(b []byte, width int) *IndexKey {
+	if k.err != nil {
+		return k
+	}
+	if len(b) > width {
+		k.err = errors.Wrapf(errors.ErrInput, "%d bytes exceed the field width %d", len(b), width)
+		return k
+	}
+	k.buf = append(k.buf, b...)
+	k.buf = append(k.buf, make([]byte, width-len(b))...)
+	return k
+}
+
+// String appends s, padded with zeros on the right to the given width
+func (k *IndexKey) String(s string, width int) *IndexKey {
+	return k.Bytes([]byte(s), width)
+}
+
+// Byte appends a single byte, for example an enum value
+func (k *IndexKey) Byte(b byte) *IndexKey {
+	return k.Bytes([]byte{b}, 1)
+}
+
+// Uint64 appends v as 8 bytes big-endian
+func (k *IndexKey) Uint64(v uint64) *IndexKey {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, v)
+	return k.Bytes(raw, 8)
+}
+
+// Int64 appends v as 8 bytes big-endian. Negative values are rejected, as
+// they would sort after all positive ones.
+func (k *IndexKey) Int64(v int64) *IndexKey {
+	if k.err == nil && v < 0 {
+		k.err = errors.Wrapf(errors.ErrInput, "cannot index negative value %d", v)
+		return k
+	}
+	return k.Uint64(uint64(v))
+}
+
+// Time appends t as 8 bytes big-endian seconds. Times before the epoch are
+// rejected.
+func (k *IndexKey) Time(t weave.UnixTime) *IndexKey {
+	return k.Int64(int64(t))
+}
+
+// SignedTime appends t as 8 bytes big-endian seconds with the sign bit
+// flipped, so times before the epoch sort before the ones after it
+func (k *IndexKey) SignedTime(t weave.UnixTime) *IndexKey {
+	return k.Uint64(uint64(t) ^ 1<<63)
+}
+
+// Address appends a, which must be a valid address of weave.AddressLength
+// bytes
+func (k *IndexKey) Address(a weave.Address) *IndexKey {
+	if k.err == nil {
+		if err := a.Validate(); err != nil {
+			k.err = errors.Wrap(err, "address")
+			return k
+		}
+	}
+	return k.Bytes(a, weave.AddressLength)
+}
+
+// Lexographic appends the lexographic encoding of v, which must be of the
+// given width
+func (k *IndexKey) Lexographic(v Lexographic, width int) *IndexKey {
+	if k.err != nil {
+		return k
+	}
+	raw, err := v.Lexographic()
+	if err != nil {
+		k.err = err
+		return k
+	}
+	if len(raw) != width {
+		k.err = errors.Wrapf(errors.ErrInput, "lexographic encoding of %d bytes, expected %d", len(raw), width)
+		return k
+	}
+	return k.Bytes(raw, width)
+}
+
+// IndexKeyReader decodes the fields of an index value built with IndexKey.
+// Fields must be read in the order they were added, using the same widths.
+//
+// As with IndexKey, the first error is kept and returned by Done. All reads
+// after an error return zero values.
+type IndexKeyReader struct {
+	key []byte
+	err error
+}
+
+// NewIndexKeyReader returns a reader for the fields of the given index value
+func NewIndexKeyReader(key []byte) *IndexKeyReader {
+	return &IndexKeyReader{key: key}
+}
+
+// Done returns the first error that occurred while reading the fields. It
+// also fails if the index value was not read completely.
+func (r *IndexKeyReader) Done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.key) != 0 {
+		return errors.Wrapf(errors.ErrInput, "%d bytes left in the index key", len(r.key))
+	}
+	return nil
+}
+
+// Bytes returns the next width bytes, including any padding
+func (r *IndexKeyReader) Bytes(width int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.key) < width {
+		r.err = errors.Wrapf(errors.ErrInput, "index key too short, %d bytes left for a %d bytes field", len(r.key), width)
+		return nil
+	}
+	res := r.key[:width]
+	r.key = r.key[width:]
+	return res
+}
+
+// String returns the next width bytes as a string, without the padding
+func (r *IndexKeyReader) String(width int) string {
+	return string(bytes.TrimRight(r.Bytes(width), "\x00"))
+}
+
+// Byte returns the next byte
+func (r *IndexKeyReader) Byte() byte {
+	raw := r.Bytes(1)
+	if raw == nil {
+		return 0
+	}
+	return raw[0]
+}
+
+// Uint64 returns the next 8 bytes as a big-endian integer
+func (r *IndexKeyReader) Uint64() uint64 {
+	raw := r.Bytes(8)
+	if raw == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(raw)
+}
+
+// Int64 returns the next 8 bytes as a big-endian integer
+func (r *IndexKeyReader) Int64() int64 {
+	return int64(r.Uint64())
+}
+
+// Time returns the next 8 bytes as a big-endian time in seconds
+func (r *IndexKeyReader) Time() weave.UnixTime {
+	return weave.UnixTime(r.Int64())
+}
+
+// SignedTime returns the next 8 bytes as a time added with SignedTime
+func (r *IndexKeyReader) SignedTime() weave.UnixTime {
+	return weave.UnixTime(r.Uint64() ^ 1<<63)
+}
+
+// Address returns the next weave.AddressLength bytes as an address
+func (r *IndexKeyReader) Address() weave.Address {
+	return weave.Address(r.Bytes(weave.AddressLength))
+}
@@ -0,0 +1,144 @@
+package morm
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+type lex []byte
+
+func (l lex) Lexographic() ([]byte, error) {
+	if l == nil {
+		return nil, errors.ErrEmpty
+	}
+	return l, nil
+}
+
+func TestIndexKey(t *testing.T) {
+	addr := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		key     *IndexKey
+		wantKey []byte
+		wantErr *errors.Error
+	}{
+		"compound key": {
+			key: NewIndexKey().
+				Bytes(weavetest.SequenceID(5), 8).
+				Byte(2).
+				String("ETH", 5).
+				Uint64(0x0102).
+				Time(weave.UnixTime(7)).
+				Lexographic(lex{9, 9}, 2),
+			wantKey: []byte{
+				0, 0, 0, 0, 0, 0, 0, 5,
+				2,
+				'E', 'T', 'H', 0, 0,
+				0, 0, 0, 0, 0, 0, 1, 2,
+				0, 0, 0, 0, 0, 0, 0, 7,
+				9, 9,
+			},
+		},
+		"signed times keep their order": {
+			key: NewIndexKey().
+				SignedTime(weave.UnixTime(-1)).
+				SignedTime(weave.UnixTime(0)).
+				SignedTime(weave.UnixTime(7)),
+			wantKey: []byte{
+				0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0x80, 0, 0, 0, 0, 0, 0, 0,
+				0x80, 0, 0, 0, 0, 0, 0, 7,
+			},
+		},
+		"short bytes are padded": {
+			key:     NewIndexKey().Bytes([]byte{1}, 3).Byte(4),
+			wantKey: []byte{1, 0, 0, 4},
+		},
+		"address": {
+			key:     NewIndexKey().Address(addr),
+			wantKey: addr,
+		},
+		"bytes too long": {
+			key:     NewIndexKey().Bytes([]byte{1, 2, 3}, 2),
+			wantErr: errors.ErrInput,
+		},
+		"string too long": {
+			key:     NewIndexKey().String("BARZOO", 5),
+			wantErr: errors.ErrInput,
+		},
+		"negative int": {
+			key:     NewIndexKey().Int64(-1),
+			wantErr: errors.ErrInput,
+		},
+		"invalid address": {
+			key:     NewIndexKey().Address(weave.Address{1, 2, 3}),
+			wantErr: errors.ErrInput,
+		},
+		"lexographic encoding failed": {
+			key:     NewIndexKey().Lexographic(lex(nil), 2),
+			wantErr: errors.ErrEmpty,
+		},
+		"lexographic of wrong width": {
+			key:     NewIndexKey().Lexographic(lex{1, 2, 3}, 2),
+			wantErr: errors.ErrInput,
+		},
+		"first error is kept": {
+			key:     NewIndexKey().Int64(-1).Address(nil).Byte(1),
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			key, err := tc.key.Key()
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, tc.wantKey, key)
+		})
+	}
+}
+
+func TestIndexKeyReader(t *testing.T) {
+	addr := weavetest.NewCondition().Address()
+	key, err := NewIndexKey().
+		Bytes(weavetest.SequenceID(5), 8).
+		Byte(2).
+		String("ETH", 5).
+		Int64(1234).
+		Time(weave.UnixTime(7)).
+		SignedTime(weave.UnixTime(-62135596800)).
+		Address(addr).
+		Key()
+	assert.Nil(t, err)
+
+	r := NewIndexKeyReader(key)
+	assert.Equal(t, weavetest.SequenceID(5), r.Bytes(8))
+	assert.Equal(t, byte(2), r.Byte())
+	assert.Equal(t, "ETH", r.String(5))
+	assert.Equal(t, int64(1234), r.Int64())
+	assert.Equal(t, weave.UnixTime(7), r.Time())
+	assert.Equal(t, weave.UnixTime(-62135596800), r.SignedTime())
+	assert.Equal(t, addr, r.Address())
+	assert.Nil(t, r.Done())
+
+	// not read completely
+	r = NewIndexKeyReader(key)
+	r.Bytes(8)
+	if err := r.Done(); !errors.ErrInput.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	// reading past the end
+	r = NewIndexKeyReader(key[:10])
+	r.Bytes(8)
+	assert.Equal(t, uint64(0), r.Uint64())
+	assert.Equal(t, "", r.String(1))
+	if err := r.Done(); !errors.ErrInput.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
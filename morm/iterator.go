@@ -2,17 +2,44 @@ package morm
 
 import (
 	"bytes"
+	"reflect"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
-	"github.com/iov-one/weave/orm"
 )
 
+// ErrIteratorDone is returned by ModelIterator.LoadNext once there are no
+// more models to load. It is the same error as weave errors.ErrIteratorDone,
+// so either can be used to test for it.
+var ErrIteratorDone = errors.ErrIteratorDone
+
+// ModelIterator iterates over the models of a ModelBucket.
+//
+// The iterator is always positioned on the model that the next call to
+// LoadNext will load. Valid, Key and IndexKey describe that model without
+// loading it, which allows the caller to stop iterating, for example at a
+// boundary of the index value, without unmarshaling any further models.
 type ModelIterator interface {
 	// LoadNext moves the iterator to the next sequntial key in the database and
 	// loads the current value at the given key into the passed destination.
+	// ErrIteratorDone is returned if there are no more models.
 	LoadNext(dest Model) error
 
+	// Valid returns true if there is a model to be loaded by the next call
+	// to LoadNext. Once it returns false, LoadNext returns the reason,
+	// ErrIteratorDone if the iteration is complete.
+	Valid() bool
+
+	// Key returns the primary key (ID) of the model that the next call to
+	// LoadNext will load, or nil if the iterator is not valid.
+	Key() []byte
+
+	// IndexKey returns the key that the iterator is ordered by for the
+	// model that the next call to LoadNext will load, or nil if the
+	// iterator is not valid. For index scans this is the value produced by
+	// the indexer, for scans over the primary key it is the same as Key.
+	IndexKey() []byte
+
 	// Release releases the Iterator.
 	Release()
 }
@@ -22,17 +49,50 @@ type idModelIterator struct {
 	iterator weave.Iterator
 	// this is the bucketPrefix to strip from each key
 	bucketPrefix []byte
+
+	// the next entry, read ahead from the iterator
+	key, value []byte
+	err        error
+	// fetched is true if key, value and err hold the next entry
+	fetched bool
 }
 
 var _ ModelIterator = (*idModelIterator)(nil)
 
 func (i *idModelIterator) LoadNext(dest Model) error {
-	key, value, err := i.iterator.Next()
-	if err != nil {
-		return err
+	if !i.Valid() {
+		return i.err
 	}
+	i.fetched = false
+	return load(i.key, i.value, i.bucketPrefix, dest)
+}
 
-	return load(key, value, i.bucketPrefix, dest)
+func (i *idModelIterator) Valid() bool {
+	if !i.fetched {
+		i.key, i.value, i.err = i.iterator.Next()
+		i.fetched = true
+	}
+	return i.err == nil
+}
+
+func (i *idModelIterator) Key() []byte {
+	if !i.Valid() || !bytes.HasPrefix(i.key, i.bucketPrefix) {
+		return nil
+	}
+	return i.key[len(i.bucketPrefix):]
+}
+
+func (i *idModelIterator) IndexKey() []byte {
+	return i.Key()
+}
+
+// skip moves to the next model without loading the current one
+func (i *idModelIterator) skip() error {
+	if !i.Valid() {
+		return i.err
+	}
+	i.fetched = false
+	return nil
 }
 
 func (i *idModelIterator) Release() {
@@ -44,22 +104,30 @@ type indexModelIterator struct {
 	iterator weave.Iterator
 	// this is the bucketPrefix to strip from each key
 	bucketPrefix []byte
-	unique       bool
+	// this is the index prefix to strip from each index key
+	indexPrefix []byte
+	unique      bool
 
-	kv         weave.ReadOnlyKVStore
-	cachedKeys [][]byte
+	kv weave.ReadOnlyKVStore
+
+	// indexKey is the current index entry, refs are the IDs referenced by it
+	// that were not loaded yet
+	indexKey []byte
+	refs     [][]byte
+	err      error
 }
 
 var _ ModelIterator = (*indexModelIterator)(nil)
 
 // LoadNext loads next iterator value to dest
 func (i *indexModelIterator) LoadNext(dest Model) error {
-	key, err := i.getKey()
-	if err != nil {
-		return err
+	if !i.Valid() {
+		return i.err
 	}
-	val, err := i.kv.Get(key)
+	key := i.dbKey(i.refs[0])
+	i.refs = i.refs[1:]
 
+	val, err := i.kv.Get(key)
 	if err != nil {
 		return errors.Wrap(err, "loading referenced key")
 	}
@@ -70,61 +138,54 @@ func (i *indexModelIterator) LoadNext(dest Model) error {
 	return load(key, val, i.bucketPrefix, dest)
 }
 
-func (i *indexModelIterator) Release() {
-	i.iterator.Release()
-}
-
-// getKey retrieves the key from cache if i.cacheKeys is not nil, otherwise loads next iterator key
-func (i *indexModelIterator) getKey() ([]byte, error) {
-	var key []byte
-
-	switch cachedKeysLen := len(i.cachedKeys); {
-	case cachedKeysLen > 1:
-		//gets the key from cache and remove first key from i.cacheKey
-		key = i.dbKey(i.cachedKeys[0])
-		i.cachedKeys = i.cachedKeys[1:]
-	case cachedKeysLen == 1:
-		//gets the key from cache and sets i.cachedKeys as nil
-		key = i.dbKey(i.cachedKeys[0])
-		i.cachedKeys = nil
-	default:
-		//retrievesthe key and value from iterator
-		_, value, err := i.iterator.Next()
+// Valid reads the next index entry once all references of the current one
+// are loaded
+func (i *indexModelIterator) Valid() bool {
+	for len(i.refs) == 0 && i.err == nil {
+		key, value, err := i.iterator.Next()
 		if err != nil {
-			return nil, err
+			i.indexKey, i.err = nil, err
+			break
 		}
-
-		keys, err := i.getRefs(value, i.unique)
+		refs, err := parseRefs(value, i.unique)
 		if err != nil {
-			return nil, errors.Wrap(err, "parsing index refs")
-		}
-		if len(keys) != 1 {
-			i.cachedKeys = keys[1:]
+			i.indexKey, i.err = nil, errors.Wrap(err, "parsing index refs")
+			break
 		}
-		key = i.dbKey(keys[0])
+		i.indexKey, i.refs = key, refs
 	}
-
-	return key, nil
+	return i.err == nil
 }
-// get refs takes a value stored in an index and parse it into a slice of
-// db keys
-func (i *indexModelIterator) getRefs(val []byte, unique bool) ([][]byte, error) {
-	if val == nil {
-		return nil, nil
+
+func (i *indexModelIterator) Key() []byte {
+	if !i.Valid() {
+		return nil
 	}
-	if unique {
-		return [][]byte{val}, nil
+	return i.refs[0]
+}
+
+func (i *indexModelIterator) IndexKey() []byte {
+	if !i.Valid() || !bytes.HasPrefix(i.indexKey, i.indexPrefix) {
+		return nil
 	}
-	var data = new(orm.MultiRef)
-	err := data.Unmarshal(val)
-	if err != nil {
-		return nil, err
+	return i.indexKey[len(i.indexPrefix):]
+}
+
+// skip moves to the next model without loading the current one
+func (i *indexModelIterator) skip() error {
+	if !i.Valid() {
+		return i.err
 	}
-	return data.GetRefs(), nil
+	i.refs = i.refs[1:]
+	return nil
+}
+
+func (i *indexModelIterator) Release() {
+	i.iterator.Release()
 }
 
 func (i *indexModelIterator) dbKey(key []byte) []byte {
-	return append(i.bucketPrefix, key...)
+	return joinKey(i.bucketPrefix, key)
 }
 
 func load(key, value, bucketPrefix []byte, dest Model) error {
@@ -132,12 +193,20 @@ func load(key, value, bucketPrefix []byte, dest Model) error {
 	if !bytes.HasPrefix(key, bucketPrefix) {
 		return errors.Wrapf(errors.ErrDatabase, "key with unexpected prefix: %X", key)
 	}
-	key = key[len(bucketPrefix):]
+	return unmarshalModel(key[len(bucketPrefix):], value, dest)
+}
 
+// unmarshalModel replaces the content of dest with the stored value, and sets
+// its ID
+func unmarshalModel(id, value []byte, dest Model) error {
+	// generated Unmarshal merges into existing data, so reset it first
+	if v := reflect.ValueOf(dest); v.Kind() == reflect.Ptr && !v.IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
 	if err := dest.Unmarshal(value); err != nil {
 		return errors.Wrapf(err, "unmarshaling into %T", dest)
 	}
-	if err := dest.SetID(key); err != nil {
+	if err := dest.SetID(id); err != nil {
 		return errors.Wrap(err, "setting ID")
 	}
 	return nil
@@ -1,7 +1,9 @@
 package morm
 
 import (
+	"fmt"
 	"reflect"
+	"regexp"
 
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
@@ -10,8 +12,6 @@ import (
 
 // TODO
 // - migrations
-// - do not use Bucket but directly access KVStore
-// - register for queries
 
 // Model is implemented by any entity that can be stored using ModelBucket.
 //
@@ -67,6 +67,16 @@ type ModelBucket interface {
 	// in order of their count. Or easily find the lowest or highest count.
 	IndexScan(db weave.ReadOnlyKVStore, indexName string, prefix []byte, reverse bool) (ModelIterator, error)
 
+	// RangeScan iterates over all models with a primary key (ID) within
+	// the bounds of the given options. Offset and limit are applied to
+	// the models, in the order they are iterated.
+	RangeScan(db weave.ReadOnlyKVStore, opts ScanOptions) (ModelIterator, error)
+
+	// IndexRangeScan does a RangeScan, but on the named index. Bounds are
+	// compared with the index values, so for example a time range on a
+	// (owner, time) index would be expressed as (owner, start) - (owner, end).
+	IndexRangeScan(db weave.ReadOnlyKVStore, indexName string, opts ScanOptions) (ModelIterator, error)
+
 	// Put saves given model in the database. Before inserting into
 	// database, model is validated using its Validate method.
 	// If the key is nil or zero length then a sequence generator is used
@@ -90,11 +100,19 @@ type ModelBucket interface {
 	Register(name string, r weave.QueryRouter)
 }
 
-// NewModelBucket returns a ModelBucket instance. This implementation relies on
-// a bucket instance. Final implementation should operate directly on the
-// KVStore instead.
+var isBucketName = regexp.MustCompile(`^[a-z_]{3,10}$`).MatchString
+
+// NewModelBucket returns a ModelBucket instance. The bucket operates directly
+// on the KVStore, using the same layout as orm.Bucket so both can be used to
+// access the same data:
+//
+//	<name>:<id>                  the model, with the ID cleared
+//	_s.<name>:id                 the ID sequence
+//	_i.<name>_<index>:<value>    the index entries
 func NewModelBucket(name string, m Model, opts ...ModelBucketOption) ModelBucket {
-	b := orm.NewBucket(name, orm.NewSimpleObj(nil, m))
+	if !isBucketName(name) {
+		panic(fmt.Sprintf("Illegal bucket: %s", name))
+	}
 
 	tp := reflect.TypeOf(m)
 	if tp.Kind() == reflect.Ptr {
@@ -102,8 +120,8 @@ func NewModelBucket(name string, m Model, opts ...ModelBucketOption) ModelBucket
 	}
 
 	mb := &modelBucket{
-		b:          b,
-		idSeq:      b.Sequence("id"),
+		prefix:     []byte(name + ":"),
+		idSeq:      orm.NewSequence(name, orm.SeqID),
 		model:      tp,
 		bucketName: name,
 	}
@@ -117,41 +135,34 @@ func NewModelBucket(name string, m Model, opts ...ModelBucketOption) ModelBucket
 // ModelBucket during creation.
 type ModelBucketOption func(mb *modelBucket)
 
-type indexInfo struct {
-	name string
-	// prefix is the kvstore prefix used for all items in the index
-	prefix []byte
-	unique bool
-}
-
 // WithIndex configures the bucket to build an index with given name. All
 // entities stored in the bucket are indexed using value returned by the
 // indexer function. If an index is unique, there can be only one entity
 // referenced per index value.
 func WithIndex(name string, indexer orm.Indexer, unique bool) ModelBucketOption {
+	return WithMultiKeyIndex(name, singleKey(indexer), unique)
+}
+
+// WithMultiKeyIndex configures the bucket to build an index with given name,
+// where every entity can be indexed under any number of values, for example
+// a trade under the addresses of both the maker and the taker. Iterating over
+// such an index returns an entity once for each of its values.
+func WithMultiKeyIndex(name string, indexer orm.MultiKeyIndexer, unique bool) ModelBucketOption {
 	return func(mb *modelBucket) {
-		mb.b = mb.b.WithIndex(name, indexer, unique)
-		// Until we get better integration with orm, we need to store some info ourselves here...
-		info := indexInfo{
-			name:   name,
-			prefix: indexPrefix(mb.bucketName, name),
-			unique: unique,
+		if mb.getIndex(name) != nil {
+			panic(fmt.Sprintf("Index %s registered twice", name))
 		}
-		mb.indices = append(mb.indices, info)
+		mb.indexes = append(mb.indexes, newIndex(mb.bucketName, name, indexer, unique))
 	}
 }
 
-func indexPrefix(bucketName, indexName string) []byte {
-	path := "_i." + bucketName + "_" + indexName + ":"
-	return []byte(path)
-}
-
 type modelBucket struct {
-	b     orm.Bucket
-	idSeq orm.Sequence
+	// prefix is the kvstore prefix used for all models
+	prefix []byte
+	idSeq  orm.Sequence
 
 	bucketName string
-	indices    []indexInfo
+	indexes    []*index
 
 	// model is referencing the structure type. Event if the structure
 	// pointer is implementing Model interface, this variable references
@@ -160,96 +171,133 @@ type modelBucket struct {
 }
 
 func (mb *modelBucket) Register(name string, r weave.QueryRouter) {
-	mb.b.Register(name, r)
+	if name == "" {
+		name = mb.bucketName
+	}
+	root := "/" + name
+	r.Register(root, bucketQuery{prefix: mb.prefix})
+	for _, idx := range mb.indexes {
+		r.Register(root+"/"+idx.name, indexQuery{index: idx, bucketPrefix: mb.prefix})
+	}
 }
 
 func (mb *modelBucket) One(db weave.ReadOnlyKVStore, key []byte, dest Model) error {
-	obj, err := mb.b.Get(db, key)
+	if reflect.TypeOf(dest) != reflect.PtrTo(mb.model) {
+		return errors.Wrapf(errors.ErrType, "%s cannot be represented as %T", mb.model, dest)
+	}
+	raw, err := db.Get(mb.dbKey(key))
 	if err != nil {
 		return err
 	}
-	if obj == nil || obj.Value() == nil {
+	if raw == nil {
 		return errors.Wrapf(errors.ErrNotFound, "%T not in the store", dest)
 	}
-	res := obj.Value()
-
-	if !reflect.TypeOf(res).AssignableTo(reflect.TypeOf(dest)) {
-		return errors.Wrapf(errors.ErrType, "%T cannot be represented as %T", res, dest)
-	}
-
-	ptr := reflect.ValueOf(dest)
-	ptr.Elem().Set(reflect.ValueOf(res).Elem())
-	ptr.Interface().(Model).SetID(key)
-	return nil
+	return unmarshalModel(key, raw, dest)
 }
 
 func (mb *modelBucket) PrefixScan(db weave.ReadOnlyKVStore, prefix []byte, reverse bool) (ModelIterator, error) {
-	var rawIter weave.Iterator
-	var err error
-
-	start, end := prefixRange(mb.b.DBKey(prefix))
-	if reverse {
-		rawIter, err = db.ReverseIterator(start, end)
-		if err != nil {
-			return nil, errors.Wrap(err, "reverse prefix scan")
-		}
-	} else {
-		rawIter, err = db.Iterator(start, end)
-		if err != nil {
-			return nil, errors.Wrap(err, "prefix scan")
-		}
+	start, end := prefixRange(mb.dbKey(prefix))
+	rawIter, err := rawIterator(db, start, end, reverse)
+	if err != nil {
+		return nil, err
 	}
+	return &idModelIterator{iterator: rawIter, bucketPrefix: mb.prefix}, nil
+}
 
-	return &idModelIterator{iterator: rawIter, bucketPrefix: mb.b.DBKey(nil)}, nil
+func (mb *modelBucket) RangeScan(db weave.ReadOnlyKVStore, opts ScanOptions) (ModelIterator, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	start, end := opts.dbRange(mb.prefix)
+	rawIter, err := rawIterator(db, start, end, opts.Reverse)
+	if err != nil {
+		return nil, err
+	}
+	iter := &idModelIterator{iterator: rawIter, bucketPrefix: mb.prefix}
+	return opts.paginate(iter), nil
 }
 
-func (mb *modelBucket) getIndexInfo(name string) *indexInfo {
-	for _, info := range mb.indices {
-		if info.name == name {
-			return &info
+func (mb *modelBucket) getIndex(name string) *index {
+	for _, idx := range mb.indexes {
+		if idx.name == name {
+			return idx
 		}
 	}
 	return nil
 }
 
 func (mb *modelBucket) IndexScan(db weave.ReadOnlyKVStore, indexName string, prefix []byte, reverse bool) (ModelIterator, error) {
-	// get index
-	info := mb.getIndexInfo(indexName)
-	if info == nil {
+	idx := mb.getIndex(indexName)
+	if idx == nil {
 		return nil, errors.Wrapf(errors.ErrDatabase, "no index with name %s", indexName)
 	}
 
-	dbPrefix := append(info.prefix, prefix...)
-	start, end := prefixRange(dbPrefix)
-
-	var rawIter weave.Iterator
-	var err error
-	if reverse {
-		rawIter, err = db.ReverseIterator(start, end)
-		if err != nil {
-			return nil, errors.Wrap(err, "reverse prefix scan")
-		}
-	} else {
-		rawIter, err = db.Iterator(start, end)
-		if err != nil {
-			return nil, errors.Wrap(err, "prefix scan")
-		}
+	start, end := prefixRange(joinKey(idx.prefix, prefix))
+	rawIter, err := rawIterator(db, start, end, reverse)
+	if err != nil {
+		return nil, err
 	}
 
 	return &indexModelIterator{
 		iterator:     rawIter,
-		bucketPrefix: mb.b.DBKey(nil),
-		unique:       info.unique,
+		bucketPrefix: mb.prefix,
+		indexPrefix:  idx.prefix,
+		unique:       idx.unique,
 		kv:           db,
 	}, nil
 }
 
+func (mb *modelBucket) IndexRangeScan(db weave.ReadOnlyKVStore, indexName string, opts ScanOptions) (ModelIterator, error) {
+	idx := mb.getIndex(indexName)
+	if idx == nil {
+		return nil, errors.Wrapf(errors.ErrDatabase, "no index with name %s", indexName)
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	start, end := opts.dbRange(idx.prefix)
+	rawIter, err := rawIterator(db, start, end, opts.Reverse)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := &indexModelIterator{
+		iterator:     rawIter,
+		bucketPrefix: mb.prefix,
+		indexPrefix:  idx.prefix,
+		unique:       idx.unique,
+		kv:           db,
+	}
+	return opts.paginate(iter), nil
+}
+
+// rawIterator returns a store iterator over [start, end) in the requested order
+func rawIterator(db weave.ReadOnlyKVStore, start, end []byte, reverse bool) (weave.Iterator, error) {
+	if reverse {
+		iter, err := db.ReverseIterator(start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "reverse prefix scan")
+		}
+		return iter, nil
+	}
+	iter, err := db.Iterator(start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "prefix scan")
+	}
+	return iter, nil
+}
+
 func (mb *modelBucket) ByIndex(db weave.ReadOnlyKVStore, indexName string, key []byte, destination ModelSlicePtr) error {
-	objs, err := mb.b.GetIndexed(db, indexName, key)
+	idx := mb.getIndex(indexName)
+	if idx == nil {
+		return errors.Wrap(orm.ErrInvalidIndex, indexName)
+	}
+	refs, err := idx.refs(db, key)
 	if err != nil {
 		return err
 	}
-	if len(objs) == 0 {
+	if len(refs) == 0 {
 		return nil
 	}
 
@@ -276,20 +324,24 @@ func (mb *modelBucket) ByIndex(db weave.ReadOnlyKVStore, indexName string, key [
 		return errors.Wrapf(errors.ErrType, "this bucket operates on %s model and cannot return %s", mb.model, allowed)
 	}
 
-	for _, obj := range objs {
-		if obj == nil || obj.Value() == nil {
+	for _, ref := range refs {
+		raw, err := db.Get(mb.dbKey(ref))
+		if err != nil {
+			return err
+		}
+		if raw == nil {
 			continue
 		}
-		val := reflect.ValueOf(obj.Value())
-		val.Interface().(Model).SetID(obj.Key())
+		val := reflect.New(mb.model)
+		if err := unmarshalModel(ref, raw, val.Interface().(Model)); err != nil {
+			return err
+		}
 		if !sliceOfPointers {
 			val = val.Elem()
 		}
-		// store the key on the model
 		dest.Set(reflect.Append(dest, val))
 	}
 	return nil
-
 }
 
 func (mb *modelBucket) Put(db weave.KVStore, m Model) error {
@@ -312,18 +364,23 @@ func (mb *modelBucket) Put(db weave.KVStore, m Model) error {
 		if err != nil {
 			return errors.Wrap(err, "ID sequence")
 		}
-	} else {
-		// always nil out the key before saving the value
-		m.SetID(nil)
 	}
 
-	obj := orm.NewSimpleObj(key, m)
-	if err := mb.b.Save(db, obj); err != nil {
-		return errors.Wrap(err, "cannot store in the database")
-	}
-	// after serialization, return original/generated key on model
+	// always nil out the key before serializing the value, and return the
+	// original/generated key on the model afterwards
+	m.SetID(nil)
+	raw, err := m.Marshal()
 	m.SetID(key)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal")
+	}
 
+	if err := mb.updateIndexes(db, key, m); err != nil {
+		return errors.Wrap(err, "cannot update indexes")
+	}
+	if err := db.Set(mb.dbKey(key), raw); err != nil {
+		return errors.Wrap(err, "cannot store in the database")
+	}
 	return nil
 }
 
@@ -331,7 +388,10 @@ func (mb *modelBucket) Delete(db weave.KVStore, key []byte) error {
 	if err := mb.Has(db, key); err != nil {
 		return err
 	}
-	return mb.b.Delete(db, key)
+	if err := mb.updateIndexes(db, key, nil); err != nil {
+		return errors.Wrap(err, "cannot update indexes")
+	}
+	return db.Delete(mb.dbKey(key))
 }
 
 func (mb *modelBucket) Has(db weave.KVStore, key []byte) error {
@@ -340,11 +400,7 @@ func (mb *modelBucket) Has(db weave.KVStore, key []byte) error {
 		return errors.ErrNotFound
 	}
 
-	// As long as we rely on the Bucket implementation to access the
-	// database, we must refine the key.
-	key = mb.b.DBKey(key)
-
-	ok, err := db.Has(key)
+	ok, err := db.Has(mb.dbKey(key))
 	if err != nil {
 		return err
 	}
@@ -354,4 +410,39 @@ func (mb *modelBucket) Has(db weave.KVStore, key []byte) error {
 	return nil
 }
 
+// updateIndexes moves all index entries of the model stored under the given
+// key to the values of next, which is nil when the model is deleted
+func (mb *modelBucket) updateIndexes(db weave.KVStore, key []byte, next Model) error {
+	if len(mb.indexes) == 0 {
+		return nil
+	}
+
+	var prev Model
+	raw, err := db.Get(mb.dbKey(key))
+	if err != nil {
+		return err
+	}
+	if raw != nil {
+		prev = reflect.New(mb.model).Interface().(Model)
+		if err := unmarshalModel(key, raw, prev); err != nil {
+			return errors.Wrap(err, "previous version")
+		}
+	}
+	if prev == nil && next == nil {
+		return nil
+	}
+
+	for _, idx := range mb.indexes {
+		if err := idx.update(db, key, prev, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dbKey returns the store key of the model with the given ID
+func (mb *modelBucket) dbKey(key []byte) []byte {
+	return joinKey(mb.prefix, key)
+}
+
 var _ ModelBucket = (*modelBucket)(nil)
@@ -0,0 +1,181 @@
+package morm
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
+)
+
+// The benchmarks compare the ModelBucket with an orm.Bucket doing the same
+// work, which is how the ModelBucket used to be implemented. The workload is
+// modeled after order placement: new models get a sequence ID and are
+// indexed by a price like value, which changes when the order is filled.
+
+type benchBucket interface {
+	put(db weave.KVStore, c *Counter) error
+	one(db weave.ReadOnlyKVStore, key []byte) (*Counter, error)
+}
+
+func newBenchBuckets() map[string]benchBucket {
+	return map[string]benchBucket{
+		"morm": mormBench{
+			b: NewModelBucket("cnts", &Counter{}, WithIndex("counter", lexographicCountIndex, false)),
+		},
+		"orm": ormBench{
+			b: orm.NewBucket("cnts", orm.NewSimpleObj(nil, &Counter{})).
+				WithIndex("counter", lexographicCountIndex, false),
+			seq: orm.NewSequence("cnts", "id"),
+		},
+	}
+}
+
+type mormBench struct {
+	b ModelBucket
+}
+
+func (m mormBench) put(db weave.KVStore, c *Counter) error {
+	return m.b.Put(db, c)
+}
+
+func (m mormBench) one(db weave.ReadOnlyKVStore, key []byte) (*Counter, error) {
+	var c Counter
+	err := m.b.One(db, key, &c)
+	return &c, err
+}
+
+type ormBench struct {
+	b   orm.Bucket
+	seq orm.Sequence
+}
+
+func (o ormBench) put(db weave.KVStore, c *Counter) error {
+	key := c.ID
+	if len(key) == 0 {
+		var err error
+		if key, err = o.seq.NextVal(db); err != nil {
+			return err
+		}
+	}
+	c.ID = nil
+	if err := o.b.Save(db, orm.NewSimpleObj(key, c)); err != nil {
+		return err
+	}
+	c.ID = key
+	return nil
+}
+
+func (o ormBench) one(db weave.ReadOnlyKVStore, key []byte) (*Counter, error) {
+	obj, err := o.b.Get(db, key)
+	if err != nil {
+		return nil, err
+	}
+	c := obj.Value().(*Counter)
+	c.ID = key
+	return c, nil
+}
+
+func BenchmarkModelBucketPut(b *testing.B) {
+	for name, bucket := range newBenchBuckets() {
+		b.Run(name, func(b *testing.B) {
+			db := store.MemStore()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := bucket.put(db, &Counter{Count: int64(i % 100)}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkModelBucketOne(b *testing.B) {
+	for name, bucket := range newBenchBuckets() {
+		b.Run(name, func(b *testing.B) {
+			db := store.MemStore()
+			keys := make([][]byte, 1000)
+			for i := range keys {
+				c := &Counter{Count: int64(i)}
+				if err := bucket.put(db, c); err != nil {
+					b.Fatal(err)
+				}
+				keys[i] = c.ID
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := bucket.one(db, keys[i%len(keys)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkModelBucketOrderPlacement stores a new model, then loads it and
+// updates it twice, moving its index entry, like an order that is placed and
+// then partially and fully filled.
+func BenchmarkModelBucketOrderPlacement(b *testing.B) {
+	for name, bucket := range newBenchBuckets() {
+		b.Run(name, func(b *testing.B) {
+			db := store.MemStore()
+			// a book with some resting orders
+			for i := 0; i < 1000; i++ {
+				if err := bucket.put(db, &Counter{Count: int64(i)}); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				c := &Counter{Count: int64(i % 1000)}
+				if err := bucket.put(db, c); err != nil {
+					b.Fatal(err)
+				}
+				for fill := 0; fill < 2; fill++ {
+					loaded, err := bucket.one(db, c.ID)
+					if err != nil {
+						b.Fatal(err)
+					}
+					loaded.Count += 1000
+					if err := bucket.put(db, loaded); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkModelBucketIndexScan(b *testing.B) {
+	db := store.MemStore()
+	bucket := NewModelBucket("cnts", &Counter{}, WithIndex("counter", lexographicCountIndex, false))
+	for i := 0; i < 1000; i++ {
+		if err := bucket.Put(db, &Counter{Count: int64(i % 100)}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	// all counts are below 256
+	prefix := make([]byte, 7)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter, err := bucket.IndexScan(db, "counter", prefix, false)
+		if err != nil {
+			b.Fatal(err)
+		}
+		// best ten prices
+		for n := 0; n < 10; n++ {
+			var c Counter
+			if err := iter.LoadNext(&c); err != nil {
+				b.Fatal(err)
+			}
+		}
+		iter.Release()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/orm"
 	"github.com/iov-one/weave/store"
@@ -401,3 +402,367 @@ func TestModelBucketHas(t *testing.T) {
 		t.Fatalf("a non exists entity must return ErrNotFound: %s", err)
 	}
 }
+
+func TestModelBucketRangeScan(t *testing.T) {
+	db := store.MemStore()
+
+	b := NewModelBucket("cnts", &Counter{}, WithIndex("counter", lexographicCountIndex, false))
+
+	// index order is 1 (id 1), 3 (ids 3, 6), 8 (id 4), 17 (ids 2, 5)
+	cnts := []Counter{
+		Counter{Count: 1},
+		Counter{Count: 17},
+		Counter{Count: 3},
+		Counter{Count: 8},
+		Counter{Count: 17},
+		Counter{Count: 3},
+	}
+	for i := range cnts {
+		err := b.Put(db, &cnts[i])
+		assert.Nil(t, err)
+	}
+
+	count := func(n uint64) []byte {
+		res := make([]byte, 8)
+		binary.BigEndian.PutUint64(res, n)
+		return res
+	}
+
+	cases := map[string]struct {
+		// index name, primary keys are scanned if empty
+		index   string
+		opts    ScanOptions
+		wantIDs []int64
+		wantErr *errors.Error
+	}{
+		"primary key range": {
+			opts:    ScanOptions{Start: weavetest.SequenceID(2), End: weavetest.SequenceID(5)},
+			wantIDs: []int64{2, 3, 4},
+		},
+		"primary key resume after cursor": {
+			opts:    ScanOptions{Start: weavetest.SequenceID(2), StartExclusive: true, Limit: 2},
+			wantIDs: []int64{3, 4},
+		},
+		"primary key reverse": {
+			opts:    ScanOptions{End: weavetest.SequenceID(4), EndInclusive: true, Reverse: true},
+			wantIDs: []int64{4, 3, 2, 1},
+		},
+		"whole index": {
+			index:   "counter",
+			opts:    ScanOptions{},
+			wantIDs: []int64{1, 3, 6, 4, 2, 5},
+		},
+		"index start inclusive": {
+			index:   "counter",
+			opts:    ScanOptions{Start: count(3)},
+			wantIDs: []int64{3, 6, 4, 2, 5},
+		},
+		"index start exclusive": {
+			index:   "counter",
+			opts:    ScanOptions{Start: count(3), StartExclusive: true},
+			wantIDs: []int64{4, 2, 5},
+		},
+		"index end exclusive": {
+			index:   "counter",
+			opts:    ScanOptions{End: count(8)},
+			wantIDs: []int64{1, 3, 6},
+		},
+		"index end inclusive": {
+			index:   "counter",
+			opts:    ScanOptions{End: count(8), EndInclusive: true},
+			wantIDs: []int64{1, 3, 6, 4},
+		},
+		"index reverse range": {
+			index:   "counter",
+			opts:    ScanOptions{Start: count(3), End: count(17), Reverse: true},
+			wantIDs: []int64{4, 3, 6},
+		},
+		"index offset and limit": {
+			index:   "counter",
+			opts:    ScanOptions{Offset: 2, Limit: 2},
+			wantIDs: []int64{6, 4},
+		},
+		"index offset within one key": {
+			index:   "counter",
+			opts:    ScanOptions{Start: count(3), Offset: 1, Limit: 1},
+			wantIDs: []int64{6},
+		},
+		"offset past the end": {
+			index:   "counter",
+			opts:    ScanOptions{Offset: 10},
+			wantIDs: nil,
+		},
+		"negative limit": {
+			opts:    ScanOptions{Limit: -1},
+			wantErr: errors.ErrInput,
+		},
+		"unknown index": {
+			index:   "foo",
+			opts:    ScanOptions{},
+			wantErr: errors.ErrDatabase,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			var iter ModelIterator
+			var err error
+			if tc.index == "" {
+				iter, err = b.RangeScan(db, tc.opts)
+			} else {
+				iter, err = b.IndexRangeScan(db, tc.index, tc.opts)
+			}
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			defer iter.Release()
+
+			var ids []int64
+			for {
+				var c Counter
+				err := iter.LoadNext(&c)
+				if errors.ErrIteratorDone.Is(err) {
+					break
+				}
+				assert.Nil(t, err)
+				ids = append(ids, int64(binary.BigEndian.Uint64(c.ID)))
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
+
+func TestModelIteratorKeys(t *testing.T) {
+	db := store.MemStore()
+
+	b := NewModelBucket("cnts", &Counter{}, WithIndex("counter", lexographicCountIndex, false))
+	for _, cnt := range []int64{3, 1, 3, 8} {
+		err := b.Put(db, &Counter{Count: cnt})
+		assert.Nil(t, err)
+	}
+	count := func(n uint64) []byte {
+		res := make([]byte, 8)
+		binary.BigEndian.PutUint64(res, n)
+		return res
+	}
+
+	cases := map[string]struct {
+		iterate       func() (ModelIterator, error)
+		wantKeys      [][]byte
+		wantIndexKeys [][]byte
+	}{
+		"prefix scan": {
+			iterate: func() (ModelIterator, error) {
+				return b.PrefixScan(db, nil, false)
+			},
+			wantKeys: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(2), weavetest.SequenceID(3), weavetest.SequenceID(4)},
+			// the primary key is the index key
+			wantIndexKeys: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(2), weavetest.SequenceID(3), weavetest.SequenceID(4)},
+		},
+		"index scan with many models per index value": {
+			iterate: func() (ModelIterator, error) {
+				return b.IndexScan(db, "counter", nil, false)
+			},
+			wantKeys:      [][]byte{weavetest.SequenceID(2), weavetest.SequenceID(1), weavetest.SequenceID(3), weavetest.SequenceID(4)},
+			wantIndexKeys: [][]byte{count(1), count(3), count(3), count(8)},
+		},
+		"reverse index scan": {
+			iterate: func() (ModelIterator, error) {
+				return b.IndexScan(db, "counter", nil, true)
+			},
+			wantKeys:      [][]byte{weavetest.SequenceID(4), weavetest.SequenceID(1), weavetest.SequenceID(3), weavetest.SequenceID(2)},
+			wantIndexKeys: [][]byte{count(8), count(3), count(3), count(1)},
+		},
+		"paginated index scan": {
+			iterate: func() (ModelIterator, error) {
+				return b.IndexRangeScan(db, "counter", ScanOptions{Offset: 2, Limit: 1})
+			},
+			wantKeys:      [][]byte{weavetest.SequenceID(3)},
+			wantIndexKeys: [][]byte{count(3)},
+		},
+		"empty scan": {
+			iterate: func() (ModelIterator, error) {
+				return b.IndexScan(db, "counter", count(2), false)
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			iter, err := tc.iterate()
+			assert.Nil(t, err)
+			defer iter.Release()
+
+			var keys, indexKeys [][]byte
+			for iter.Valid() {
+				key := iter.Key()
+				// accessors do not move the iterator
+				assert.Equal(t, key, iter.Key())
+				keys = append(keys, key)
+				indexKeys = append(indexKeys, iter.IndexKey())
+
+				var c Counter
+				err := iter.LoadNext(&c)
+				assert.Nil(t, err)
+				assert.Equal(t, key, c.ID)
+			}
+			assert.Equal(t, tc.wantKeys, keys)
+			assert.Equal(t, tc.wantIndexKeys, indexKeys)
+
+			assert.Nil(t, iter.Key())
+			assert.Nil(t, iter.IndexKey())
+			// the iterator stays done
+			for i := 0; i < 2; i++ {
+				var c Counter
+				if err := iter.LoadNext(&c); !ErrIteratorDone.Is(err) {
+					t.Fatalf("want iterator done, got %+v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestModelBucketOrmCompatibility(t *testing.T) {
+	db := store.MemStore()
+
+	mb := NewModelBucket("cnts", &Counter{},
+		WithIndex("counter", lexographicCountIndex, false),
+		WithIndex("unique", lexographicCountIndex, true),
+	)
+	ob := orm.NewBucket("cnts", orm.NewSimpleObj(nil, &Counter{})).
+		WithIndex("counter", lexographicCountIndex, false).
+		WithIndex("unique", lexographicCountIndex, true)
+	seq := ob.Sequence("id")
+
+	// models stored by orm.Bucket can be read and updated by the ModelBucket
+	for _, cnt := range []int64{5, 3} {
+		key, err := seq.NextVal(db)
+		assert.Nil(t, err)
+		err = ob.Save(db, orm.NewSimpleObj(key, &Counter{Count: cnt}))
+		assert.Nil(t, err)
+	}
+	var c Counter
+	err := mb.One(db, weavetest.SequenceID(1), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, Counter{ID: weavetest.SequenceID(1), Count: 5}, c)
+
+	// the sequence is shared
+	c3 := Counter{Count: 7}
+	err = mb.Put(db, &c3)
+	assert.Nil(t, err)
+	assert.Equal(t, weavetest.SequenceID(3), c3.ID)
+	err = mb.Put(db, &Counter{ID: weavetest.SequenceID(2), Count: 9})
+	assert.Nil(t, err)
+
+	// both see the same indexes
+	for _, cnt := range []uint64{5, 7, 9} {
+		index := make([]byte, 8)
+		binary.BigEndian.PutUint64(index, cnt)
+
+		objs, err := ob.GetIndexed(db, "counter", index)
+		assert.Nil(t, err)
+		var models []Counter
+		err = mb.ByIndex(db, "unique", index, &models)
+		assert.Nil(t, err)
+		if len(objs) != 1 || len(models) != 1 {
+			t.Fatalf("count %d: want one model, got %d and %d", cnt, len(objs), len(models))
+		}
+		assert.Equal(t, objs[0].Key(), models[0].ID)
+	}
+	objs, err := ob.GetIndexed(db, "counter", []byte{0, 0, 0, 0, 0, 0, 0, 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(objs))
+
+	// deleting through the ModelBucket cleans up the orm indexes
+	err = mb.Delete(db, weavetest.SequenceID(1))
+	assert.Nil(t, err)
+	obj, err := ob.Get(db, weavetest.SequenceID(1))
+	assert.Nil(t, err)
+	assert.Nil(t, obj)
+	objs, err = ob.GetIndexed(db, "counter", []byte{0, 0, 0, 0, 0, 0, 0, 5})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(objs))
+
+	// queries return the same results
+	mr, or := weave.NewQueryRouter(), weave.NewQueryRouter()
+	mb.Register("counters", mr)
+	ob.Register("counters", or)
+	queries := []struct {
+		path, mod string
+		data      []byte
+	}{
+		{"/counters", weave.KeyQueryMod, weavetest.SequenceID(2)},
+		{"/counters", weave.KeyQueryMod, weavetest.SequenceID(1)},
+		{"/counters", weave.PrefixQueryMod, nil},
+		{"/counters/counter", weave.KeyQueryMod, []byte{0, 0, 0, 0, 0, 0, 0, 7}},
+		{"/counters/counter", weave.PrefixQueryMod, nil},
+		{"/counters/unique", weave.PrefixQueryMod, []byte{0, 0, 0}},
+	}
+	for _, q := range queries {
+		want, err := or.Handler(q.path).Query(db, q.mod, q.data)
+		assert.Nil(t, err)
+		got, err := mr.Handler(q.path).Query(db, q.mod, q.data)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestModelBucketMultiKeyIndex(t *testing.T) {
+	db := store.MemStore()
+
+	// index every counter under its count and each of its digits
+	digits := func(obj orm.Object) ([][]byte, error) {
+		c, ok := obj.Value().(*Counter)
+		if !ok {
+			return nil, errors.Wrapf(errors.ErrType, "%T", obj.Value())
+		}
+		res := [][]byte{[]byte(strconv.FormatInt(c.Count, 10))}
+		for _, d := range strconv.FormatInt(c.Count, 10) {
+			res = append(res, []byte{byte(d)})
+		}
+		return res, nil
+	}
+	b := NewModelBucket("cnts", &Counter{}, WithMultiKeyIndex("digits", digits, false))
+
+	c1 := Counter{Count: 12}
+	assert.Nil(t, b.Put(db, &c1))
+	c2 := Counter{Count: 22}
+	assert.Nil(t, b.Put(db, &c2))
+
+	find := func(value string) []Counter {
+		t.Helper()
+		var res []Counter
+		assert.Nil(t, b.ByIndex(db, "digits", []byte(value), &res))
+		return res
+	}
+	assert.Equal(t, []Counter{c1}, find("1"))
+	assert.Equal(t, []Counter{c1, c2}, find("2"))
+	assert.Equal(t, []Counter{c2}, find("22"))
+
+	// moving to new values keeps the values in common
+	c1.Count = 13
+	assert.Nil(t, b.Put(db, &c1))
+	assert.Equal(t, []Counter{c1}, find("1"))
+	assert.Equal(t, []Counter{c2}, find("2"))
+	assert.Equal(t, []Counter{c1}, find("3"))
+	assert.Equal(t, 0, len(find("12")))
+
+	// iterating returns a model for each of its values
+	iter, err := b.IndexScan(db, "digits", nil, false)
+	assert.Nil(t, err)
+	var keys [][]byte
+	for iter.Valid() {
+		keys = append(keys, iter.IndexKey())
+		var c Counter
+		assert.Nil(t, iter.LoadNext(&c))
+	}
+	iter.Release()
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("13"), []byte("2"), []byte("22"), []byte("3")}, keys)
+
+	assert.Nil(t, b.Delete(db, c1.ID))
+	assert.Equal(t, 0, len(find("1")))
+	assert.Equal(t, []Counter{c2}, find("2"))
+}
@@ -0,0 +1,114 @@
+package morm
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+// bucketQuery serves the models of a bucket by primary key. Results are the
+// same as of an orm.Bucket: keys are returned with the bucket prefix and
+// values as stored.
+type bucketQuery struct {
+	prefix []byte
+}
+
+var _ weave.QueryHandler = bucketQuery{}
+
+func (q bucketQuery) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	switch mod {
+	case weave.KeyQueryMod:
+		key := joinKey(q.prefix, data)
+		value, err := db.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		return []weave.Model{{Key: key, Value: value}}, nil
+	case weave.PrefixQueryMod:
+		start, end := prefixRange(joinKey(q.prefix, data))
+		iter, err := db.Iterator(start, end)
+		if err != nil {
+			return nil, err
+		}
+		defer iter.Release()
+
+		var res []weave.Model
+		for {
+			key, value, err := iter.Next()
+			if errors.ErrIteratorDone.Is(err) {
+				return res, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, weave.Model{Key: key, Value: value})
+		}
+	default:
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+}
+
+// indexQuery serves the models of a bucket by the value of one of its
+// indexes, for an exact value or a prefix of it.
+type indexQuery struct {
+	index *index
+	// bucketPrefix is prepended to each reference to load the model
+	bucketPrefix []byte
+}
+
+var _ weave.QueryHandler = indexQuery{}
+
+func (q indexQuery) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	switch mod {
+	case weave.KeyQueryMod:
+		refs, err := q.index.refs(db, data)
+		if err != nil {
+			return nil, err
+		}
+		return q.load(db, refs)
+	case weave.PrefixQueryMod:
+		start, end := prefixRange(joinKey(q.index.prefix, data))
+		iter, err := db.Iterator(start, end)
+		if err != nil {
+			return nil, err
+		}
+		defer iter.Release()
+
+		var refs [][]byte
+		for {
+			_, value, err := iter.Next()
+			if errors.ErrIteratorDone.Is(err) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			r, err := parseRefs(value, q.index.unique)
+			if err != nil {
+				return nil, errors.Wrap(err, "parsing index refs")
+			}
+			refs = append(refs, r...)
+		}
+		return q.load(db, refs)
+	default:
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+}
+
+func (q indexQuery) load(db weave.ReadOnlyKVStore, refs [][]byte) ([]weave.Model, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	res := make([]weave.Model, len(refs))
+	for i, ref := range refs {
+		key := joinKey(q.bucketPrefix, ref)
+		value, err := db.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = weave.Model{Key: key, Value: value}
+	}
+	return res, nil
+}
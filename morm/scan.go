@@ -0,0 +1,151 @@
+package morm
+
+import (
+	"github.com/iov-one/weave/errors"
+)
+
+// ScanOptions describe a range scan over the primary keys or over an index
+// of a ModelBucket.
+//
+// Keys are given without any bucket or index prefix. By default the range is
+// [Start, End), which makes it easy to page through the results: to resume a
+// listing, pass the last key seen as Start with StartExclusive set (or as End
+// when iterating in reverse). Models sharing a key of a non unique index can
+// be paged through using Offset.
+type ScanOptions struct {
+	// Start is the lowest key of the range. nil has no lower bound.
+	Start []byte
+	// StartExclusive excludes Start itself from the range.
+	StartExclusive bool
+	// End is the highest key of the range. nil has no upper bound.
+	End []byte
+	// EndInclusive includes End itself in the range.
+	EndInclusive bool
+	// Reverse iterates from the highest key to the lowest.
+	Reverse bool
+	// Offset is the number of models skipped before the first one is returned.
+	Offset int
+	// Limit is the maximum number of models returned, zero is unlimited.
+	Limit int
+}
+
+func (o ScanOptions) validate() error {
+	if o.Offset < 0 {
+		return errors.Wrap(errors.ErrInput, "negative offset")
+	}
+	if o.Limit < 0 {
+		return errors.Wrap(errors.ErrInput, "negative limit")
+	}
+	return nil
+}
+
+// dbRange returns the [start, end) store keys of the scan, for keys stored
+// under the given prefix
+func (o ScanOptions) dbRange(prefix []byte) ([]byte, []byte) {
+	start, end := prefixRange(prefix)
+
+	if o.Start != nil {
+		start = joinKey(prefix, o.Start)
+		if o.StartExclusive {
+			start = nextKey(start)
+		}
+	}
+	if o.End != nil {
+		end = joinKey(prefix, o.End)
+		if o.EndInclusive {
+			end = nextKey(end)
+		}
+	}
+	return start, end
+}
+
+// paginate applies offset and limit to the iterator
+func (o ScanOptions) paginate(iter skipIterator) ModelIterator {
+	if o.Offset == 0 && o.Limit == 0 {
+		return iter
+	}
+	return &pageIterator{
+		skipIterator: iter,
+		offset:       o.Offset,
+		limit:        o.Limit,
+	}
+}
+
+// joinKey returns a new slice of prefix || key, never modifying prefix
+func joinKey(prefix, key []byte) []byte {
+	res := make([]byte, 0, len(prefix)+len(key))
+	res = append(res, prefix...)
+	return append(res, key...)
+}
+
+// nextKey returns the lowest key that sorts after the given one
+func nextKey(key []byte) []byte {
+	return joinKey(key, []byte{0})
+}
+
+// skipIterator is a ModelIterator that can move past a model without loading it
+type skipIterator interface {
+	ModelIterator
+	skip() error
+}
+
+// pageIterator skips the first models of an iterator, and stops after the
+// limit is reached
+type pageIterator struct {
+	skipIterator
+	offset int
+	// limit is unlimited when zero
+	limit    int
+	returned int
+}
+
+var _ ModelIterator = (*pageIterator)(nil)
+
+func (i *pageIterator) LoadNext(dest Model) error {
+	if err := i.skipOffset(); err != nil {
+		return err
+	}
+	if i.limitReached() {
+		return ErrIteratorDone
+	}
+	if err := i.skipIterator.LoadNext(dest); err != nil {
+		return err
+	}
+	i.returned++
+	return nil
+}
+
+func (i *pageIterator) Valid() bool {
+	if err := i.skipOffset(); err != nil {
+		return false
+	}
+	return !i.limitReached() && i.skipIterator.Valid()
+}
+
+func (i *pageIterator) Key() []byte {
+	if !i.Valid() {
+		return nil
+	}
+	return i.skipIterator.Key()
+}
+
+func (i *pageIterator) IndexKey() []byte {
+	if !i.Valid() {
+		return nil
+	}
+	return i.skipIterator.IndexKey()
+}
+
+// skipOffset moves past the models before the offset, once
+func (i *pageIterator) skipOffset() error {
+	for ; i.offset > 0; i.offset-- {
+		if err := i.skipIterator.skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *pageIterator) limitReached() bool {
+	return i.limit != 0 && i.returned >= i.limit
+}
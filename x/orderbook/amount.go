@@ -2,6 +2,7 @@ package orderbook
 
 import (
 	"encoding/binary"
+	"math/big"
 
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
@@ -25,6 +26,9 @@ func NewAmountp(whole int64, fractional int64) *Amount {
 
 // Clone copies values of Amount to a new Amount struct
 func (a *Amount) Clone() *Amount {
+	if a == nil {
+		return nil
+	}
 	return &Amount{
 		Whole:      a.Whole,
 		Fractional: a.Fractional,
@@ -50,6 +54,49 @@ func (a *Amount) Validate() error {
 	return nil
 }
 
+// validateOptionalAmount accepts a missing or zero amount, anything
+// else must be a valid positive amount
+func validateOptionalAmount(a *Amount) error {
+	if a == nil {
+		return nil
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if a.IsNegative() {
+		return errors.Wrap(errors.ErrInput, "must not be negative")
+	}
+	return nil
+}
+
+// validatePositiveAmount ensures a required amount is set and positive
+func validatePositiveAmount(a *Amount) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if !a.IsPositive() {
+		return errors.Wrap(errors.ErrInput, "must be positive")
+	}
+	return nil
+}
+
+// validateFeeRate accepts a missing fee rate, anything else must be a
+// fraction at least 0 and below 1
+func validateFeeRate(a *Amount) error {
+	if err := validateOptionalAmount(a); err != nil {
+		return err
+	}
+	if a != nil && a.Compare(NewAmountp(1, 0)) >= 0 {
+		return errors.Wrap(errors.ErrInput, "must be below 1")
+	}
+	return nil
+}
+
+// IsZero returns true if the value is 0. nil is treated as zero
+func (a *Amount) IsZero() bool {
+	return a.GetWhole() == 0 && a.GetFractional() == 0
+}
+
 // IsPositive returns true if the value is greater than 0
 func (a *Amount) IsPositive() bool {
 	return a.Whole > 0 ||
@@ -82,3 +129,192 @@ func (a *Amount) Lexographic() ([]byte, error) {
 	binary.BigEndian.PutUint64(res[8:], uint64(a.Fractional))
 	return res, nil
 }
+
+// AmountFromLexographic decodes an amount encoded with Lexographic
+func AmountFromLexographic(b []byte) (*Amount, error) {
+	if len(b) != 16 {
+		return nil, errors.Wrapf(errors.ErrInput, "lexographic amount must be 16 bytes, got %d", len(b))
+	}
+	a := &Amount{
+		Whole:      int64(binary.BigEndian.Uint64(b)),
+		Fractional: int64(binary.BigEndian.Uint64(b[8:])),
+	}
+	if err := a.Validate(); err != nil {
+		return nil, errors.Wrap(err, "lexographic amount")
+	}
+	return a, nil
+}
+
+// RoundingMode defines how results that cannot be represented with nine
+// fractional digits are rounded
+type RoundingMode int
+
+const (
+	// RoundDown truncates the result towards zero
+	RoundDown RoundingMode = iota
+	// RoundUp rounds the result away from zero
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, and away from zero on a tie
+	RoundHalfUp
+)
+
+// Add returns a + b, or an error if the result overflows
+func (a *Amount) Add(b *Amount) (*Amount, error) {
+	res := new(big.Int).Add(a.atoms(), b.atoms())
+	return amountFromAtoms(res)
+}
+
+// Subtract returns a - b, or an error if the result overflows
+func (a *Amount) Subtract(b *Amount) (*Amount, error) {
+	res := new(big.Int).Sub(a.atoms(), b.atoms())
+	return amountFromAtoms(res)
+}
+
+// Multiply returns a * b, rounded with the given mode.
+// Returns an error if the result overflows
+func (a *Amount) Multiply(b *Amount, mode RoundingMode) (*Amount, error) {
+	res := new(big.Int).Mul(a.atoms(), b.atoms())
+	return amountFromAtoms(quoRound(res, big.NewInt(coin.FracUnit), mode))
+}
+
+// Divide returns a / b, rounded with the given mode.
+// Returns an error on division by zero or if the result overflows
+func (a *Amount) Divide(b *Amount, mode RoundingMode) (*Amount, error) {
+	div := b.atoms()
+	if div.Sign() == 0 {
+		return nil, errors.Wrap(errors.ErrInput, "division by zero")
+	}
+	res := new(big.Int).Mul(a.atoms(), big.NewInt(coin.FracUnit))
+	return amountFromAtoms(quoRound(res, div, mode))
+}
+
+// Inverse returns 1 / a, rounded with the given mode.
+// This converts a price from one side of an orderbook to the other
+func (a *Amount) Inverse(mode RoundingMode) (*Amount, error) {
+	return NewAmountp(1, 0).Divide(a, mode)
+}
+
+// Compare returns -1 if a < b, 0 if a == b and 1 if a > b
+func (a *Amount) Compare(b *Amount) int {
+	return a.atoms().Cmp(b.atoms())
+}
+
+// IsMultipleOf returns true if a is an exact multiple of step.
+// Every amount is a multiple of a zero step
+func (a *Amount) IsMultipleOf(step *Amount) bool {
+	div := step.atoms()
+	if div.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).Rem(a.atoms(), div).Sign() == 0
+}
+
+// NextMultipleAbove returns the smallest multiple of step that is greater
+// than a. A zero step is treated as the smallest fractional unit
+func (a *Amount) NextMultipleAbove(step *Amount) (*Amount, error) {
+	div := step.atoms()
+	if div.Sign() <= 0 {
+		div = big.NewInt(1)
+	}
+	// Div rounds towards negative infinity for a positive divisor
+	res := new(big.Int).Div(a.atoms(), div)
+	res.Add(res, big.NewInt(1))
+	return amountFromAtoms(res.Mul(res, div))
+}
+
+// Equals returns true if both amounts represent the same value
+func (a *Amount) Equals(b *Amount) bool {
+	return a.Compare(b) == 0
+}
+
+// MulCoin returns a * c, truncating any fractional unit lost in the
+// calculation. The result keeps the ticker of c.
+// Returns an error if the result does not fit in a coin
+func (a *Amount) MulCoin(c coin.Coin) (coin.Coin, error) {
+	return a.MulCoinRound(c, RoundDown)
+}
+
+// MulCoinRound returns a * c, rounded with the given mode.
+// The result keeps the ticker of c.
+// Returns an error if the result does not fit in a coin
+func (a *Amount) MulCoinRound(c coin.Coin, mode RoundingMode) (coin.Coin, error) {
+	res := new(big.Int).Mul(a.atoms(), atoms(c.Whole, c.Fractional))
+	return coinFromAtoms(quoRound(res, big.NewInt(coin.FracUnit), mode), c.Ticker)
+}
+
+// DivideCoin returns c / a, rounded with the given mode.
+// The result keeps the ticker of c.
+// Returns an error on division by zero or if the result does not fit in a coin
+func (a *Amount) DivideCoin(c coin.Coin, mode RoundingMode) (coin.Coin, error) {
+	div := a.atoms()
+	if div.Sign() == 0 {
+		return coin.Coin{}, errors.Wrap(errors.ErrInput, "division by zero")
+	}
+	res := new(big.Int).Mul(atoms(c.Whole, c.Fractional), big.NewInt(coin.FracUnit))
+	return coinFromAtoms(quoRound(res, div, mode), c.Ticker)
+}
+
+// atoms returns the amount expressed in fractional units. nil is treated as zero
+func (a *Amount) atoms() *big.Int {
+	return atoms(a.GetWhole(), a.GetFractional())
+}
+
+// atoms returns the value expressed in fractional units, so that
+// whole and fractional part can be used in a single big integer calculation
+func atoms(whole, fractional int64) *big.Int {
+	res := big.NewInt(whole)
+	res.Mul(res, big.NewInt(coin.FracUnit))
+	return res.Add(res, big.NewInt(fractional))
+}
+
+// splitAtoms is the inverse of atoms.
+// Returns an error if the whole part is out of the range of a coin
+func splitAtoms(val *big.Int) (int64, int64, error) {
+	whole, frac := new(big.Int).QuoRem(val, big.NewInt(coin.FracUnit), new(big.Int))
+	if !whole.IsInt64() || whole.Int64() > coin.MaxInt || whole.Int64() < coin.MinInt {
+		return 0, 0, errors.Wrap(errors.ErrOverflow, "whole")
+	}
+	return whole.Int64(), frac.Int64(), nil
+}
+
+func amountFromAtoms(val *big.Int) (*Amount, error) {
+	whole, frac, err := splitAtoms(val)
+	if err != nil {
+		return nil, err
+	}
+	return NewAmountp(whole, frac), nil
+}
+
+func coinFromAtoms(val *big.Int, ticker string) (coin.Coin, error) {
+	whole, frac, err := splitAtoms(val)
+	if err != nil {
+		return coin.Coin{}, err
+	}
+	return coin.NewCoin(whole, frac, ticker), nil
+}
+
+// quoRound returns num / den rounded with the given mode. den must not be zero
+func quoRound(num, den *big.Int, mode RoundingMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	awayFromZero := false
+	switch mode {
+	case RoundUp:
+		awayFromZero = true
+	case RoundHalfUp:
+		twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		awayFromZero = twice.Cmp(new(big.Int).Abs(den)) >= 0
+	}
+	if !awayFromZero {
+		return quo
+	}
+	// the remainder has the sign of num, so the exact result is negative
+	// when the signs of num and den differ
+	if num.Sign() == den.Sign() {
+		return quo.Add(quo, big.NewInt(1))
+	}
+	return quo.Sub(quo, big.NewInt(1))
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/weavetest/assert"
 )
 
@@ -27,6 +29,10 @@ func TestAmountLexographicEncoding(t *testing.T) {
 			lex, err := tc.a.Lexographic()
 			assert.Nil(t, err)
 			assert.Equal(t, tc.expect, lex)
+
+			decoded, err := AmountFromLexographic(lex)
+			assert.Nil(t, err)
+			assert.Equal(t, &tc.a, decoded)
 		})
 	}
 }
@@ -68,3 +74,206 @@ func TestAmountLexographic(t *testing.T) {
 		})
 	}
 }
+
+func TestAmountArithmetic(t *testing.T) {
+	cases := map[string]struct {
+		op      func() (*Amount, error)
+		want    *Amount
+		wantErr *errors.Error
+	}{
+		"add with fractional carry": {
+			op:   func() (*Amount, error) { return NewAmountp(1, 600000000).Add(NewAmountp(2, 500000000)) },
+			want: NewAmountp(4, 100000000),
+		},
+		"add overflow": {
+			op:      func() (*Amount, error) { return NewAmountp(coin.MaxInt, 0).Add(NewAmountp(1, 0)) },
+			wantErr: errors.ErrOverflow,
+		},
+		"subtract to negative": {
+			op:   func() (*Amount, error) { return NewAmountp(1, 0).Subtract(NewAmountp(1, 500000000)) },
+			want: NewAmountp(0, -500000000),
+		},
+		"multiply exact": {
+			op:   func() (*Amount, error) { return NewAmountp(2, 500000000).Multiply(NewAmountp(4, 0), RoundDown) },
+			want: NewAmountp(10, 0),
+		},
+		"multiply round down": {
+			op:   func() (*Amount, error) { return NewAmountp(0, 3).Multiply(NewAmountp(0, 500000000), RoundDown) },
+			want: NewAmountp(0, 1),
+		},
+		"multiply round up": {
+			op:   func() (*Amount, error) { return NewAmountp(0, 3).Multiply(NewAmountp(0, 300000000), RoundUp) },
+			want: NewAmountp(0, 1),
+		},
+		"multiply round half up on a tie": {
+			op:   func() (*Amount, error) { return NewAmountp(0, 3).Multiply(NewAmountp(0, 500000000), RoundHalfUp) },
+			want: NewAmountp(0, 2),
+		},
+		"multiply negative rounds away from zero": {
+			op:   func() (*Amount, error) { return NewAmountp(0, -3).Multiply(NewAmountp(0, 500000000), RoundUp) },
+			want: NewAmountp(0, -2),
+		},
+		"multiply overflow": {
+			op:      func() (*Amount, error) { return NewAmountp(100000000, 0).Multiply(NewAmountp(100000000, 0), RoundDown) },
+			wantErr: errors.ErrOverflow,
+		},
+		"divide round down": {
+			op:   func() (*Amount, error) { return NewAmountp(2, 0).Divide(NewAmountp(3, 0), RoundDown) },
+			want: NewAmountp(0, 666666666),
+		},
+		"divide round half up": {
+			op:   func() (*Amount, error) { return NewAmountp(2, 0).Divide(NewAmountp(3, 0), RoundHalfUp) },
+			want: NewAmountp(0, 666666667),
+		},
+		"divide by zero": {
+			op:      func() (*Amount, error) { return NewAmountp(2, 0).Divide(NewAmountp(0, 0), RoundDown) },
+			wantErr: errors.ErrInput,
+		},
+		"inverse": {
+			op:   func() (*Amount, error) { return NewAmountp(0, 250000000).Inverse(RoundDown) },
+			want: NewAmountp(4, 0),
+		},
+		"inverse round up": {
+			op:   func() (*Amount, error) { return NewAmountp(3, 0).Inverse(RoundUp) },
+			want: NewAmountp(0, 333333334),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.op()
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAmountCompare(t *testing.T) {
+	cases := map[string]struct {
+		a, b *Amount
+		want int
+	}{
+		"equal": {
+			a:    NewAmountp(1, 5),
+			b:    NewAmountp(1, 5),
+			want: 0,
+		},
+		"smaller fractional": {
+			a:    NewAmountp(1, 4),
+			b:    NewAmountp(1, 5),
+			want: -1,
+		},
+		"bigger whole": {
+			a:    NewAmountp(2, 0),
+			b:    NewAmountp(1, 999999999),
+			want: 1,
+		},
+		"negative": {
+			a:    NewAmountp(0, -1),
+			b:    NewAmountp(0, 0),
+			want: -1,
+		},
+		"nil is zero": {
+			a:    nil,
+			b:    NewAmountp(0, 0),
+			want: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.a.Compare(tc.b))
+			assert.Equal(t, tc.want == 0, tc.a.Equals(tc.b))
+		})
+	}
+}
+
+func TestAmountNextMultipleAbove(t *testing.T) {
+	cases := map[string]struct {
+		a, step *Amount
+		want    *Amount
+	}{
+		"between multiples": {
+			a:    NewAmountp(0, 53000000),
+			step: NewAmountp(0, 10000000),
+			want: NewAmountp(0, 60000000),
+		},
+		"on a multiple": {
+			a:    NewAmountp(0, 50000000),
+			step: NewAmountp(0, 10000000),
+			want: NewAmountp(0, 60000000),
+		},
+		"whole step": {
+			a:    NewAmountp(20, 1),
+			step: NewAmountp(5, 0),
+			want: NewAmountp(25, 0),
+		},
+		"zero step": {
+			a:    NewAmountp(0, 50000000),
+			want: NewAmountp(0, 50000001),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.a.NextMultipleAbove(tc.step)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAmountMulCoin(t *testing.T) {
+	cases := map[string]struct {
+		op      func() (coin.Coin, error)
+		want    coin.Coin
+		wantErr *errors.Error
+	}{
+		"mul coin": {
+			op:   func() (coin.Coin, error) { return NewAmountp(2, 500000000).MulCoin(coin.NewCoin(3, 0, "FOO")) },
+			want: coin.NewCoin(7, 500000000, "FOO"),
+		},
+		"mul coin truncates": {
+			op:   func() (coin.Coin, error) { return NewAmountp(0, 500000000).MulCoin(coin.NewCoin(0, 3, "FOO")) },
+			want: coin.NewCoin(0, 1, "FOO"),
+		},
+		"mul coin round up": {
+			op: func() (coin.Coin, error) {
+				return NewAmountp(0, 500000000).MulCoinRound(coin.NewCoin(0, 3, "FOO"), RoundUp)
+			},
+			want: coin.NewCoin(0, 2, "FOO"),
+		},
+		"mul coin overflow": {
+			op:      func() (coin.Coin, error) { return NewAmountp(2, 0).MulCoin(coin.NewCoin(coin.MaxInt, 0, "FOO")) },
+			wantErr: errors.ErrOverflow,
+		},
+		"divide coin": {
+			op: func() (coin.Coin, error) {
+				return NewAmountp(3, 0).DivideCoin(coin.NewCoin(10, 0, "FOO"), RoundDown)
+			},
+			want: coin.NewCoin(3, 333333333, "FOO"),
+		},
+		"divide coin by zero": {
+			op: func() (coin.Coin, error) {
+				return NewAmountp(0, 0).DivideCoin(coin.NewCoin(10, 0, "FOO"), RoundDown)
+			},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.op()
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
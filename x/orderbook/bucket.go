@@ -1,10 +1,8 @@
 package orderbook
 
 import (
-	"bytes"
-	"encoding/binary"
-
 	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/orm"
 )
@@ -12,19 +10,38 @@ import (
 const (
 	// Assumed maximum ticker letter size is 5
 	tickerByteSize = 5
+	// IDs are generated by the bucket sequences
+	idByteSize = 8
+	// Amount.Lexographic() size
+	amountByteSize = 16
 )
 
 type MarketBucket struct {
 	morm.ModelBucket
 }
 
+// NewMarketBucket initates market with required indexes
 func NewMarketBucket() *MarketBucket {
-	b := morm.NewModelBucket("market", &Market{})
+	b := morm.NewModelBucket("market", &Market{},
+		morm.WithIndex("name", marketNameIndexer, true),
+	)
 	return &MarketBucket{
 		ModelBucket: b,
 	}
 }
 
+// marketNameIndexer indexes by market name, which must be unique
+func marketNameIndexer(obj orm.Object) ([]byte, error) {
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	market, ok := obj.Value().(*Market)
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrState, "expected market, got %T", obj.Value())
+	}
+	return []byte(market.Name), nil
+}
+
 type OrderBookBucket struct {
 	morm.ModelBucket
 }
@@ -67,18 +84,28 @@ func marketIDTickersIndexer(obj orm.Object) ([]byte, error) {
 		return nil, errors.Wrapf(errors.ErrState, "expected orderbook, got %T", obj.Value())
 	}
 
-	return BuildMarketIDTickersIndex(orderbook), nil
+	return BuildMarketIDTickersIndex(orderbook)
 }
 
 // BuildMarketIDTickersIndex indexByteSize = 8(MarketID) + ask ticker size + bid ticker size
-func BuildMarketIDTickersIndex(orderbook *OrderBook) []byte {
-	askTickerByte := make([]byte, tickerByteSize)
-	copy(askTickerByte, orderbook.AskTicker)
-
-	bidTickerByte := make([]byte, tickerByteSize)
-	copy(bidTickerByte, orderbook.BidTicker)
+func BuildMarketIDTickersIndex(orderbook *OrderBook) ([]byte, error) {
+	return morm.NewIndexKey().
+		Bytes(orderbook.MarketID, idByteSize).
+		String(orderbook.AskTicker, tickerByteSize).
+		String(orderbook.BidTicker, tickerByteSize).
+		Key()
+}
 
-	return bytes.Join([][]byte{orderbook.MarketID, askTickerByte, bidTickerByte}, nil)
+// ParseMarketIDTickersIndex returns the fields of a BuildMarketIDTickersIndex value
+func ParseMarketIDTickersIndex(index []byte) (marketID []byte, askTicker, bidTicker string, err error) {
+	r := morm.NewIndexKeyReader(index)
+	marketID = r.Bytes(idByteSize)
+	askTicker = r.String(tickerByteSize)
+	bidTicker = r.String(tickerByteSize)
+	if err := r.Done(); err != nil {
+		return nil, "", "", errors.Wrap(err, "market tickers index")
+	}
+	return marketID, askTicker, bidTicker, nil
 }
 
 type OrderBucket struct {
@@ -88,6 +115,7 @@ type OrderBucket struct {
 func NewOrderBucket() *OrderBucket {
 	b := morm.NewModelBucket("order", &Order{},
 		morm.WithIndex("open", openOrderIndexer, false),
+		morm.WithIndex("trader", orderTraderIndexer, false),
 	)
 	return &OrderBucket{
 		ModelBucket: b,
@@ -96,11 +124,12 @@ func NewOrderBucket() *OrderBucket {
 
 // openOrderIndexer produces, in SQL parlance, a compound index:
 //
-//   (OrderBookID, Side, Price) WHERE order.OrderState = Open
+//   (OrderBookID, Side, Price, CreatedAt, Sequence) WHERE order.OrderState = Open
 //
 // The purpose is to enable range proofs over price for matching order...
-// eg. (orderbook=7, side=ask) and then and Iterate over prices Ascending
-// (TODO: add a proper Iterator/First method to ModelBucket - key and index)
+// eg. (orderbook=7, side=ask) and then and Iterate over prices Ascending.
+// Orders with the same price are ordered by time, and by their sequence in
+// the orderbook within a block
 func openOrderIndexer(obj orm.Object) ([]byte, error) {
 	if obj == nil || obj.Value() == nil {
 		return nil, nil
@@ -114,9 +143,11 @@ func openOrderIndexer(obj orm.Object) ([]byte, error) {
 
 // BuildOpenOrderIndex produces a compound index like:
 //
-//   (OrderBookID, Side, Price) WHERE order.OrderState = Open
+//   (OrderBookID, Side, Price, CreatedAt, Sequence) WHERE order.OrderState = Open
 //
-// Stored as - 8 bytes bigendian OrderBookID, 1 byte Side, 8 byte bigendian Price.Whole, 8 byte bigendian Price.Fractional
+// Stored as - 8 bytes bigendian OrderBookID, 1 byte Side, 8 byte bigendian Price.Whole, 8 byte bigendian Price.Fractional,
+// 8 bytes bigendian CreatedAt with the sign bit flipped, so times before the epoch keep their order,
+// 8 bytes bigendian Sequence
 // We use Price.Lexographic() to produce a lexographic ordering, such than
 //
 //   A.Lexographic() < B.Lexographic == A < B
@@ -128,15 +159,175 @@ func BuildOpenOrderIndex(order *Order) ([]byte, error) {
 		return nil, nil
 	}
 
-	res := make([]byte, 9+16)
-	copy(res, order.OrderBookID)
-	res[8] = byte(order.Side)
-	lex, err := order.Price.Lexographic()
+	index, err := morm.NewIndexKey().
+		Bytes(order.OrderBookID, idByteSize).
+		Byte(byte(order.Side)).
+		Lexographic(order.Price, amountByteSize).
+		SignedTime(order.CreatedAt).
+		Uint64(order.Sequence).
+		Key()
 	if err != nil {
 		return nil, errors.Wrap(err, "building order index")
 	}
-	copy(res[9:], lex)
-	return res, nil
+	return index, nil
+}
+
+// ParseOpenOrderIndex returns the fields of a BuildOpenOrderIndex value
+func ParseOpenOrderIndex(index []byte) (orderBookID []byte, side Side, price *Amount, createdAt weave.UnixTime, sequence uint64, err error) {
+	r := morm.NewIndexKeyReader(index)
+	orderBookID = r.Bytes(idByteSize)
+	side = Side(r.Byte())
+	lex := r.Bytes(amountByteSize)
+	createdAt = r.SignedTime()
+	sequence = r.Uint64()
+	if err := r.Done(); err != nil {
+		return nil, 0, nil, 0, 0, errors.Wrap(err, "open order index")
+	}
+	price, err = AmountFromLexographic(lex)
+	if err != nil {
+		return nil, 0, nil, 0, 0, err
+	}
+	return orderBookID, side, price, createdAt, sequence, nil
+}
+
+// ParseOpenOrderPrice returns the price of an order from its BuildOpenOrderIndex
+// value, so open orders can be compared by price without loading them
+func ParseOpenOrderPrice(index []byte) (*Amount, error) {
+	_, _, price, _, _, err := ParseOpenOrderIndex(index)
+	return price, err
+}
+
+// BuildOpenOrderPrefix produces the prefix of BuildOpenOrderIndex covering all open
+// orders of one side of an orderbook. Scanning it in ascending order yields the
+// best priced orders first.
+func BuildOpenOrderPrefix(orderBookID []byte, side Side) ([]byte, error) {
+	return morm.NewIndexKey().
+		Bytes(orderBookID, idByteSize).
+		Byte(byte(side)).
+		Key()
+}
+
+// orderTraderIndexer indexes all orders of a trader by
+//   (Trader, OrderState, CreatedAt)
+// so a wallet can list the open orders of an address, or its order history,
+// oldest first
+func orderTraderIndexer(obj orm.Object) ([]byte, error) {
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	order, ok := obj.Value().(*Order)
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrState, "expected order, got %T", obj.Value())
+	}
+	return BuildOrderTraderIndex(order)
+}
+
+// BuildOrderTraderIndex produces 20 bytes Trader || 1 byte OrderState || 8 bytes big-endian CreatedAt
+// with the sign bit flipped, as for the open order index
+func BuildOrderTraderIndex(order *Order) ([]byte, error) {
+	index, err := morm.NewIndexKey().
+		Address(order.Trader).
+		Byte(byte(order.OrderState)).
+		SignedTime(order.CreatedAt).
+		Key()
+	if err != nil {
+		return nil, errors.Wrap(err, "building order trader index")
+	}
+	return index, nil
+}
+
+// BuildOrderTraderPrefix produces the prefix of BuildOrderTraderIndex covering all
+// orders of a trader in the given state. Invalid state covers the orders in any state.
+func BuildOrderTraderPrefix(trader weave.Address, state OrderState) ([]byte, error) {
+	key := morm.NewIndexKey().Address(trader)
+	if state != OrderState_Invalid {
+		key = key.Byte(byte(state))
+	}
+	return key.Key()
+}
+
+// ParseOrderTraderIndex returns the fields of a BuildOrderTraderIndex value
+func ParseOrderTraderIndex(index []byte) (trader weave.Address, state OrderState, createdAt weave.UnixTime, err error) {
+	r := morm.NewIndexKeyReader(index)
+	trader = r.Address()
+	state = OrderState(r.Byte())
+	createdAt = r.SignedTime()
+	if err := r.Done(); err != nil {
+		return nil, 0, 0, errors.Wrap(err, "order trader index")
+	}
+	return trader, state, createdAt, nil
+}
+
+type StopOrderBucket struct {
+	morm.ModelBucket
+}
+
+func NewStopOrderBucket() *StopOrderBucket {
+	b := morm.NewModelBucket("stoporder", &StopOrder{},
+		morm.WithIndex("trigger", stopTriggerIndexer, false),
+	)
+	return &StopOrderBucket{
+		ModelBucket: b,
+	}
+}
+
+// stopTriggerIndexer produces, in SQL parlance, a compound index:
+//
+//   (OrderBookID, Direction, TriggerPrice) WHERE stop.State = Pending
+//
+// so the stop orders triggered by a trade price can be found with a range
+// scan over the trigger prices of each direction
+func stopTriggerIndexer(obj orm.Object) ([]byte, error) {
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	stop, ok := obj.Value().(*StopOrder)
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrState, "expected stop order, got %T", obj.Value())
+	}
+	if stop.State != StopOrderState_Pending {
+		return nil, nil
+	}
+	return BuildStopTriggerKey(stop.OrderBookID, stop.Direction, stop.TriggerPrice)
+}
+
+// BuildStopTriggerKey produces 8 bytes OrderBookID || 1 byte Direction || 16 bytes
+// TriggerPrice.Lexographic(), the "trigger" index value of a pending stop order
+func BuildStopTriggerKey(orderBookID []byte, direction TriggerDirection, triggerPrice *Amount) ([]byte, error) {
+	index, err := morm.NewIndexKey().
+		Bytes(orderBookID, idByteSize).
+		Byte(byte(direction)).
+		Lexographic(triggerPrice, amountByteSize).
+		Key()
+	if err != nil {
+		return nil, errors.Wrap(err, "building stop trigger index")
+	}
+	return index, nil
+}
+
+// BuildStopTriggerPrefix produces the prefix of BuildStopTriggerKey covering all
+// pending stop orders of an orderbook triggered in one direction
+func BuildStopTriggerPrefix(orderBookID []byte, direction TriggerDirection) ([]byte, error) {
+	return morm.NewIndexKey().
+		Bytes(orderBookID, idByteSize).
+		Byte(byte(direction)).
+		Key()
+}
+
+// ParseStopTriggerKey returns the fields of a BuildStopTriggerKey value
+func ParseStopTriggerKey(index []byte) (orderBookID []byte, direction TriggerDirection, triggerPrice *Amount, err error) {
+	r := morm.NewIndexKeyReader(index)
+	orderBookID = r.Bytes(idByteSize)
+	direction = TriggerDirection(r.Byte())
+	lex := r.Bytes(amountByteSize)
+	if err := r.Done(); err != nil {
+		return nil, 0, nil, errors.Wrap(err, "stop trigger index")
+	}
+	triggerPrice, err = AmountFromLexographic(lex)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return orderBookID, direction, triggerPrice, nil
 }
 
 type TradeBucket struct {
@@ -147,6 +338,7 @@ func NewTradeBucket() *TradeBucket {
 	b := morm.NewModelBucket("trade", &Trade{},
 		morm.WithIndex("order", orderIDIndexer, false),
 		morm.WithIndex("orderbook", orderBookTimedIndexer, false),
+		morm.WithMultiKeyIndex("trader", tradeTraderIndexer, false),
 	)
 	return &TradeBucket{
 		ModelBucket: b,
@@ -183,16 +375,138 @@ func orderBookTimedIndexer(obj orm.Object) ([]byte, error) {
 	return BuildOrderBookTimeIndex(trade)
 }
 
-// BuildOpenOrderIndex produces 8 bytes OrderBookID || big-endian ExecutedAt
+// BuildOrderBookTimeIndex produces 8 bytes OrderBookID || big-endian ExecutedAt
 // This allows lexographical searches over the time ranges (or earliest or latest)
 // of all trades within one orderbook
 func BuildOrderBookTimeIndex(trade *Trade) ([]byte, error) {
-	res := make([]byte, 16)
-	copy(res, trade.OrderID)
 	// this would violate lexographical ordering as negatives would be highest
 	if trade.ExecutedAt < 0 {
 		return nil, errors.Wrap(errors.ErrState, "cannot index negative execution times")
 	}
-	binary.BigEndian.PutUint64(res[8:], uint64(trade.ExecutedAt))
-	return res, nil
+	return BuildOrderBookTimeKey(trade.OrderBookID, trade.ExecutedAt)
+}
+
+// BuildOrderBookTimeKey produces the "orderbook" trade index value for the given
+// orderbook and time. Use it to build the bounds of a range scan, for example
+// all trades of an orderbook between two points in time:
+//
+//   morm.ScanOptions{
+//     Start: BuildOrderBookTimeKey(orderBookID, from),
+//     End:   BuildOrderBookTimeKey(orderBookID, until),
+//   }
+func BuildOrderBookTimeKey(orderBookID []byte, t weave.UnixTime) ([]byte, error) {
+	return morm.NewIndexKey().
+		Bytes(orderBookID, idByteSize).
+		Time(t).
+		Key()
+}
+
+// ParseOrderBookTimeIndex returns the fields of a BuildOrderBookTimeIndex value
+func ParseOrderBookTimeIndex(index []byte) (orderBookID []byte, executedAt weave.UnixTime, err error) {
+	r := morm.NewIndexKeyReader(index)
+	orderBookID = r.Bytes(idByteSize)
+	executedAt = r.Time()
+	if err := r.Done(); err != nil {
+		return nil, 0, errors.Wrap(err, "orderbook time index")
+	}
+	return orderBookID, executedAt, nil
+}
+
+// tradeTraderIndexer indexes every trade twice, by
+//   (Maker, ExecutedAt) and (Taker, ExecutedAt)
+// so a wallet can list the fill history of an address, whichever side it was on.
+// A trade between orders of the same trader is indexed once
+func tradeTraderIndexer(obj orm.Object) ([][]byte, error) {
+	if obj == nil || obj.Value() == nil {
+		return nil, nil
+	}
+	trade, ok := obj.Value().(*Trade)
+	if !ok {
+		return nil, errors.Wrapf(errors.ErrState, "expected trade, got %T", obj.Value())
+	}
+	return BuildTradeTraderIndexes(trade)
+}
+
+// BuildTradeTraderIndexes produces 20 bytes Maker || 8 bytes big-endian ExecutedAt and
+// the same for the Taker, unless it is the Maker
+func BuildTradeTraderIndexes(trade *Trade) ([][]byte, error) {
+	maker, err := BuildTradeTraderKey(trade.Maker, trade.ExecutedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "maker")
+	}
+	if trade.Taker.Equals(trade.Maker) {
+		return [][]byte{maker}, nil
+	}
+	taker, err := BuildTradeTraderKey(trade.Taker, trade.ExecutedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "taker")
+	}
+	return [][]byte{maker, taker}, nil
+}
+
+// BuildTradeTraderKey produces the "trader" trade index value for the given trader
+// and time. Use the trader address alone as prefix to list all trades of a trader.
+func BuildTradeTraderKey(trader weave.Address, t weave.UnixTime) ([]byte, error) {
+	return morm.NewIndexKey().
+		Address(trader).
+		Time(t).
+		Key()
+}
+
+// ParseTradeTraderIndex returns the fields of a BuildTradeTraderIndexes value
+func ParseTradeTraderIndex(index []byte) (trader weave.Address, executedAt weave.UnixTime, err error) {
+	r := morm.NewIndexKeyReader(index)
+	trader = r.Address()
+	executedAt = r.Time()
+	if err := r.Done(); err != nil {
+		return nil, 0, errors.Wrap(err, "trade trader index")
+	}
+	return trader, executedAt, nil
+}
+
+type CandleBucket struct {
+	morm.ModelBucket
+}
+
+// NewCandleBucket stores candles under their compound ID built by BuildCandleID,
+// so no index is needed to scan them in time order
+func NewCandleBucket() *CandleBucket {
+	b := morm.NewModelBucket("candle", &Candle{})
+	return &CandleBucket{
+		ModelBucket: b,
+	}
+}
+
+// BuildCandleID produces 8 bytes OrderBookID || 1 byte interval || 8 bytes big-endian start.
+// Without the start, it is the prefix of all candles of one interval of an orderbook.
+func BuildCandleID(orderBookID []byte, interval CandleInterval, start weave.UnixTime) ([]byte, error) {
+	return morm.NewIndexKey().
+		Bytes(orderBookID, idByteSize).
+		Byte(byte(interval)).
+		Time(start).
+		Key()
+}
+
+// ParseCandleID returns the fields of a BuildCandleID value
+func ParseCandleID(id []byte) (orderBookID []byte, interval CandleInterval, start weave.UnixTime, err error) {
+	r := morm.NewIndexKeyReader(id)
+	orderBookID = r.Bytes(idByteSize)
+	interval = CandleInterval(r.Byte())
+	start = r.Time()
+	if err := r.Done(); err != nil {
+		return nil, 0, 0, errors.Wrap(err, "candle id")
+	}
+	return orderBookID, interval, start, nil
+}
+
+type TickerBucket struct {
+	morm.ModelBucket
+}
+
+// NewTickerBucket stores one ticker per orderbook, under the orderbook ID
+func NewTickerBucket() *TickerBucket {
+	b := morm.NewModelBucket("ticker", &Ticker{})
+	return &TickerBucket{
+		ModelBucket: b,
+	}
 }
@@ -1,17 +1,61 @@
 package orderbook
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
+	"github.com/iov-one/tutorial/morm"
 	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/orm"
+	"github.com/iov-one/weave/store"
 	"github.com/iov-one/weave/weavetest"
 	"github.com/iov-one/weave/weavetest/assert"
 )
 
+func TestMarketNameIndexer(t *testing.T) {
+	market := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    weavetest.NewCondition().Address(),
+		Name:     "main-market",
+	}
+
+	cases := map[string]struct {
+		obj      orm.Object
+		expected []byte
+		wantErr  *errors.Error
+	}{
+		"success": {
+			obj:      orm.NewSimpleObj(nil, market),
+			expected: []byte("main-market"),
+			wantErr:  nil,
+		},
+		"failure, obj is nil": {
+			obj:      nil,
+			expected: nil,
+			wantErr:  nil,
+		},
+		"not market": {
+			obj:      orm.NewSimpleObj(nil, new(Order)),
+			expected: nil,
+			wantErr:  errors.ErrState,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			index, err := marketNameIndexer(tc.obj)
+
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, tc.expected, index)
+		})
+	}
+}
+
 func TestMarketIDindexer(t *testing.T) {
 	marketID := weavetest.SequenceID(5)
 
@@ -181,7 +225,7 @@ func TestOpenOrderIndexer(t *testing.T) {
 		UpdatedAt:      now,
 	}
 
-	successCaseExpectedValue := []byte{0, 0, 0, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 0, 0, 0, 121, 0, 0, 0, 0, 0, 0, 8, 77}
+	successCaseExpectedValue := []byte{0, 0, 0, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 0, 0, 0, 121, 0, 0, 0, 0, 0, 0, 8, 77, 127, 255, 255, 241, 136, 110, 9, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 
 	cases := map[string]struct {
 		obj      orm.Object
@@ -228,6 +272,123 @@ func TestOpenOrderIndexer(t *testing.T) {
 	}
 }
 
+func TestParseOpenOrderPrice(t *testing.T) {
+	index := []byte{0, 0, 0, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 0, 0, 0, 121, 0, 0, 0, 0, 0, 0, 8, 77, 127, 255, 255, 241, 136, 110, 9, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	price, err := ParseOpenOrderPrice(index)
+	assert.Nil(t, err)
+	assert.Equal(t, NewAmountp(121, 2125), price)
+
+	if _, err := ParseOpenOrderPrice(index[:9]); !errors.ErrInput.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestParseIndexes(t *testing.T) {
+	order := &Order{
+		OrderBookID: weavetest.SequenceID(5),
+		Side:        Side_Bid,
+		OrderState:  OrderState_Open,
+		Price:       NewAmountp(3, 200),
+		CreatedAt:   weave.UnixTime(1000),
+		Sequence:    7,
+	}
+	index, err := BuildOpenOrderIndex(order)
+	assert.Nil(t, err)
+	orderBookID, side, price, createdAt, sequence, err := ParseOpenOrderIndex(index)
+	assert.Nil(t, err)
+	assert.Equal(t, order.OrderBookID, orderBookID)
+	assert.Equal(t, order.Side, side)
+	assert.Equal(t, order.Price, price)
+	assert.Equal(t, order.CreatedAt, createdAt)
+	assert.Equal(t, order.Sequence, sequence)
+
+	// an order created before the epoch is still ordered first
+	older := &Order{
+		OrderBookID: order.OrderBookID,
+		Side:        order.Side,
+		OrderState:  OrderState_Open,
+		Price:       order.Price,
+		CreatedAt:   weave.AsUnixTime(time.Time{}),
+		Sequence:    8,
+	}
+	olderIndex, err := BuildOpenOrderIndex(older)
+	assert.Nil(t, err)
+	if bytes.Compare(olderIndex, index) >= 0 {
+		t.Fatalf("older order not ordered first: %x >= %x", olderIndex, index)
+	}
+
+	orderbook := &OrderBook{MarketID: weavetest.SequenceID(2), AskTicker: "BTC", BidTicker: "ETHX"}
+	index, err = BuildMarketIDTickersIndex(orderbook)
+	assert.Nil(t, err)
+	marketID, ask, bid, err := ParseMarketIDTickersIndex(index)
+	assert.Nil(t, err)
+	assert.Equal(t, orderbook.MarketID, marketID)
+	assert.Equal(t, orderbook.AskTicker, ask)
+	assert.Equal(t, orderbook.BidTicker, bid)
+
+	trade := &Trade{OrderBookID: weavetest.SequenceID(3), ExecutedAt: weave.UnixTime(1000)}
+	index, err = BuildOrderBookTimeIndex(trade)
+	assert.Nil(t, err)
+	orderBookID, executedAt, err := ParseOrderBookTimeIndex(index)
+	assert.Nil(t, err)
+	assert.Equal(t, trade.OrderBookID, orderBookID)
+	assert.Equal(t, trade.ExecutedAt, executedAt)
+
+	if _, _, err := ParseOrderBookTimeIndex(index[:12]); !errors.ErrInput.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestStopTriggerIndexer(t *testing.T) {
+	stop := &StopOrder{
+		OrderBookID:  weavetest.SequenceID(5),
+		State:        StopOrderState_Pending,
+		Direction:    TriggerDirection_Below,
+		TriggerPrice: NewAmountp(121, 2125),
+	}
+	triggered := stop.Copy().(*StopOrder)
+	triggered.State = StopOrderState_Triggered
+
+	cases := map[string]struct {
+		obj      orm.Object
+		expected []byte
+		wantErr  *errors.Error
+	}{
+		"success": {
+			obj:      orm.NewSimpleObj(nil, stop),
+			expected: []byte{0, 0, 0, 0, 0, 0, 0, 5, 2, 0, 0, 0, 0, 0, 0, 0, 121, 0, 0, 0, 0, 0, 0, 8, 77},
+		},
+		"not pending": {
+			obj:      orm.NewSimpleObj(nil, triggered),
+			expected: nil,
+		},
+		"obj is nil": {
+			obj:      nil,
+			expected: nil,
+		},
+		"not stop order": {
+			obj:     orm.NewSimpleObj(nil, new(Order)),
+			wantErr: errors.ErrState,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			index, err := stopTriggerIndexer(tc.obj)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, tc.expected, index)
+		})
+	}
+
+	orderBookID, direction, price, err := ParseStopTriggerKey(cases["success"].expected)
+	assert.Nil(t, err)
+	assert.Equal(t, stop.OrderBookID, orderBookID)
+	assert.Equal(t, stop.Direction, direction)
+	assert.Equal(t, stop.TriggerPrice, price)
+}
+
 func TestOrderIDindexer(t *testing.T) {
 	now := weave.AsUnixTime(time.Now())
 
@@ -303,7 +464,7 @@ func TestOrderBookTimedIndexer(t *testing.T) {
 		ExecutedAt:  invalidTime,
 	}
 
-	successCaseExpectedValue := []byte{0, 0, 0, 0, 0, 0, 0, 14, 0, 0, 0, 0, 0, 0, 0, 1}
+	successCaseExpectedValue := []byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1}
 
 	cases := map[string]struct {
 		obj      orm.Object
@@ -345,3 +506,285 @@ func TestOrderBookTimedIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestTradeTimeRangeScan(t *testing.T) {
+	db := store.MemStore()
+	trades := NewTradeBucket()
+
+	start := time.Unix(1000, 0)
+	trade := func(orderBookID []byte, minutes int) *Trade {
+		return &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderID:     weavetest.SequenceID(1),
+			OrderBookID: orderBookID,
+			Taker:       weavetest.NewCondition().Address(),
+			Maker:       weavetest.NewCondition().Address(),
+			TakerPaid:   coin.NewCoinp(100, 0, "ETH"),
+			MakerPaid:   coin.NewCoinp(7, 0, "BTC"),
+			ExecutedAt:  weave.AsUnixTime(start.Add(time.Duration(minutes) * time.Minute)),
+		}
+	}
+	book := weavetest.SequenceID(1)
+	other := weavetest.SequenceID(2)
+	for _, tr := range []*Trade{
+		trade(book, 0),
+		trade(other, 1),
+		trade(book, 2),
+		trade(book, 4),
+		trade(book, 4),
+		trade(book, 6),
+	} {
+		assert.Nil(t, trades.Put(db, tr))
+	}
+
+	// all trades of one book in [start+1m, start+6m), newest first, one per page
+	from, err := BuildOrderBookTimeKey(book, weave.AsUnixTime(start.Add(time.Minute)))
+	assert.Nil(t, err)
+	until, err := BuildOrderBookTimeKey(book, weave.AsUnixTime(start.Add(6*time.Minute)))
+	assert.Nil(t, err)
+	opts := morm.ScanOptions{
+		Start:   from,
+		End:     until,
+		Reverse: true,
+		Limit:   1,
+	}
+	var got [][]byte
+	for page := 0; page < 5; page++ {
+		iter, err := trades.IndexRangeScan(db, "orderbook", opts)
+		assert.Nil(t, err)
+		key := iter.IndexKey()
+		var tr Trade
+		err = iter.LoadNext(&tr)
+		iter.Release()
+		if errors.ErrIteratorDone.Is(err) {
+			break
+		}
+		assert.Nil(t, err)
+		got = append(got, tr.ID)
+		// continue after the last trade seen. Trades executed at the same
+		// time share the key, those already seen are skipped with the offset
+		if bytes.Equal(key, opts.End) && opts.EndInclusive {
+			opts.Offset++
+		} else {
+			opts.End, opts.EndInclusive, opts.Offset = key, true, 1
+		}
+	}
+	assert.Equal(t, [][]byte{weavetest.SequenceID(4), weavetest.SequenceID(5), weavetest.SequenceID(3)}, got)
+}
+
+func TestOrderTraderIndexer(t *testing.T) {
+	trader := weavetest.NewCondition().Address()
+
+	order := &Order{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      trader,
+		OrderBookID: weavetest.SequenceID(5),
+		OrderState:  OrderState_Done,
+		CreatedAt:   weave.UnixTime(0x0102),
+	}
+	expected := append(append([]byte{}, trader...), 2, 128, 0, 0, 0, 0, 0, 1, 2)
+
+	cases := map[string]struct {
+		obj      orm.Object
+		expected []byte
+		wantErr  *errors.Error
+	}{
+		"success": {
+			obj:      orm.NewSimpleObj(nil, order),
+			expected: expected,
+		},
+		"failure, obj is nil": {
+			obj: nil,
+		},
+		"failure not order": {
+			obj:     orm.NewSimpleObj(nil, new(Trade)),
+			wantErr: errors.ErrState,
+		},
+		"failure, invalid trader": {
+			obj:     orm.NewSimpleObj(nil, &Order{Trader: weave.Address{1, 2}}),
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			index, err := orderTraderIndexer(tc.obj)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, tc.expected, index)
+
+			if tc.expected != nil {
+				tr, state, createdAt, err := ParseOrderTraderIndex(index)
+				assert.Nil(t, err)
+				assert.Equal(t, trader, tr)
+				assert.Equal(t, OrderState_Done, state)
+				assert.Equal(t, weave.UnixTime(0x0102), createdAt)
+			}
+		})
+	}
+
+	// an order created before the epoch is indexed and listed first
+	older := &Order{
+		Trader:     trader,
+		OrderState: OrderState_Done,
+		CreatedAt:  weave.AsUnixTime(time.Time{}),
+	}
+	olderIndex, err := BuildOrderTraderIndex(older)
+	assert.Nil(t, err)
+	if bytes.Compare(olderIndex, expected) >= 0 {
+		t.Fatalf("older order not ordered first: %x >= %x", olderIndex, expected)
+	}
+	_, _, createdAt, err := ParseOrderTraderIndex(olderIndex)
+	assert.Nil(t, err)
+	assert.Equal(t, older.CreatedAt, createdAt)
+}
+
+func TestTradeTraderIndexer(t *testing.T) {
+	maker := weavetest.NewCondition().Address()
+	taker := weavetest.NewCondition().Address()
+
+	trade := &Trade{
+		Metadata:    &weave.Metadata{Schema: 1},
+		OrderBookID: weavetest.SequenceID(2),
+		Maker:       maker,
+		Taker:       taker,
+		ExecutedAt:  weave.UnixTime(7),
+	}
+	timestamp := []byte{0, 0, 0, 0, 0, 0, 0, 7}
+
+	cases := map[string]struct {
+		obj      orm.Object
+		expected [][]byte
+		wantErr  *errors.Error
+	}{
+		"success": {
+			obj: orm.NewSimpleObj(nil, trade),
+			expected: [][]byte{
+				append(append([]byte{}, maker...), timestamp...),
+				append(append([]byte{}, taker...), timestamp...),
+			},
+		},
+		"self-trade is indexed once": {
+			obj: orm.NewSimpleObj(nil, &Trade{Maker: maker, Taker: maker, ExecutedAt: 7}),
+			expected: [][]byte{
+				append(append([]byte{}, maker...), timestamp...),
+			},
+		},
+		"failure, obj is nil": {
+			obj: nil,
+		},
+		"failure not trade": {
+			obj:     orm.NewSimpleObj(nil, new(Order)),
+			wantErr: errors.ErrState,
+		},
+		"failure, negative execution time": {
+			obj:     orm.NewSimpleObj(nil, &Trade{Maker: maker, Taker: taker, ExecutedAt: -1}),
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			index, err := tradeTraderIndexer(tc.obj)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, tc.expected, index)
+		})
+	}
+}
+
+func TestTraderQueries(t *testing.T) {
+	db := store.MemStore()
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+
+	alice := weavetest.NewCondition().Address()
+	bob := weavetest.NewCondition().Address()
+
+	orders := NewOrderBucket()
+	for i, o := range []struct {
+		trader weave.Address
+		state  OrderState
+	}{
+		{alice, OrderState_Open},
+		{bob, OrderState_Open},
+		{alice, OrderState_Done},
+		{alice, OrderState_Open},
+	} {
+		order := &Order{
+			Metadata:       &weave.Metadata{Schema: 1},
+			Trader:         o.trader,
+			OrderBookID:    weavetest.SequenceID(1),
+			Side:           Side_Ask,
+			OrderState:     o.state,
+			OriginalOffer:  coin.NewCoinp(10, 0, "BTC"),
+			RemainingOffer: coin.NewCoinp(10, 0, "BTC"),
+			Price:          NewAmountp(2, 0),
+			CreatedAt:      weave.UnixTime(1000 + i),
+			UpdatedAt:      weave.UnixTime(1000 + i),
+		}
+		assert.Nil(t, orders.Put(db, order))
+	}
+
+	trades := NewTradeBucket()
+	for i, tr := range [][2]weave.Address{{alice, bob}, {bob, bob}} {
+		trade := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: weavetest.SequenceID(1),
+			OrderID:     weavetest.SequenceID(1),
+			Maker:       tr[0],
+			Taker:       tr[1],
+			MakerPaid:   coin.NewCoinp(1, 0, "BTC"),
+			TakerPaid:   coin.NewCoinp(2, 0, "ETH"),
+			ExecutedAt:  weave.UnixTime(2000 + i),
+		}
+		assert.Nil(t, trades.Put(db, trade))
+	}
+
+	aliceOpen, err := BuildOrderTraderPrefix(alice, OrderState_Open)
+	assert.Nil(t, err)
+	aliceAll, err := BuildOrderTraderPrefix(alice, OrderState_Invalid)
+	assert.Nil(t, err)
+
+	cases := map[string]struct {
+		path    string
+		prefix  []byte
+		wantIDs [][]byte
+	}{
+		"open orders of a trader": {
+			path:    "/orders/trader",
+			prefix:  aliceOpen,
+			wantIDs: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(4)},
+		},
+		"all orders of a trader": {
+			path:    "/orders/trader",
+			prefix:  aliceAll,
+			wantIDs: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(4), weavetest.SequenceID(3)},
+		},
+		"trades as maker": {
+			path:    "/trades/trader",
+			prefix:  alice,
+			wantIDs: [][]byte{weavetest.SequenceID(1)},
+		},
+		"trades as maker and taker are listed once": {
+			path:    "/trades/trader",
+			prefix:  bob,
+			wantIDs: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(2)},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			models, err := qr.Handler(tc.path).Query(db, weave.PrefixQueryMod, tc.prefix)
+			assert.Nil(t, err)
+			var ids [][]byte
+			for _, m := range models {
+				// keys are returned with the bucket prefix
+				ids = append(ids, m.Key[len(m.Key)-8:])
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
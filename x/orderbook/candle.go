@@ -0,0 +1,117 @@
+package orderbook
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+// candleIntervals are maintained for every orderbook
+var candleIntervals = []CandleInterval{
+	CandleInterval_Minute,
+	CandleInterval_Hour,
+	CandleInterval_Day,
+}
+
+// Seconds returns the length of the interval, zero for an unknown interval
+func (i CandleInterval) Seconds() int64 {
+	switch i {
+	case CandleInterval_Minute:
+		return 60
+	case CandleInterval_Hour:
+		return 60 * 60
+	case CandleInterval_Day:
+		return 24 * 60 * 60
+	default:
+		return 0
+	}
+}
+
+// StartOf returns the start of the interval containing t
+func (i CandleInterval) StartOf(t weave.UnixTime) weave.UnixTime {
+	secs := i.Seconds()
+	if secs == 0 {
+		return t
+	}
+	start := int64(t) - int64(t)%secs
+	// round towards the past for times before the epoch
+	if start > int64(t) {
+		start -= secs
+	}
+	return weave.UnixTime(start)
+}
+
+// tradePrice returns the price of the trade in bid ticker per unit of the ask
+// ticker, along with the traded amounts of the ask (base) and bid (quote) ticker
+func tradePrice(orderbook *OrderBook, trade *Trade) (*Amount, coin.Coin, coin.Coin, error) {
+	base, quote := *trade.MakerPaid, *trade.TakerPaid
+	if base.Ticker != orderbook.AskTicker {
+		base, quote = quote, base
+	}
+	price, err := NewAmountp(quote.Whole, quote.Fractional).
+		Divide(NewAmountp(base.Whole, base.Fractional), RoundHalfUp)
+	if err != nil {
+		return nil, base, quote, errors.Wrap(err, "trade price")
+	}
+	return price, base, quote, nil
+}
+
+// Record adds a trade of the orderbook to its candles of every interval
+func (b *CandleBucket) Record(db weave.KVStore, orderbook *OrderBook, trade *Trade) error {
+	price, base, quote, err := tradePrice(orderbook, trade)
+	if err != nil {
+		return err
+	}
+
+	for _, interval := range candleIntervals {
+		start := interval.StartOf(trade.ExecutedAt)
+		id, err := BuildCandleID(trade.OrderBookID, interval, start)
+		if err != nil {
+			return errors.Wrap(err, "candle id")
+		}
+
+		var candle Candle
+		switch err := b.One(db, id, &candle); {
+		case errors.ErrNotFound.Is(err):
+			candle = Candle{
+				Metadata:    &weave.Metadata{Schema: 1},
+				ID:          id,
+				OrderBookID: trade.OrderBookID,
+				Interval:    interval,
+				Start:       start,
+				Open:        price.Clone(),
+				High:        price.Clone(),
+				Low:         price.Clone(),
+				BaseVolume:  coin.NewCoinp(0, 0, base.Ticker),
+				QuoteVolume: coin.NewCoinp(0, 0, quote.Ticker),
+			}
+		case err != nil:
+			return errors.Wrap(err, "cannot load candle")
+		default:
+			if price.Compare(candle.High) > 0 {
+				candle.High = price.Clone()
+			}
+			if price.Compare(candle.Low) < 0 {
+				candle.Low = price.Clone()
+			}
+		}
+
+		candle.Close = price.Clone()
+		baseVolume, err := candle.BaseVolume.Add(base)
+		if err != nil {
+			return errors.Wrap(err, "base volume")
+		}
+		candle.BaseVolume = &baseVolume
+		quoteVolume, err := candle.QuoteVolume.Add(quote)
+		if err != nil {
+			return errors.Wrap(err, "quote volume")
+		}
+		candle.QuoteVolume = &quoteVolume
+		candle.TradeCount++
+
+		if err := b.Put(db, &candle); err != nil {
+			return errors.Wrap(err, "cannot store candle")
+		}
+	}
+	return nil
+}
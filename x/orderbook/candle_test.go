@@ -0,0 +1,172 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+func TestCandleIntervalStartOf(t *testing.T) {
+	cases := map[string]struct {
+		interval CandleInterval
+		t        weave.UnixTime
+		want     weave.UnixTime
+	}{
+		"minute":              {CandleInterval_Minute, 3725, 3720},
+		"minute at the start": {CandleInterval_Minute, 3720, 3720},
+		"hour":                {CandleInterval_Hour, 3725, 3600},
+		"day":                 {CandleInterval_Day, 90000, 86400},
+		"before the epoch":    {CandleInterval_Minute, -5, -60},
+		"unknown interval":    {CandleInterval_Invalid, 3725, 3725},
+	}
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.interval.StartOf(tc.t))
+		})
+	}
+}
+
+func TestCandleRecord(t *testing.T) {
+	book := weavetest.SequenceID(1)
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		ID:        book,
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+
+	// trade is priced in ETH per BTC, whoever was the maker
+	trade := func(btc, eth int64, at weave.UnixTime, makerAsks bool) *Trade {
+		t := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: book,
+			OrderID:     weavetest.SequenceID(1),
+			MakerPaid:   coin.NewCoinp(btc, 0, "BTC"),
+			TakerPaid:   coin.NewCoinp(eth, 0, "ETH"),
+			ExecutedAt:  at,
+		}
+		if !makerAsks {
+			t.MakerPaid, t.TakerPaid = t.TakerPaid, t.MakerPaid
+		}
+		return t
+	}
+
+	cases := map[string]struct {
+		trades   []*Trade
+		interval CandleInterval
+		start    weave.UnixTime
+		want     *Candle
+	}{
+		"single trade": {
+			trades:   []*Trade{trade(2, 5, 3725, true)},
+			interval: CandleInterval_Minute,
+			start:    3720,
+			want: &Candle{
+				Open:        NewAmountp(2, 500000000),
+				High:        NewAmountp(2, 500000000),
+				Low:         NewAmountp(2, 500000000),
+				Close:       NewAmountp(2, 500000000),
+				BaseVolume:  coin.NewCoinp(2, 0, "BTC"),
+				QuoteVolume: coin.NewCoinp(5, 0, "ETH"),
+				TradeCount:  1,
+			},
+		},
+		"trades in the same interval": {
+			trades: []*Trade{
+				trade(1, 3, 3725, true),
+				trade(1, 5, 3730, false),
+				trade(1, 1, 3740, true),
+				trade(1, 2, 3779, false),
+			},
+			interval: CandleInterval_Minute,
+			start:    3720,
+			want: &Candle{
+				Open:        NewAmountp(3, 0),
+				High:        NewAmountp(5, 0),
+				Low:         NewAmountp(1, 0),
+				Close:       NewAmountp(2, 0),
+				BaseVolume:  coin.NewCoinp(4, 0, "BTC"),
+				QuoteVolume: coin.NewCoinp(11, 0, "ETH"),
+				TradeCount:  4,
+			},
+		},
+		"trades in different minutes of the same hour": {
+			trades: []*Trade{
+				trade(1, 3, 3725, true),
+				trade(1, 5, 3800, true),
+				trade(1, 4, 7199, true),
+			},
+			interval: CandleInterval_Hour,
+			start:    3600,
+			want: &Candle{
+				Open:        NewAmountp(3, 0),
+				High:        NewAmountp(5, 0),
+				Low:         NewAmountp(3, 0),
+				Close:       NewAmountp(4, 0),
+				BaseVolume:  coin.NewCoinp(3, 0, "BTC"),
+				QuoteVolume: coin.NewCoinp(12, 0, "ETH"),
+				TradeCount:  3,
+			},
+		},
+		"trades of the next minute are not counted": {
+			trades: []*Trade{
+				trade(1, 3, 3725, true),
+				trade(1, 5, 3780, true),
+			},
+			interval: CandleInterval_Minute,
+			start:    3720,
+			want: &Candle{
+				Open:        NewAmountp(3, 0),
+				High:        NewAmountp(3, 0),
+				Low:         NewAmountp(3, 0),
+				Close:       NewAmountp(3, 0),
+				BaseVolume:  coin.NewCoinp(1, 0, "BTC"),
+				QuoteVolume: coin.NewCoinp(3, 0, "ETH"),
+				TradeCount:  1,
+			},
+		},
+		"price is rounded": {
+			trades:   []*Trade{trade(3, 2, 3725, true)},
+			interval: CandleInterval_Day,
+			start:    0,
+			want: &Candle{
+				Open:        NewAmountp(0, 666666667),
+				High:        NewAmountp(0, 666666667),
+				Low:         NewAmountp(0, 666666667),
+				Close:       NewAmountp(0, 666666667),
+				BaseVolume:  coin.NewCoinp(3, 0, "BTC"),
+				QuoteVolume: coin.NewCoinp(2, 0, "ETH"),
+				TradeCount:  1,
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			db := store.MemStore()
+			candles := NewCandleBucket()
+			for _, trade := range tc.trades {
+				assert.Nil(t, candles.Record(db, orderbook, trade))
+			}
+
+			id, err := BuildCandleID(book, tc.interval, tc.start)
+			assert.Nil(t, err)
+			var got Candle
+			assert.Nil(t, candles.One(db, id, &got))
+			assert.Nil(t, got.Validate())
+
+			want := *tc.want
+			want.Metadata = &weave.Metadata{Schema: 1}
+			want.ID = id
+			want.OrderBookID = book
+			want.Interval = tc.interval
+			want.Start = tc.start
+			assert.Equal(t, &want, &got)
+		})
+	}
+}
@@ -90,12 +90,292 @@ func (Side) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_492308ae36fa08c1, []int{1}
 }
 
+// OrderType determines how the price of an order is interpreted
+type OrderType int32
+
+const (
+	// Limit orders trade at their price or better, this is the default
+	OrderType_Limit OrderType = 0
+	// Market orders trade at the best available prices, the price of the
+	// order is only the worst price accepted. They never rest on the book.
+	OrderType_Market OrderType = 1
+)
+
+var OrderType_name = map[int32]string{
+	0: "ORDER_TYPE_LIMIT",
+	1: "ORDER_TYPE_MARKET",
+}
+
+var OrderType_value = map[string]int32{
+	"ORDER_TYPE_LIMIT":  0,
+	"ORDER_TYPE_MARKET": 1,
+}
+
+func (x OrderType) String() string {
+	return proto.EnumName(OrderType_name, int32(x))
+}
+
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{2}
+}
+
+// TimeInForce determines what happens to the part of an order that cannot
+// be matched immediately
+type TimeInForce int32
+
+const (
+	// Good till cancel orders rest on the book until filled or cancelled,
+	// this is the default
+	TimeInForce_GoodTillCancel TimeInForce = 0
+	// Immediate or cancel orders match what they can, the rest is refunded
+	TimeInForce_ImmediateOrCancel TimeInForce = 1
+	// Fill or kill orders must be filled completely, otherwise the whole
+	// transaction fails
+	TimeInForce_FillOrKill TimeInForce = 2
+)
+
+var TimeInForce_name = map[int32]string{
+	0: "TIME_IN_FORCE_GTC",
+	1: "TIME_IN_FORCE_IOC",
+	2: "TIME_IN_FORCE_FOK",
+}
+
+var TimeInForce_value = map[string]int32{
+	"TIME_IN_FORCE_GTC": 0,
+	"TIME_IN_FORCE_IOC": 1,
+	"TIME_IN_FORCE_FOK": 2,
+}
+
+func (x TimeInForce) String() string {
+	return proto.EnumName(TimeInForce_name, int32(x))
+}
+
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{3}
+}
+
+// SelfTradePrevention determines what happens when an incoming order would
+// match a resting order of the same trader. They never trade with each other
+type SelfTradePrevention int32
+
+const (
+	// The incoming order is cancelled, what it filled before is kept.
+	// This is the default
+	SelfTradePrevention_CancelNewest SelfTradePrevention = 0
+	// The resting order is cancelled and matching continues
+	SelfTradePrevention_CancelOldest SelfTradePrevention = 1
+	// Both orders are cancelled
+	SelfTradePrevention_CancelBoth SelfTradePrevention = 2
+	// Both orders are reduced by what they would have traded, which is
+	// refunded, and matching continues. An order reduced to nothing is cancelled
+	SelfTradePrevention_Decrement SelfTradePrevention = 3
+)
+
+var SelfTradePrevention_name = map[int32]string{
+	0: "SELF_TRADE_PREVENTION_CANCEL_NEWEST",
+	1: "SELF_TRADE_PREVENTION_CANCEL_OLDEST",
+	2: "SELF_TRADE_PREVENTION_CANCEL_BOTH",
+	3: "SELF_TRADE_PREVENTION_DECREMENT",
+}
+
+var SelfTradePrevention_value = map[string]int32{
+	"SELF_TRADE_PREVENTION_CANCEL_NEWEST": 0,
+	"SELF_TRADE_PREVENTION_CANCEL_OLDEST": 1,
+	"SELF_TRADE_PREVENTION_CANCEL_BOTH":   2,
+	"SELF_TRADE_PREVENTION_DECREMENT":     3,
+}
+
+func (x SelfTradePrevention) String() string {
+	return proto.EnumName(SelfTradePrevention_name, int32(x))
+}
+
+func (SelfTradePrevention) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{4}
+}
+
+// PostOnly guarantees that an order never takes liquidity, it only rests on
+// the book. Post-only orders must be good till cancel limit orders
+type PostOnly int32
+
+const (
+	// The order may match resting orders, this is the default
+	PostOnly_Disabled PostOnly = 0
+	// An order that would match a resting order is rejected
+	PostOnly_Reject PostOnly = 1
+	// An order that would match a resting order is repriced one tick away
+	// from the best opposite price instead
+	PostOnly_Reprice PostOnly = 2
+)
+
+var PostOnly_name = map[int32]string{
+	0: "POST_ONLY_DISABLED",
+	1: "POST_ONLY_REJECT",
+	2: "POST_ONLY_REPRICE",
+}
+
+var PostOnly_value = map[string]int32{
+	"POST_ONLY_DISABLED": 0,
+	"POST_ONLY_REJECT":   1,
+	"POST_ONLY_REPRICE":  2,
+}
+
+func (x PostOnly) String() string {
+	return proto.EnumName(PostOnly_name, int32(x))
+}
+
+func (PostOnly) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{5}
+}
+
+// CancelReason records why an order was cancelled
+type CancelReason int32
+
+const (
+	// The order was not cancelled
+	CancelReason_None CancelReason = 0
+	// Cancelled by the trader
+	CancelReason_Trader CancelReason = 1
+	// Cancelled at its expiration time
+	CancelReason_Expired CancelReason = 2
+	// Whatever an order that does not rest on the book could not match
+	CancelReason_Unfilled CancelReason = 3
+	// Cancelled by self-trade prevention
+	CancelReason_SelfTrade CancelReason = 4
+)
+
+var CancelReason_name = map[int32]string{
+	0: "CANCEL_REASON_NONE",
+	1: "CANCEL_REASON_TRADER",
+	2: "CANCEL_REASON_EXPIRED",
+	3: "CANCEL_REASON_UNFILLED",
+	4: "CANCEL_REASON_SELF_TRADE",
+}
+
+var CancelReason_value = map[string]int32{
+	"CANCEL_REASON_NONE":       0,
+	"CANCEL_REASON_TRADER":     1,
+	"CANCEL_REASON_EXPIRED":    2,
+	"CANCEL_REASON_UNFILLED":   3,
+	"CANCEL_REASON_SELF_TRADE": 4,
+}
+
+func (x CancelReason) String() string {
+	return proto.EnumName(CancelReason_name, int32(x))
+}
+
+func (CancelReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{6}
+}
+
+// TriggerDirection determines which move of the last trade price of an
+// orderbook activates a stop order
+type TriggerDirection int32
+
+const (
+	TriggerDirection_Invalid TriggerDirection = 0
+	// Activated once the last trade price is at or above the trigger price
+	TriggerDirection_Above TriggerDirection = 1
+	// Activated once the last trade price is at or below the trigger price
+	TriggerDirection_Below TriggerDirection = 2
+)
+
+var TriggerDirection_name = map[int32]string{
+	0: "TRIGGER_DIRECTION_INVALID",
+	1: "TRIGGER_DIRECTION_ABOVE",
+	2: "TRIGGER_DIRECTION_BELOW",
+}
+
+var TriggerDirection_value = map[string]int32{
+	"TRIGGER_DIRECTION_INVALID": 0,
+	"TRIGGER_DIRECTION_ABOVE":   1,
+	"TRIGGER_DIRECTION_BELOW":   2,
+}
+
+func (x TriggerDirection) String() string {
+	return proto.EnumName(TriggerDirection_name, int32(x))
+}
+
+func (TriggerDirection) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{7}
+}
+
+type StopOrderState int32
+
+const (
+	StopOrderState_Invalid StopOrderState = 0
+	// Pending stop orders wait for their trigger with the offer escrowed
+	StopOrderState_Pending StopOrderState = 1
+	// Triggered stop orders were turned into an order
+	StopOrderState_Triggered StopOrderState = 2
+	// Cancelled stop orders were refunded before they were triggered
+	StopOrderState_Cancel StopOrderState = 3
+	// Failed stop orders were triggered, but could not be placed as an order
+	// and were refunded
+	StopOrderState_Failed StopOrderState = 4
+)
+
+var StopOrderState_name = map[int32]string{
+	0: "STOP_ORDER_STATE_INVALID",
+	1: "STOP_ORDER_STATE_PENDING",
+	2: "STOP_ORDER_STATE_TRIGGERED",
+	3: "STOP_ORDER_STATE_CANCEL",
+	4: "STOP_ORDER_STATE_FAILED",
+}
+
+var StopOrderState_value = map[string]int32{
+	"STOP_ORDER_STATE_INVALID":   0,
+	"STOP_ORDER_STATE_PENDING":   1,
+	"STOP_ORDER_STATE_TRIGGERED": 2,
+	"STOP_ORDER_STATE_CANCEL":    3,
+	"STOP_ORDER_STATE_FAILED":    4,
+}
+
+func (x StopOrderState) String() string {
+	return proto.EnumName(StopOrderState_name, int32(x))
+}
+
+func (StopOrderState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{8}
+}
+
+// CandleInterval is the time span aggregated by one candle
+type CandleInterval int32
+
+const (
+	CandleInterval_Invalid CandleInterval = 0
+	CandleInterval_Minute  CandleInterval = 1
+	CandleInterval_Hour    CandleInterval = 2
+	CandleInterval_Day     CandleInterval = 3
+)
+
+var CandleInterval_name = map[int32]string{
+	0: "CANDLE_INTERVAL_INVALID",
+	1: "CANDLE_INTERVAL_MINUTE",
+	2: "CANDLE_INTERVAL_HOUR",
+	3: "CANDLE_INTERVAL_DAY",
+}
+
+var CandleInterval_value = map[string]int32{
+	"CANDLE_INTERVAL_INVALID": 0,
+	"CANDLE_INTERVAL_MINUTE":  1,
+	"CANDLE_INTERVAL_HOUR":    2,
+	"CANDLE_INTERVAL_DAY":     3,
+}
+
+func (x CandleInterval) String() string {
+	return proto.EnumName(CandleInterval_name, int32(x))
+}
+
+func (CandleInterval) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{9}
+}
+
 // Amount is like a coin.Coin but without a ticker.
 // We use it where a ticker is impossible (like quantity)
 // For offers where ticker is implied, we still use coin.Coin
 // as we inherit much math from there.
 //
-// TODO: work out how to multiply Amount * Coin
+// Amount * Coin is provided by Amount.MulCoin
 type Amount struct {
 	// Whole coins, -10^15 < integer < 10^15
 	Whole int64 `protobuf:"varint,1,opt,name=whole,proto3" json:"whole,omitempty"`
@@ -173,7 +453,29 @@ type Order struct {
 	// created_at defines create time of an order
 	CreatedAt github_com_iov_one_weave.UnixTime `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"created_at,omitempty"`
 	// updated_at defines update time of an order
-	UpdatedAt github_com_iov_one_weave.UnixTime `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"updated_at,omitempty"`
+	UpdatedAt   github_com_iov_one_weave.UnixTime `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"updated_at,omitempty"`
+	OrderType   OrderType                         `protobuf:"varint,13,opt,name=order_type,json=orderType,proto3,enum=orderbook.OrderType" json:"order_type,omitempty"`
+	TimeInForce TimeInForce                       `protobuf:"varint,14,opt,name=time_in_force,json=timeInForce,proto3,enum=orderbook.TimeInForce" json:"time_in_force,omitempty"`
+	// expires_at is when a resting order is cancelled automatically, zero never expires
+	ExpiresAt github_com_iov_one_weave.UnixTime `protobuf:"varint,15,opt,name=expires_at,json=expiresAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"expires_at,omitempty"`
+	// ExpirationTaskID references the cron task that expires this order.
+	// The task result can be queried under this ID once it executed
+	ExpirationTaskID []byte `protobuf:"bytes,16,opt,name=expiration_task_id,json=expirationTaskId,proto3" json:"expiration_task_id,omitempty"`
+	// DiscountedFee pays the fees of all fills of this order in the fee ticker
+	// of the configuration, at a discount
+	DiscountedFee bool `protobuf:"varint,17,opt,name=discounted_fee,json=discountedFee,proto3" json:"discounted_fee,omitempty"`
+	// SelfTradePrevention is applied when this order matches a resting order
+	// of the same trader
+	SelfTradePrevention SelfTradePrevention `protobuf:"varint,18,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=orderbook.SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	// CancelReason is set once the order is cancelled
+	CancelReason CancelReason `protobuf:"varint,19,opt,name=cancel_reason,json=cancelReason,proto3,enum=orderbook.CancelReason" json:"cancel_reason,omitempty"`
+	// PostOnly orders never matched when they were created. The price is
+	// the one they rest at, after repricing
+	PostOnly PostOnly `protobuf:"varint,20,opt,name=post_only,json=postOnly,proto3,enum=orderbook.PostOnly" json:"post_only,omitempty"`
+	// Sequence orders the orders of an orderbook with the same price and
+	// CreatedAt. It is taken from the orderbook when the order is placed, and
+	// again when a replacement moves it to the back of its price level
+	Sequence uint64 `protobuf:"varint,21,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *Order) Reset()         { *m = Order{} }
@@ -293,154 +595,108 @@ func (m *Order) GetUpdatedAt() github_com_iov_one_weave.UnixTime {
 	return 0
 }
 
-// Trade is a settled partial/full order
-// We store these as independent entities to help with queries to map
-// the prices over time. They are also referenced by the Orders, so we can
-// see how much was fulfilled.
-//
-// Ask and Bid tickers are "inherited" from the orderbook
-type Trade struct {
-	Metadata    *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	ID          []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	OrderBookID []byte          `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
-	OrderID     []byte          `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	// Address of taker (this is an order that was instantly fulfilled)
-	Taker github_com_iov_one_weave.Address `protobuf:"bytes,5,opt,name=taker,proto3,casttype=github.com/iov-one/weave.Address" json:"taker,omitempty"`
-	// Address of maker (this is an order that was stored first before fulfillment)
-	Maker github_com_iov_one_weave.Address `protobuf:"bytes,6,opt,name=maker,proto3,casttype=github.com/iov-one/weave.Address" json:"maker,omitempty"`
-	// this is how much each side paid (they got the opposite one)
-	MakerPaid *coin.Coin `protobuf:"bytes,7,opt,name=maker_paid,json=makerPaid,proto3" json:"maker_paid,omitempty"`
-	TakerPaid *coin.Coin `protobuf:"bytes,8,opt,name=taker_paid,json=takerPaid,proto3" json:"taker_paid,omitempty"`
-	// executed_at defines execution time of an order
-	ExecutedAt github_com_iov_one_weave.UnixTime `protobuf:"varint,9,opt,name=executed_at,json=executedAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"executed_at,omitempty"`
-}
-
-func (m *Trade) Reset()         { *m = Trade{} }
-func (m *Trade) String() string { return proto.CompactTextString(m) }
-func (*Trade) ProtoMessage()    {}
-func (*Trade) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{2}
-}
-func (m *Trade) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Trade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Trade.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *Trade) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Trade.Merge(m, src)
-}
-func (m *Trade) XXX_Size() int {
-	return m.Size()
-}
-func (m *Trade) XXX_DiscardUnknown() {
-	xxx_messageInfo_Trade.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Trade proto.InternalMessageInfo
-
-func (m *Trade) GetMetadata() *weave.Metadata {
+func (m *Order) GetOrderType() OrderType {
 	if m != nil {
-		return m.Metadata
+		return m.OrderType
 	}
-	return nil
+	return OrderType_Limit
 }
 
-func (m *Trade) GetID() []byte {
+func (m *Order) GetTimeInForce() TimeInForce {
 	if m != nil {
-		return m.ID
+		return m.TimeInForce
 	}
-	return nil
+	return TimeInForce_GoodTillCancel
 }
 
-func (m *Trade) GetOrderBookID() []byte {
+func (m *Order) GetExpiresAt() github_com_iov_one_weave.UnixTime {
 	if m != nil {
-		return m.OrderBookID
+		return m.ExpiresAt
 	}
-	return nil
+	return 0
 }
 
-func (m *Trade) GetOrderID() []byte {
+func (m *Order) GetExpirationTaskID() []byte {
 	if m != nil {
-		return m.OrderID
+		return m.ExpirationTaskID
 	}
 	return nil
 }
 
-func (m *Trade) GetTaker() github_com_iov_one_weave.Address {
+func (m *Order) GetDiscountedFee() bool {
 	if m != nil {
-		return m.Taker
+		return m.DiscountedFee
 	}
-	return nil
+	return false
 }
 
-func (m *Trade) GetMaker() github_com_iov_one_weave.Address {
+func (m *Order) GetSelfTradePrevention() SelfTradePrevention {
 	if m != nil {
-		return m.Maker
+		return m.SelfTradePrevention
 	}
-	return nil
+	return SelfTradePrevention_CancelNewest
 }
 
-func (m *Trade) GetMakerPaid() *coin.Coin {
+func (m *Order) GetCancelReason() CancelReason {
 	if m != nil {
-		return m.MakerPaid
+		return m.CancelReason
 	}
-	return nil
+	return CancelReason_None
 }
 
-func (m *Trade) GetTakerPaid() *coin.Coin {
+func (m *Order) GetPostOnly() PostOnly {
 	if m != nil {
-		return m.TakerPaid
+		return m.PostOnly
 	}
-	return nil
+	return PostOnly_Disabled
 }
 
-func (m *Trade) GetExecutedAt() github_com_iov_one_weave.UnixTime {
+func (m *Order) GetSequence() uint64 {
 	if m != nil {
-		return m.ExecutedAt
+		return m.Sequence
 	}
 	return 0
 }
 
-// An Orderbook lives in a market and represents a ask/bid pair.
-// We only allow one orderbook for each pair. To avoid confusion,
-// we enforce ask_ticker < bid_ticker so their cannot be two orderbooks
-// for the same trading pair.
-//
-// Orders and Trades must reference an orderbook and use those tickers as denominations
-type OrderBook struct {
-	Metadata  *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	ID        []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	MarketID  []byte          `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	AskTicker string          `protobuf:"bytes,4,opt,name=ask_ticker,json=askTicker,proto3" json:"ask_ticker,omitempty"`
-	BidTicker string          `protobuf:"bytes,5,opt,name=bid_ticker,json=bidTicker,proto3" json:"bid_ticker,omitempty"`
-	// repeated Order ask_orders = 5;
-	TotalAskCount int64 `protobuf:"varint,6,opt,name=total_ask_count,json=totalAskCount,proto3" json:"total_ask_count,omitempty"`
-	// repeated Order bid_orders = 7;
-	TotalBidCount int64 `protobuf:"varint,7,opt,name=total_bid_count,json=totalBidCount,proto3" json:"total_bid_count,omitempty"`
+// StopOrder is placed on the orderbook as a normal order once the last trade
+// price crosses its trigger price. Market stop orders become market orders,
+// limit stop orders become limit orders. The offer is escrowed from the start.
+type StopOrder struct {
+	Metadata    *weave.Metadata                  `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ID          []byte                           `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Trader      github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	OrderBookID []byte                           `protobuf:"bytes,4,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Side        Side                             `protobuf:"varint,5,opt,name=side,proto3,enum=orderbook.Side" json:"side,omitempty"`
+	State       StopOrderState                   `protobuf:"varint,6,opt,name=state,proto3,enum=orderbook.StopOrderState" json:"state,omitempty"`
+	Direction   TriggerDirection                 `protobuf:"varint,7,opt,name=direction,proto3,enum=orderbook.TriggerDirection" json:"direction,omitempty"`
+	// TriggerPrice is in bid ticker per unit of the ask ticker, like the last
+	// price of the orderbook ticker, whatever the side of the order
+	TriggerPrice *Amount    `protobuf:"bytes,8,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`
+	Offer        *coin.Coin `protobuf:"bytes,9,opt,name=offer,proto3" json:"offer,omitempty"`
+	// Price of the order once activated, in the ticker of the opposite side
+	Price               *Amount                           `protobuf:"bytes,10,opt,name=price,proto3" json:"price,omitempty"`
+	OrderType           OrderType                         `protobuf:"varint,11,opt,name=order_type,json=orderType,proto3,enum=orderbook.OrderType" json:"order_type,omitempty"`
+	TimeInForce         TimeInForce                       `protobuf:"varint,12,opt,name=time_in_force,json=timeInForce,proto3,enum=orderbook.TimeInForce" json:"time_in_force,omitempty"`
+	SelfTradePrevention SelfTradePrevention               `protobuf:"varint,13,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=orderbook.SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	DiscountedFee       bool                              `protobuf:"varint,14,opt,name=discounted_fee,json=discountedFee,proto3" json:"discounted_fee,omitempty"`
+	CreatedAt           github_com_iov_one_weave.UnixTime `protobuf:"varint,15,opt,name=created_at,json=createdAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"created_at,omitempty"`
+	UpdatedAt           github_com_iov_one_weave.UnixTime `protobuf:"varint,16,opt,name=updated_at,json=updatedAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"updated_at,omitempty"`
+	// OrderID references the order created once the stop order was triggered
+	OrderID []byte `protobuf:"bytes,17,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 }
 
-func (m *OrderBook) Reset()         { *m = OrderBook{} }
-func (m *OrderBook) String() string { return proto.CompactTextString(m) }
-func (*OrderBook) ProtoMessage()    {}
-func (*OrderBook) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{3}
+func (m *StopOrder) Reset()         { *m = StopOrder{} }
+func (m *StopOrder) String() string { return proto.CompactTextString(m) }
+func (*StopOrder) ProtoMessage()    {}
+func (*StopOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{2}
 }
-func (m *OrderBook) XXX_Unmarshal(b []byte) error {
+func (m *StopOrder) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *OrderBook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StopOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_OrderBook.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StopOrder.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -450,170 +706,176 @@ func (m *OrderBook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *OrderBook) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderBook.Merge(m, src)
+func (m *StopOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopOrder.Merge(m, src)
 }
-func (m *OrderBook) XXX_Size() int {
+func (m *StopOrder) XXX_Size() int {
 	return m.Size()
 }
-func (m *OrderBook) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderBook.DiscardUnknown(m)
+func (m *StopOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopOrder.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OrderBook proto.InternalMessageInfo
+var xxx_messageInfo_StopOrder proto.InternalMessageInfo
 
-func (m *OrderBook) GetMetadata() *weave.Metadata {
+func (m *StopOrder) GetMetadata() *weave.Metadata {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *OrderBook) GetID() []byte {
+func (m *StopOrder) GetID() []byte {
 	if m != nil {
 		return m.ID
 	}
 	return nil
 }
 
-func (m *OrderBook) GetMarketID() []byte {
+func (m *StopOrder) GetTrader() github_com_iov_one_weave.Address {
 	if m != nil {
-		return m.MarketID
+		return m.Trader
 	}
 	return nil
 }
 
-func (m *OrderBook) GetAskTicker() string {
+func (m *StopOrder) GetOrderBookID() []byte {
 	if m != nil {
-		return m.AskTicker
+		return m.OrderBookID
 	}
-	return ""
+	return nil
 }
 
-func (m *OrderBook) GetBidTicker() string {
+func (m *StopOrder) GetSide() Side {
 	if m != nil {
-		return m.BidTicker
+		return m.Side
 	}
-	return ""
+	return Side_Invalid
 }
 
-func (m *OrderBook) GetTotalAskCount() int64 {
+func (m *StopOrder) GetState() StopOrderState {
 	if m != nil {
-		return m.TotalAskCount
+		return m.State
 	}
-	return 0
+	return StopOrderState_Invalid
 }
 
-func (m *OrderBook) GetTotalBidCount() int64 {
+func (m *StopOrder) GetDirection() TriggerDirection {
 	if m != nil {
-		return m.TotalBidCount
+		return m.Direction
 	}
-	return 0
+	return TriggerDirection_Invalid
 }
 
-// A market holds many Orderbooks and is just a grouping for now.
-// Probably we only want one market on a chain, but we could add additional
-// rules to each market and then allow multiple.
-//
-// Currently Markets can only be created in the genesis file
-type Market struct {
-	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	ID       []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	// Owner is allowed to create new orderbooks in this Market
-	Owner github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=owner,proto3,casttype=github.com/iov-one/weave.Address" json:"owner,omitempty"`
-	// Market name
-	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+func (m *StopOrder) GetTriggerPrice() *Amount {
+	if m != nil {
+		return m.TriggerPrice
+	}
+	return nil
 }
 
-func (m *Market) Reset()         { *m = Market{} }
-func (m *Market) String() string { return proto.CompactTextString(m) }
-func (*Market) ProtoMessage()    {}
-func (*Market) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{4}
-}
-func (m *Market) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Market) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Market.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *StopOrder) GetOffer() *coin.Coin {
+	if m != nil {
+		return m.Offer
 	}
+	return nil
 }
-func (m *Market) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Market.Merge(m, src)
+
+func (m *StopOrder) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
+	}
+	return nil
 }
-func (m *Market) XXX_Size() int {
-	return m.Size()
+
+func (m *StopOrder) GetOrderType() OrderType {
+	if m != nil {
+		return m.OrderType
+	}
+	return OrderType_Limit
 }
-func (m *Market) XXX_DiscardUnknown() {
-	xxx_messageInfo_Market.DiscardUnknown(m)
+
+func (m *StopOrder) GetTimeInForce() TimeInForce {
+	if m != nil {
+		return m.TimeInForce
+	}
+	return TimeInForce_GoodTillCancel
 }
 
-var xxx_messageInfo_Market proto.InternalMessageInfo
+func (m *StopOrder) GetSelfTradePrevention() SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
+	}
+	return SelfTradePrevention_CancelNewest
+}
 
-func (m *Market) GetMetadata() *weave.Metadata {
+func (m *StopOrder) GetDiscountedFee() bool {
 	if m != nil {
-		return m.Metadata
+		return m.DiscountedFee
 	}
-	return nil
+	return false
 }
 
-func (m *Market) GetID() []byte {
+func (m *StopOrder) GetCreatedAt() github_com_iov_one_weave.UnixTime {
 	if m != nil {
-		return m.ID
+		return m.CreatedAt
 	}
-	return nil
+	return 0
 }
 
-func (m *Market) GetOwner() github_com_iov_one_weave.Address {
+func (m *StopOrder) GetUpdatedAt() github_com_iov_one_weave.UnixTime {
 	if m != nil {
-		return m.Owner
+		return m.UpdatedAt
 	}
-	return nil
+	return 0
 }
 
-func (m *Market) GetName() string {
+func (m *StopOrder) GetOrderID() []byte {
 	if m != nil {
-		return m.Name
+		return m.OrderID
 	}
-	return ""
+	return nil
 }
 
-// CreateOrderMsg will offer to sell some currency on an orderbook
-// at a given price.
-type CreateOrderMsg struct {
-	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// Trader is the Address that will pay the offer, and get the matches.
-	// Defaults to x.MainSigner() if left blank
-	Trader github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
-	// OrderBookID must support Offer.Ticker as one of the two sides,
-	// Which side this order will be, is automatically inferred
-	OrderBookID []byte `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
-	// Offer is how much will be paid
-	Offer *coin.Coin `protobuf:"bytes,4,opt,name=offer,proto3" json:"offer,omitempty"`
-	// Price is how much is requested for each unit of the offer token
-	Price *Amount `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+// Trade is a settled partial/full order
+// We store these as independent entities to help with queries to map
+// the prices over time. They are also referenced by the Orders, so we can
+// see how much was fulfilled.
+//
+// Ask and Bid tickers are "inherited" from the orderbook
+type Trade struct {
+	Metadata    *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ID          []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OrderBookID []byte          `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	OrderID     []byte          `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Address of taker (this is an order that was instantly fulfilled)
+	Taker github_com_iov_one_weave.Address `protobuf:"bytes,5,opt,name=taker,proto3,casttype=github.com/iov-one/weave.Address" json:"taker,omitempty"`
+	// Address of maker (this is an order that was stored first before fulfillment)
+	Maker github_com_iov_one_weave.Address `protobuf:"bytes,6,opt,name=maker,proto3,casttype=github.com/iov-one/weave.Address" json:"maker,omitempty"`
+	// this is how much each side paid (they got the opposite one)
+	MakerPaid *coin.Coin `protobuf:"bytes,7,opt,name=maker_paid,json=makerPaid,proto3" json:"maker_paid,omitempty"`
+	TakerPaid *coin.Coin `protobuf:"bytes,8,opt,name=taker_paid,json=takerPaid,proto3" json:"taker_paid,omitempty"`
+	// executed_at defines execution time of an order
+	ExecutedAt github_com_iov_one_weave.UnixTime `protobuf:"varint,9,opt,name=executed_at,json=executedAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"executed_at,omitempty"`
+	// MakerFee is deducted from the taker_paid the maker receives, TakerFee
+	// from the maker_paid the taker receives. Both go to the fee collector
+	// of the market. Empty if no fee was charged
+	MakerFee *coin.Coin `protobuf:"bytes,10,opt,name=maker_fee,json=makerFee,proto3" json:"maker_fee,omitempty"`
+	TakerFee *coin.Coin `protobuf:"bytes,11,opt,name=taker_fee,json=takerFee,proto3" json:"taker_fee,omitempty"`
 }
 
-func (m *CreateOrderMsg) Reset()         { *m = CreateOrderMsg{} }
-func (m *CreateOrderMsg) String() string { return proto.CompactTextString(m) }
-func (*CreateOrderMsg) ProtoMessage()    {}
-func (*CreateOrderMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{5}
+func (m *Trade) Reset()         { *m = Trade{} }
+func (m *Trade) String() string { return proto.CompactTextString(m) }
+func (*Trade) ProtoMessage()    {}
+func (*Trade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{3}
 }
-func (m *CreateOrderMsg) XXX_Unmarshal(b []byte) error {
+func (m *Trade) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Trade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateOrderMsg.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Trade.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -623,129 +885,139 @@ func (m *CreateOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *CreateOrderMsg) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateOrderMsg.Merge(m, src)
+func (m *Trade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Trade.Merge(m, src)
 }
-func (m *CreateOrderMsg) XXX_Size() int {
+func (m *Trade) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateOrderMsg) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateOrderMsg.DiscardUnknown(m)
+func (m *Trade) XXX_DiscardUnknown() {
+	xxx_messageInfo_Trade.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateOrderMsg proto.InternalMessageInfo
+var xxx_messageInfo_Trade proto.InternalMessageInfo
 
-func (m *CreateOrderMsg) GetMetadata() *weave.Metadata {
+func (m *Trade) GetMetadata() *weave.Metadata {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateOrderMsg) GetTrader() github_com_iov_one_weave.Address {
+func (m *Trade) GetID() []byte {
 	if m != nil {
-		return m.Trader
+		return m.ID
 	}
 	return nil
 }
 
-func (m *CreateOrderMsg) GetOrderBookID() []byte {
+func (m *Trade) GetOrderBookID() []byte {
 	if m != nil {
 		return m.OrderBookID
 	}
 	return nil
 }
 
-func (m *CreateOrderMsg) GetOffer() *coin.Coin {
+func (m *Trade) GetOrderID() []byte {
 	if m != nil {
-		return m.Offer
+		return m.OrderID
 	}
 	return nil
 }
 
-func (m *CreateOrderMsg) GetPrice() *Amount {
+func (m *Trade) GetTaker() github_com_iov_one_weave.Address {
 	if m != nil {
-		return m.Price
+		return m.Taker
 	}
 	return nil
 }
 
-// CancelOrderMsg will remove a standing order.
-// It must be authorized by the trader who created the order.
-// All remaining funds return to that address.
-type CancelOrderMsg struct {
-	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	OrderID  []byte          `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+func (m *Trade) GetMaker() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Maker
+	}
+	return nil
 }
 
-func (m *CancelOrderMsg) Reset()         { *m = CancelOrderMsg{} }
-func (m *CancelOrderMsg) String() string { return proto.CompactTextString(m) }
-func (*CancelOrderMsg) ProtoMessage()    {}
-func (*CancelOrderMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{6}
-}
-func (m *CancelOrderMsg) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *CancelOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_CancelOrderMsg.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *Trade) GetMakerPaid() *coin.Coin {
+	if m != nil {
+		return m.MakerPaid
 	}
+	return nil
 }
-func (m *CancelOrderMsg) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CancelOrderMsg.Merge(m, src)
-}
-func (m *CancelOrderMsg) XXX_Size() int {
-	return m.Size()
-}
-func (m *CancelOrderMsg) XXX_DiscardUnknown() {
-	xxx_messageInfo_CancelOrderMsg.DiscardUnknown(m)
+
+func (m *Trade) GetTakerPaid() *coin.Coin {
+	if m != nil {
+		return m.TakerPaid
+	}
+	return nil
 }
 
-var xxx_messageInfo_CancelOrderMsg proto.InternalMessageInfo
+func (m *Trade) GetExecutedAt() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.ExecutedAt
+	}
+	return 0
+}
 
-func (m *CancelOrderMsg) GetMetadata() *weave.Metadata {
+func (m *Trade) GetMakerFee() *coin.Coin {
 	if m != nil {
-		return m.Metadata
+		return m.MakerFee
 	}
 	return nil
 }
 
-func (m *CancelOrderMsg) GetOrderID() []byte {
+func (m *Trade) GetTakerFee() *coin.Coin {
 	if m != nil {
-		return m.OrderID
+		return m.TakerFee
 	}
 	return nil
 }
 
-// CreateOrderBookMsg creates a new orderbook in an existing market.
-// It must be executed by the owner of the market.
-type CreateOrderBookMsg struct {
+// An Orderbook lives in a market and represents a ask/bid pair.
+// We only allow one orderbook for each pair. To avoid confusion,
+// we enforce ask_ticker < bid_ticker so their cannot be two orderbooks
+// for the same trading pair.
+//
+// Orders and Trades must reference an orderbook and use those tickers as denominations
+type OrderBook struct {
 	Metadata  *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	MarketID  []byte          `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	AskTicker string          `protobuf:"bytes,3,opt,name=ask_ticker,json=askTicker,proto3" json:"ask_ticker,omitempty"`
-	BidTicker string          `protobuf:"bytes,4,opt,name=bid_ticker,json=bidTicker,proto3" json:"bid_ticker,omitempty"`
+	ID        []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	MarketID  []byte          `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	AskTicker string          `protobuf:"bytes,4,opt,name=ask_ticker,json=askTicker,proto3" json:"ask_ticker,omitempty"`
+	BidTicker string          `protobuf:"bytes,5,opt,name=bid_ticker,json=bidTicker,proto3" json:"bid_ticker,omitempty"`
+	// repeated Order ask_orders = 5;
+	TotalAskCount int64 `protobuf:"varint,6,opt,name=total_ask_count,json=totalAskCount,proto3" json:"total_ask_count,omitempty"`
+	// repeated Order bid_orders = 7;
+	TotalBidCount int64 `protobuf:"varint,7,opt,name=total_bid_count,json=totalBidCount,proto3" json:"total_bid_count,omitempty"`
+	// TickSize is the price increment, every order price must be a multiple of it.
+	// Applies to the prices of both sides, zero or empty allows any price
+	TickSize *Amount `protobuf:"bytes,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// LotSize is the offer increment, every offer must be a multiple of it.
+	// Zero or empty allows any offer
+	LotSize *Amount `protobuf:"bytes,9,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	// MinOffer is the smallest offer that can be placed on either side.
+	// Zero or empty allows any offer
+	MinOffer *Amount `protobuf:"bytes,10,opt,name=min_offer,json=minOffer,proto3" json:"min_offer,omitempty"`
+	// StopTaskID references the scheduled ActivateStopsMsg while pending stop
+	// orders are triggered by the last price
+	StopTaskID []byte `protobuf:"bytes,11,opt,name=stop_task_id,json=stopTaskId,proto3" json:"stop_task_id,omitempty"`
+	// OrderSequence is the last Sequence given to an order of the orderbook
+	OrderSequence uint64 `protobuf:"varint,12,opt,name=order_sequence,json=orderSequence,proto3" json:"order_sequence,omitempty"`
 }
 
-func (m *CreateOrderBookMsg) Reset()         { *m = CreateOrderBookMsg{} }
-func (m *CreateOrderBookMsg) String() string { return proto.CompactTextString(m) }
-func (*CreateOrderBookMsg) ProtoMessage()    {}
-func (*CreateOrderBookMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_492308ae36fa08c1, []int{7}
+func (m *OrderBook) Reset()         { *m = OrderBook{} }
+func (m *OrderBook) String() string { return proto.CompactTextString(m) }
+func (*OrderBook) ProtoMessage()    {}
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{4}
 }
-func (m *CreateOrderBookMsg) XXX_Unmarshal(b []byte) error {
+func (m *OrderBook) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateOrderBookMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *OrderBook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateOrderBookMsg.Marshal(b, m, deterministic)
+		return xxx_messageInfo_OrderBook.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -755,855 +1027,10728 @@ func (m *CreateOrderBookMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *CreateOrderBookMsg) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateOrderBookMsg.Merge(m, src)
+func (m *OrderBook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBook.Merge(m, src)
 }
-func (m *CreateOrderBookMsg) XXX_Size() int {
+func (m *OrderBook) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateOrderBookMsg) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateOrderBookMsg.DiscardUnknown(m)
+func (m *OrderBook) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBook.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateOrderBookMsg proto.InternalMessageInfo
+var xxx_messageInfo_OrderBook proto.InternalMessageInfo
 
-func (m *CreateOrderBookMsg) GetMetadata() *weave.Metadata {
+func (m *OrderBook) GetMetadata() *weave.Metadata {
 	if m != nil {
 		return m.Metadata
 	}
 	return nil
 }
 
-func (m *CreateOrderBookMsg) GetMarketID() []byte {
+func (m *OrderBook) GetID() []byte {
+	if m != nil {
+		return m.ID
+	}
+	return nil
+}
+
+func (m *OrderBook) GetMarketID() []byte {
 	if m != nil {
 		return m.MarketID
 	}
 	return nil
 }
 
-func (m *CreateOrderBookMsg) GetAskTicker() string {
+func (m *OrderBook) GetAskTicker() string {
 	if m != nil {
 		return m.AskTicker
 	}
 	return ""
 }
 
-func (m *CreateOrderBookMsg) GetBidTicker() string {
+func (m *OrderBook) GetBidTicker() string {
 	if m != nil {
 		return m.BidTicker
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("orderbook.OrderState", OrderState_name, OrderState_value)
-	proto.RegisterEnum("orderbook.Side", Side_name, Side_value)
-	proto.RegisterType((*Amount)(nil), "orderbook.Amount")
-	proto.RegisterType((*Order)(nil), "orderbook.Order")
-	proto.RegisterType((*Trade)(nil), "orderbook.Trade")
-	proto.RegisterType((*OrderBook)(nil), "orderbook.OrderBook")
-	proto.RegisterType((*Market)(nil), "orderbook.Market")
-	proto.RegisterType((*CreateOrderMsg)(nil), "orderbook.CreateOrderMsg")
-	proto.RegisterType((*CancelOrderMsg)(nil), "orderbook.CancelOrderMsg")
-	proto.RegisterType((*CreateOrderBookMsg)(nil), "orderbook.CreateOrderBookMsg")
+func (m *OrderBook) GetTotalAskCount() int64 {
+	if m != nil {
+		return m.TotalAskCount
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("x/orderbook/codec.proto", fileDescriptor_492308ae36fa08c1) }
+func (m *OrderBook) GetTotalBidCount() int64 {
+	if m != nil {
+		return m.TotalBidCount
+	}
+	return 0
+}
 
-var fileDescriptor_492308ae36fa08c1 = []byte{
-	// 944 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x6f, 0xe2, 0x46,
-	0x14, 0x8f, 0x0d, 0x06, 0xfc, 0x20, 0x40, 0xa7, 0xbb, 0xad, 0x45, 0xb5, 0x40, 0x69, 0xba, 0x4d,
-	0xb6, 0x2a, 0xa8, 0x1b, 0xa9, 0x87, 0x55, 0x55, 0xc9, 0x60, 0x2a, 0x59, 0xdd, 0x84, 0x95, 0xa1,
-	0xbd, 0x5a, 0x83, 0x67, 0xc2, 0x8e, 0x00, 0x0f, 0xb2, 0x27, 0x7f, 0xbe, 0x42, 0x73, 0xea, 0xa9,
-	0x37, 0xbe, 0x40, 0xbf, 0x44, 0xaf, 0x3d, 0xee, 0xa9, 0xea, 0x09, 0x55, 0xe4, 0x5b, 0x6c, 0x2f,
-	0x95, 0xc7, 0xc4, 0xb0, 0x9b, 0x26, 0x0a, 0x55, 0x6e, 0xe3, 0xf7, 0xfb, 0xfd, 0x1e, 0xcf, 0xef,
-	0xf7, 0xe6, 0x19, 0xf8, 0xf8, 0xa2, 0xc5, 0x03, 0x42, 0x83, 0x21, 0xe7, 0xe3, 0x96, 0xc7, 0x09,
-	0xf5, 0x9a, 0xb3, 0x80, 0x0b, 0x8e, 0xf4, 0x24, 0x5c, 0xd9, 0x1b, 0x31, 0xf1, 0xfa, 0x74, 0xd8,
-	0xf4, 0xf8, 0xb4, 0xc5, 0xf8, 0xd9, 0x57, 0xdc, 0xa7, 0xad, 0x73, 0x8a, 0xcf, 0xe8, 0xa6, 0xa0,
-	0x72, 0x70, 0x07, 0x8b, 0xf9, 0xef, 0x50, 0x1f, 0x8d, 0xf8, 0x88, 0xcb, 0x63, 0x2b, 0x3a, 0xc5,
-	0xd1, 0xc6, 0x77, 0x90, 0x31, 0xa7, 0xfc, 0xd4, 0x17, 0xe8, 0x11, 0x68, 0xe7, 0xaf, 0xf9, 0x84,
-	0x1a, 0x4a, 0x5d, 0xd9, 0x4f, 0x39, 0xf1, 0x03, 0xaa, 0x02, 0x9c, 0x04, 0xd8, 0x13, 0x8c, 0xfb,
-	0x78, 0x62, 0xa8, 0x12, 0xda, 0x88, 0x34, 0xfe, 0x4c, 0x83, 0xd6, 0x8b, 0x8a, 0x46, 0x5f, 0x42,
-	0x6e, 0x4a, 0x05, 0x26, 0x58, 0x60, 0x99, 0x22, 0xff, 0xbc, 0xd4, 0x94, 0xa5, 0x34, 0x8f, 0x56,
-	0x61, 0x27, 0x21, 0xa0, 0x8f, 0x40, 0x65, 0x44, 0xa6, 0x2b, 0xb4, 0x33, 0xcb, 0x45, 0x4d, 0xb5,
-	0x2d, 0x47, 0x65, 0x04, 0x7d, 0x0b, 0x19, 0x11, 0x60, 0x42, 0x03, 0x23, 0x25, 0xb1, 0xbd, 0xb7,
-	0x8b, 0x5a, 0xfd, 0xb6, 0x77, 0x6c, 0x9a, 0x84, 0x04, 0x34, 0x0c, 0x9d, 0x95, 0x06, 0x1d, 0xc2,
-	0xae, 0x6c, 0xa0, 0x1b, 0x75, 0xd0, 0x65, 0xc4, 0x48, 0xcb, 0x24, 0xa5, 0xe5, 0xa2, 0x96, 0x97,
-	0x45, 0xb6, 0x39, 0x1f, 0xdb, 0x96, 0x93, 0xe7, 0xc9, 0x03, 0x41, 0x9f, 0x41, 0x3a, 0x64, 0x84,
-	0x1a, 0x5a, 0x5d, 0xd9, 0x2f, 0x3e, 0x2f, 0x35, 0x13, 0x0b, 0x9a, 0x7d, 0x46, 0xa8, 0x23, 0x41,
-	0xf4, 0x0d, 0xc4, 0x1a, 0x37, 0x14, 0x58, 0x50, 0x23, 0x23, 0xb9, 0x8f, 0x37, 0xb8, 0x32, 0x7d,
-	0x3f, 0x02, 0x1d, 0xe0, 0xc9, 0x19, 0x7d, 0x0d, 0x45, 0x1e, 0xb0, 0x11, 0xf3, 0xf1, 0xc4, 0xe5,
-	0x27, 0x27, 0x34, 0x30, 0xb2, 0xb2, 0x35, 0xd0, 0x8c, 0xfc, 0x69, 0x76, 0x38, 0xf3, 0x9d, 0xdd,
-	0x6b, 0x46, 0x2f, 0x22, 0xa0, 0x43, 0x28, 0x05, 0x74, 0x8a, 0x99, 0xcf, 0xfc, 0xd1, 0x4a, 0x93,
-	0xbb, 0xa1, 0x29, 0x26, 0x94, 0x58, 0xf4, 0x05, 0x68, 0xb3, 0x80, 0x79, 0xd4, 0xd0, 0x25, 0xf5,
-	0x83, 0x8d, 0xca, 0x62, 0x7b, 0x9d, 0x18, 0x47, 0x9f, 0x80, 0x2e, 0x9b, 0xe5, 0x32, 0x12, 0x1a,
-	0x50, 0x4f, 0xed, 0x17, 0x9c, 0x9c, 0x0c, 0xd8, 0x24, 0x44, 0x16, 0x80, 0x17, 0x50, 0x2c, 0x28,
-	0x71, 0xb1, 0x30, 0xf2, 0x91, 0xd9, 0xed, 0xcf, 0xdf, 0x2e, 0x6a, 0x9f, 0xde, 0xea, 0xc0, 0x8f,
-	0x3e, 0xbb, 0x18, 0xb0, 0x29, 0x75, 0xf4, 0x95, 0xd0, 0x14, 0x51, 0x96, 0xd3, 0x19, 0xb9, 0xce,
-	0x52, 0xd8, 0x2a, 0xcb, 0x4a, 0x68, 0x8a, 0xc6, 0xef, 0x29, 0xd0, 0x06, 0x51, 0x61, 0x0f, 0x33,
-	0x58, 0x37, 0x46, 0x23, 0x75, 0x8f, 0xd1, 0x78, 0x0a, 0xb9, 0x58, 0x94, 0x8c, 0x52, 0x7e, 0xb9,
-	0xa8, 0x65, 0x25, 0xdf, 0xb6, 0x9c, 0xac, 0x04, 0x6d, 0x82, 0x5e, 0x80, 0x26, 0xf0, 0x98, 0x06,
-	0x72, 0x86, 0xee, 0x3b, 0xb4, 0xb1, 0x24, 0xd2, 0x4e, 0xa5, 0x36, 0xb3, 0x8d, 0x56, 0x4a, 0xd0,
-	0x01, 0x80, 0x3c, 0xb8, 0x33, 0xcc, 0xc8, 0x7f, 0x4c, 0x96, 0x2e, 0xd1, 0x57, 0x98, 0x91, 0x88,
-	0x2a, 0xd6, 0xd4, 0x9b, 0x03, 0xa5, 0x8b, 0x84, 0xfa, 0x3d, 0xe4, 0xe9, 0x05, 0xf5, 0x4e, 0x57,
-	0x06, 0xea, 0xdb, 0x18, 0x08, 0xd7, 0x4a, 0x53, 0x34, 0x7e, 0x56, 0x41, 0x4f, 0x5a, 0xfb, 0x30,
-	0x2e, 0x1e, 0x80, 0x3e, 0xc5, 0xc1, 0x98, 0x8a, 0xb5, 0x83, 0x85, 0xe5, 0xa2, 0x96, 0x3b, 0x92,
-	0x41, 0xdb, 0x72, 0x72, 0x31, 0x6c, 0x13, 0xf4, 0x04, 0x00, 0x87, 0x63, 0x57, 0x30, 0x2f, 0x6a,
-	0x6e, 0xe4, 0x9e, 0xee, 0xe8, 0x38, 0x1c, 0x0f, 0x64, 0x20, 0x82, 0x87, 0x8c, 0x5c, 0xc3, 0x5a,
-	0x0c, 0x0f, 0x19, 0x59, 0xc1, 0x4f, 0xa1, 0x24, 0xb8, 0xc0, 0x13, 0x37, 0xca, 0xe1, 0x45, 0x17,
-	0x48, 0xfa, 0x93, 0x72, 0x76, 0x65, 0xd8, 0x0c, 0xc7, 0x1d, 0xb9, 0x34, 0x13, 0x5e, 0x94, 0x2c,
-	0xe6, 0x65, 0x37, 0x78, 0x6d, 0x46, 0x24, 0xaf, 0x31, 0x57, 0x20, 0x13, 0x17, 0xf9, 0x30, 0x8d,
-	0x78, 0x01, 0x1a, 0x3f, 0xf7, 0xb7, 0x5c, 0x93, 0xb1, 0x04, 0x21, 0x48, 0xfb, 0x78, 0x4a, 0x57,
-	0x3d, 0x91, 0xe7, 0xc6, 0x3f, 0x0a, 0x14, 0x3b, 0xf2, 0x06, 0x4b, 0xc7, 0x8e, 0xc2, 0xd1, 0x76,
-	0x75, 0xae, 0xf7, 0xb6, 0xfa, 0x10, 0x7b, 0xfb, 0x3e, 0x97, 0xb3, 0x0e, 0x5a, 0xbc, 0x1d, 0xd3,
-	0x37, 0x86, 0x39, 0x06, 0xd6, 0x4b, 0x51, 0xbb, 0x7b, 0x29, 0x36, 0x28, 0x14, 0x3b, 0xd8, 0xf7,
-	0xe8, 0xe4, 0xff, 0xbd, 0xfc, 0xe6, 0x9a, 0x50, 0x6f, 0x5f, 0x13, 0x8d, 0xdf, 0x14, 0x40, 0x1b,
-	0x4d, 0x8e, 0xde, 0x63, 0xeb, 0xdf, 0x7a, 0xe7, 0x06, 0xa8, 0x5b, 0xdc, 0x80, 0xd4, 0xdd, 0x37,
-	0x20, 0xfd, 0xde, 0x0d, 0x78, 0xf6, 0xab, 0x02, 0xb0, 0xfe, 0xa8, 0xa1, 0x3d, 0xf8, 0xb0, 0xe7,
-	0x58, 0x5d, 0xc7, 0xed, 0x0f, 0xcc, 0x41, 0xd7, 0xb5, 0x8f, 0x7f, 0x32, 0x5f, 0xda, 0x56, 0x79,
-	0xa7, 0x92, 0xbf, 0x9c, 0xd7, 0xb3, 0xb6, 0x7f, 0x86, 0x27, 0x8c, 0xa0, 0x2a, 0x94, 0x37, 0x59,
-	0xbd, 0x57, 0xdd, 0xe3, 0xb2, 0x52, 0xc9, 0x5d, 0xce, 0xeb, 0xe9, 0xde, 0x8c, 0xfa, 0xef, 0xe3,
-	0x56, 0xef, 0xb8, 0x5b, 0x56, 0x63, 0xdc, 0xe2, 0x3e, 0x45, 0x0d, 0x40, 0x9b, 0x78, 0xc7, 0x3c,
-	0xee, 0x74, 0x5f, 0x96, 0x53, 0x15, 0xb8, 0x9c, 0xd7, 0x33, 0xb1, 0x45, 0xcf, 0xfa, 0x90, 0x8e,
-	0x3e, 0xcc, 0xe8, 0x09, 0x14, 0xfa, 0xb6, 0x75, 0x6b, 0x29, 0x8f, 0x21, 0x27, 0x61, 0xb3, 0xff,
-	0x43, 0x59, 0xa9, 0x64, 0x2f, 0xe7, 0xf5, 0x94, 0x19, 0x8e, 0x93, 0x70, 0xdb, 0xb6, 0xca, 0x6a,
-	0x1c, 0x6e, 0x33, 0xd2, 0x36, 0xfe, 0x58, 0x56, 0x95, 0x37, 0xcb, 0xaa, 0xf2, 0xf7, 0xb2, 0xaa,
-	0xfc, 0x72, 0x55, 0xdd, 0x79, 0x73, 0x55, 0xdd, 0xf9, 0xeb, 0xaa, 0xba, 0x33, 0xcc, 0xc8, 0xff,
-	0x49, 0x87, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x77, 0xb3, 0x6c, 0x8b, 0xb4, 0x09, 0x00, 0x00,
+func (m *OrderBook) GetTickSize() *Amount {
+	if m != nil {
+		return m.TickSize
+	}
+	return nil
 }
 
-func (m *Amount) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *OrderBook) GetLotSize() *Amount {
+	if m != nil {
+		return m.LotSize
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Amount) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Whole != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Whole))
+func (m *OrderBook) GetMinOffer() *Amount {
+	if m != nil {
+		return m.MinOffer
 	}
-	if m.Fractional != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Fractional))
+	return nil
+}
+
+func (m *OrderBook) GetStopTaskID() []byte {
+	if m != nil {
+		return m.StopTaskID
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Order) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *OrderBook) GetOrderSequence() uint64 {
+	if m != nil {
+		return m.OrderSequence
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Order) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n1, err := m.Metadata.MarshalTo(dAtA[i:])
+// A market holds many Orderbooks and is just a grouping for now.
+// Probably we only want one market on a chain, but we could add additional
+// rules to each market and then allow multiple.
+//
+// Markets are created with CreateMarketMsg, their name must be unique
+type Market struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ID       []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Owner is allowed to create new orderbooks in this Market
+	Owner github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=owner,proto3,casttype=github.com/iov-one/weave.Address" json:"owner,omitempty"`
+	// Market name
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// MakerFee and TakerFee are the fractions of what the maker and the taker
+	// receive in every trade that is charged as a fee. Empty charges no fee
+	MakerFee *Amount `protobuf:"bytes,5,opt,name=maker_fee,json=makerFee,proto3" json:"maker_fee,omitempty"`
+	TakerFee *Amount `protobuf:"bytes,6,opt,name=taker_fee,json=takerFee,proto3" json:"taker_fee,omitempty"`
+	// FeeCollector receives all fees, empty sends them to the owner
+	FeeCollector github_com_iov_one_weave.Address `protobuf:"bytes,7,opt,name=fee_collector,json=feeCollector,proto3,casttype=github.com/iov-one/weave.Address" json:"fee_collector,omitempty"`
+}
+
+func (m *Market) Reset()         { *m = Market{} }
+func (m *Market) String() string { return proto.CompactTextString(m) }
+func (*Market) ProtoMessage()    {}
+func (*Market) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{5}
+}
+func (m *Market) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Market) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Market.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n1
+		return b[:n], nil
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+}
+func (m *Market) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Market.Merge(m, src)
+}
+func (m *Market) XXX_Size() int {
+	return m.Size()
+}
+func (m *Market) XXX_DiscardUnknown() {
+	xxx_messageInfo_Market.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Market proto.InternalMessageInfo
+
+func (m *Market) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if len(m.Trader) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
-		i += copy(dAtA[i:], m.Trader)
+	return nil
+}
+
+func (m *Market) GetID() []byte {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.OrderBookID) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
-		i += copy(dAtA[i:], m.OrderBookID)
+	return nil
+}
+
+func (m *Market) GetOwner() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Owner
 	}
-	if m.Side != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Side))
+	return nil
+}
+
+func (m *Market) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	if m.OrderState != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.OrderState))
+	return ""
+}
+
+func (m *Market) GetMakerFee() *Amount {
+	if m != nil {
+		return m.MakerFee
 	}
-	if m.OriginalOffer != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.OriginalOffer.Size()))
-		n2, err := m.OriginalOffer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
+	return nil
+}
+
+func (m *Market) GetTakerFee() *Amount {
+	if m != nil {
+		return m.TakerFee
 	}
-	if m.RemainingOffer != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.RemainingOffer.Size()))
-		n3, err := m.RemainingOffer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+	return nil
+}
+
+func (m *Market) GetFeeCollector() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.FeeCollector
 	}
-	if m.Price != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
-		n4, err := m.Price.MarshalTo(dAtA[i:])
+	return nil
+}
+
+// Candle aggregates all trades of an orderbook during one interval (OHLCV).
+// Prices are in bid ticker per unit of the ask ticker, the base currency.
+//
+// The ID is (order_book_id, interval, start), so all candles of one interval
+// of an orderbook can be iterated in time order.
+type Candle struct {
+	Metadata    *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ID          []byte          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	OrderBookID []byte          `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Interval    CandleInterval  `protobuf:"varint,4,opt,name=interval,proto3,enum=orderbook.CandleInterval" json:"interval,omitempty"`
+	// Start of the interval, a multiple of its length
+	Start github_com_iov_one_weave.UnixTime `protobuf:"varint,5,opt,name=start,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"start,omitempty"`
+	// Prices of the first, highest, lowest and last trade
+	Open  *Amount `protobuf:"bytes,6,opt,name=open,proto3" json:"open,omitempty"`
+	High  *Amount `protobuf:"bytes,7,opt,name=high,proto3" json:"high,omitempty"`
+	Low   *Amount `protobuf:"bytes,8,opt,name=low,proto3" json:"low,omitempty"`
+	Close *Amount `protobuf:"bytes,9,opt,name=close,proto3" json:"close,omitempty"`
+	// BaseVolume is the traded amount of the ask ticker
+	BaseVolume *coin.Coin `protobuf:"bytes,10,opt,name=base_volume,json=baseVolume,proto3" json:"base_volume,omitempty"`
+	// QuoteVolume is the traded amount of the bid ticker
+	QuoteVolume *coin.Coin `protobuf:"bytes,11,opt,name=quote_volume,json=quoteVolume,proto3" json:"quote_volume,omitempty"`
+	TradeCount  int64      `protobuf:"varint,12,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+}
+
+func (m *Candle) Reset()         { *m = Candle{} }
+func (m *Candle) String() string { return proto.CompactTextString(m) }
+func (*Candle) ProtoMessage()    {}
+func (*Candle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{6}
+}
+func (m *Candle) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Candle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Candle.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
-		}
-		i += n4
-	}
-	if len(m.TradeIds) > 0 {
-		for _, b := range m.TradeIds {
-			dAtA[i] = 0x52
-			i++
-			i = encodeVarintCodec(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.CreatedAt != 0 {
-		dAtA[i] = 0x58
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.CreatedAt))
-	}
-	if m.UpdatedAt != 0 {
-		dAtA[i] = 0x60
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.UpdatedAt))
-	}
-	return i, nil
+}
+func (m *Candle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Candle.Merge(m, src)
+}
+func (m *Candle) XXX_Size() int {
+	return m.Size()
+}
+func (m *Candle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Candle.DiscardUnknown(m)
 }
 
-func (m *Trade) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Candle proto.InternalMessageInfo
+
+func (m *Candle) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Trade) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n5, err := m.Metadata.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+func (m *Candle) GetID() []byte {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+	return nil
+}
+
+func (m *Candle) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
 	}
-	if len(m.OrderBookID) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
-		i += copy(dAtA[i:], m.OrderBookID)
+	return nil
+}
+
+func (m *Candle) GetInterval() CandleInterval {
+	if m != nil {
+		return m.Interval
 	}
-	if len(m.OrderID) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
-		i += copy(dAtA[i:], m.OrderID)
+	return CandleInterval_Invalid
+}
+
+func (m *Candle) GetStart() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.Start
 	}
-	if len(m.Taker) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Taker)))
-		i += copy(dAtA[i:], m.Taker)
+	return 0
+}
+
+func (m *Candle) GetOpen() *Amount {
+	if m != nil {
+		return m.Open
 	}
-	if len(m.Maker) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Maker)))
-		i += copy(dAtA[i:], m.Maker)
+	return nil
+}
+
+func (m *Candle) GetHigh() *Amount {
+	if m != nil {
+		return m.High
 	}
-	if m.MakerPaid != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.MakerPaid.Size()))
-		n6, err := m.MakerPaid.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+	return nil
+}
+
+func (m *Candle) GetLow() *Amount {
+	if m != nil {
+		return m.Low
 	}
-	if m.TakerPaid != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.TakerPaid.Size()))
-		n7, err := m.TakerPaid.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+	return nil
+}
+
+func (m *Candle) GetClose() *Amount {
+	if m != nil {
+		return m.Close
 	}
-	if m.ExecutedAt != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.ExecutedAt))
+	return nil
+}
+
+func (m *Candle) GetBaseVolume() *coin.Coin {
+	if m != nil {
+		return m.BaseVolume
 	}
-	return i, nil
+	return nil
 }
 
-func (m *OrderBook) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Candle) GetQuoteVolume() *coin.Coin {
+	if m != nil {
+		return m.QuoteVolume
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *OrderBook) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n8, err := m.Metadata.MarshalTo(dAtA[i:])
+func (m *Candle) GetTradeCount() int64 {
+	if m != nil {
+		return m.TradeCount
+	}
+	return 0
+}
+
+// Ticker holds the market data of an orderbook, stored under the orderbook ID.
+// Prices are in bid ticker per unit of the ask ticker, as for candles.
+type Ticker struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// ID is the orderbook ID
+	ID []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// LastPrice is the price of the last trade, empty before the first trade
+	LastPrice   *Amount                           `protobuf:"bytes,3,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastTradeAt github_com_iov_one_weave.UnixTime `protobuf:"varint,4,opt,name=last_trade_at,json=lastTradeAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"last_trade_at,omitempty"`
+	// BestBid and BestAsk are the prices of the best open orders of each side,
+	// empty for a side without open orders
+	BestBid *Amount `protobuf:"bytes,5,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
+	BestAsk *Amount `protobuf:"bytes,6,opt,name=best_ask,json=bestAsk,proto3" json:"best_ask,omitempty"`
+	// WindowStart is where the 24h statistics start, they include all trades
+	// executed after it up to the last trade
+	WindowStart github_com_iov_one_weave.UnixTime `protobuf:"varint,7,opt,name=window_start,json=windowStart,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"window_start,omitempty"`
+	// DayHigh and DayLow are the highest and lowest prices of the 24h window
+	DayHigh *Amount `protobuf:"bytes,8,opt,name=day_high,json=dayHigh,proto3" json:"day_high,omitempty"`
+	DayLow  *Amount `protobuf:"bytes,9,opt,name=day_low,json=dayLow,proto3" json:"day_low,omitempty"`
+	// DayBaseVolume is the traded amount of the ask ticker in the 24h window
+	DayBaseVolume *coin.Coin `protobuf:"bytes,10,opt,name=day_base_volume,json=dayBaseVolume,proto3" json:"day_base_volume,omitempty"`
+	// DayQuoteVolume is the traded amount of the bid ticker in the 24h window
+	DayQuoteVolume *coin.Coin `protobuf:"bytes,11,opt,name=day_quote_volume,json=dayQuoteVolume,proto3" json:"day_quote_volume,omitempty"`
+	DayTradeCount  int64      `protobuf:"varint,12,opt,name=day_trade_count,json=dayTradeCount,proto3" json:"day_trade_count,omitempty"`
+}
+
+func (m *Ticker) Reset()         { *m = Ticker{} }
+func (m *Ticker) String() string { return proto.CompactTextString(m) }
+func (*Ticker) ProtoMessage()    {}
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{7}
+}
+func (m *Ticker) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Ticker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Ticker.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n8
+		return b[:n], nil
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+}
+func (m *Ticker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ticker.Merge(m, src)
+}
+func (m *Ticker) XXX_Size() int {
+	return m.Size()
+}
+func (m *Ticker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ticker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ticker proto.InternalMessageInfo
+
+func (m *Ticker) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if len(m.MarketID) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.MarketID)))
-		i += copy(dAtA[i:], m.MarketID)
+	return nil
+}
+
+func (m *Ticker) GetID() []byte {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.AskTicker) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.AskTicker)))
-		i += copy(dAtA[i:], m.AskTicker)
+	return nil
+}
+
+func (m *Ticker) GetLastPrice() *Amount {
+	if m != nil {
+		return m.LastPrice
 	}
-	if len(m.BidTicker) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.BidTicker)))
-		i += copy(dAtA[i:], m.BidTicker)
+	return nil
+}
+
+func (m *Ticker) GetLastTradeAt() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.LastTradeAt
 	}
-	if m.TotalAskCount != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.TotalAskCount))
+	return 0
+}
+
+func (m *Ticker) GetBestBid() *Amount {
+	if m != nil {
+		return m.BestBid
 	}
-	if m.TotalBidCount != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.TotalBidCount))
+	return nil
+}
+
+func (m *Ticker) GetBestAsk() *Amount {
+	if m != nil {
+		return m.BestAsk
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Market) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Ticker) GetWindowStart() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.WindowStart
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Market) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n9, err := m.Metadata.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+func (m *Ticker) GetDayHigh() *Amount {
+	if m != nil {
+		return m.DayHigh
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+	return nil
+}
+
+func (m *Ticker) GetDayLow() *Amount {
+	if m != nil {
+		return m.DayLow
 	}
-	if len(m.Owner) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Owner)))
-		i += copy(dAtA[i:], m.Owner)
+	return nil
+}
+
+func (m *Ticker) GetDayBaseVolume() *coin.Coin {
+	if m != nil {
+		return m.DayBaseVolume
 	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	return nil
+}
+
+func (m *Ticker) GetDayQuoteVolume() *coin.Coin {
+	if m != nil {
+		return m.DayQuoteVolume
 	}
-	return i, nil
+	return nil
 }
 
-func (m *CreateOrderMsg) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Ticker) GetDayTradeCount() int64 {
+	if m != nil {
+		return m.DayTradeCount
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CreateOrderMsg) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n10, err := m.Metadata.MarshalTo(dAtA[i:])
+// Configuration of the orderbook extension, stored with gconf. Genesis must
+// set its owner.
+type Configuration struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Owner is present to implement gconf.OwnedConfig interface,
+	// it is allowed to update the configuration
+	Owner github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=owner,proto3,casttype=github.com/iov-one/weave.Address" json:"owner,omitempty"`
+	// FeeTicker is the token that trading fees can be paid in at a discount
+	FeeTicker string `protobuf:"bytes,3,opt,name=fee_ticker,json=feeTicker,proto3" json:"fee_ticker,omitempty"`
+	// FeeDiscount is the fraction of the fee waived when it is paid in the fee
+	// ticker, for example 0.25 for a 25% discount
+	FeeDiscount *Amount `protobuf:"bytes,4,opt,name=fee_discount,json=feeDiscount,proto3" json:"fee_discount,omitempty"`
+	// FeePrices convert fees into the fee ticker. Fees in a ticker without a
+	// price cannot be paid in the fee ticker
+	FeePrices []*FeePrice `protobuf:"bytes,5,rep,name=fee_prices,json=feePrices,proto3" json:"fee_prices,omitempty"`
+}
+
+func (m *Configuration) Reset()         { *m = Configuration{} }
+func (m *Configuration) String() string { return proto.CompactTextString(m) }
+func (*Configuration) ProtoMessage()    {}
+func (*Configuration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{8}
+}
+func (m *Configuration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Configuration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Configuration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n10
+		return b[:n], nil
 	}
-	if len(m.Trader) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
-		i += copy(dAtA[i:], m.Trader)
+}
+func (m *Configuration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Configuration.Merge(m, src)
+}
+func (m *Configuration) XXX_Size() int {
+	return m.Size()
+}
+func (m *Configuration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Configuration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Configuration proto.InternalMessageInfo
+
+func (m *Configuration) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if len(m.OrderBookID) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
-		i += copy(dAtA[i:], m.OrderBookID)
+	return nil
+}
+
+func (m *Configuration) GetOwner() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Owner
 	}
-	if m.Offer != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Offer.Size()))
-		n11, err := m.Offer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
+	return nil
+}
+
+func (m *Configuration) GetFeeTicker() string {
+	if m != nil {
+		return m.FeeTicker
 	}
-	if m.Price != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
-		n12, err := m.Price.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+	return ""
+}
+
+func (m *Configuration) GetFeeDiscount() *Amount {
+	if m != nil {
+		return m.FeeDiscount
 	}
-	return i, nil
+	return nil
 }
 
-func (m *CancelOrderMsg) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Configuration) GetFeePrices() []*FeePrice {
+	if m != nil {
+		return m.FeePrices
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CancelOrderMsg) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n13, err := m.Metadata.MarshalTo(dAtA[i:])
+// FeePrice is the conversion price of a ticker into the fee ticker
+type FeePrice struct {
+	Ticker string `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	// Price is the amount of the fee ticker paid for one unit of the ticker
+	Price *Amount `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (m *FeePrice) Reset()         { *m = FeePrice{} }
+func (m *FeePrice) String() string { return proto.CompactTextString(m) }
+func (*FeePrice) ProtoMessage()    {}
+func (*FeePrice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{9}
+}
+func (m *FeePrice) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeePrice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeePrice.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n13
+		return b[:n], nil
 	}
-	if len(m.OrderID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
-		i += copy(dAtA[i:], m.OrderID)
+}
+func (m *FeePrice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeePrice.Merge(m, src)
+}
+func (m *FeePrice) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeePrice) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeePrice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeePrice proto.InternalMessageInfo
+
+func (m *FeePrice) GetTicker() string {
+	if m != nil {
+		return m.Ticker
 	}
-	return i, nil
+	return ""
 }
 
-func (m *CreateOrderBookMsg) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *FeePrice) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CreateOrderBookMsg) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
-		n14, err := m.Metadata.MarshalTo(dAtA[i:])
+// CreateOrderMsg will offer to sell some currency on an orderbook
+// at a given price.
+type CreateOrderMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Trader is the Address that will pay the offer, and get the matches.
+	// Defaults to x.MainSigner() if left blank
+	Trader github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	// OrderBookID must support Offer.Ticker as one of the two sides,
+	// Which side this order will be, is automatically inferred
+	OrderBookID []byte `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	// Offer is how much will be paid
+	Offer *coin.Coin `protobuf:"bytes,4,opt,name=offer,proto3" json:"offer,omitempty"`
+	// Price is how much is requested for each unit of the offer token.
+	// For market orders this is the worst price accepted
+	Price *Amount `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	// OrderType defaults to a limit order
+	OrderType OrderType `protobuf:"varint,6,opt,name=order_type,json=orderType,proto3,enum=orderbook.OrderType" json:"order_type,omitempty"`
+	// TimeInForce defaults to good till cancel.
+	// Market orders must be immediate or cancel, or fill or kill
+	TimeInForce TimeInForce `protobuf:"varint,7,opt,name=time_in_force,json=timeInForce,proto3,enum=orderbook.TimeInForce" json:"time_in_force,omitempty"`
+	// ExpiresAt optionally cancels whatever rests on the book at the given time.
+	// Only good till cancel limit orders can expire
+	ExpiresAt github_com_iov_one_weave.UnixTime `protobuf:"varint,8,opt,name=expires_at,json=expiresAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"expires_at,omitempty"`
+	// DiscountedFee opts in to pay the trading fees in the fee ticker of the
+	// configuration (IDEX), at a discount. The fees are paid from the trader
+	// balance, without enough of it they are charged as usual
+	DiscountedFee bool `protobuf:"varint,9,opt,name=discounted_fee,json=discountedFee,proto3" json:"discounted_fee,omitempty"`
+	// SelfTradePrevention determines what happens if the order would match a
+	// resting order of the same trader, it defaults to cancel newest
+	SelfTradePrevention SelfTradePrevention `protobuf:"varint,10,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=orderbook.SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	// PostOnly optionally rejects or reprices the order if it would match a
+	// resting order when it is placed
+	PostOnly PostOnly `protobuf:"varint,11,opt,name=post_only,json=postOnly,proto3,enum=orderbook.PostOnly" json:"post_only,omitempty"`
+}
+
+func (m *CreateOrderMsg) Reset()         { *m = CreateOrderMsg{} }
+func (m *CreateOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*CreateOrderMsg) ProtoMessage()    {}
+func (*CreateOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{10}
+}
+func (m *CreateOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n14
+		return b[:n], nil
 	}
-	if len(m.MarketID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.MarketID)))
-		i += copy(dAtA[i:], m.MarketID)
+}
+func (m *CreateOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrderMsg.Merge(m, src)
+}
+func (m *CreateOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrderMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrderMsg proto.InternalMessageInfo
+
+func (m *CreateOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if len(m.AskTicker) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.AskTicker)))
-		i += copy(dAtA[i:], m.AskTicker)
+	return nil
+}
+
+func (m *CreateOrderMsg) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
 	}
-	if len(m.BidTicker) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintCodec(dAtA, i, uint64(len(m.BidTicker)))
-		i += copy(dAtA[i:], m.BidTicker)
+	return nil
+}
+
+func (m *CreateOrderMsg) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
 	}
-	return i, nil
+	return nil
 }
 
-func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CreateOrderMsg) GetOffer() *coin.Coin {
+	if m != nil {
+		return m.Offer
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return nil
 }
-func (m *Amount) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *CreateOrderMsg) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
 	}
-	var l int
-	_ = l
-	if m.Whole != 0 {
-		n += 1 + sovCodec(uint64(m.Whole))
+	return nil
+}
+
+func (m *CreateOrderMsg) GetOrderType() OrderType {
+	if m != nil {
+		return m.OrderType
 	}
-	if m.Fractional != 0 {
-		n += 1 + sovCodec(uint64(m.Fractional))
+	return OrderType_Limit
+}
+
+func (m *CreateOrderMsg) GetTimeInForce() TimeInForce {
+	if m != nil {
+		return m.TimeInForce
 	}
-	return n
+	return TimeInForce_GoodTillCancel
 }
 
-func (m *Order) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateOrderMsg) GetExpiresAt() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.ExpiresAt
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
-	}
-	l = len(m.Trader)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
-	}
-	l = len(m.OrderBookID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return 0
+}
+
+func (m *CreateOrderMsg) GetDiscountedFee() bool {
+	if m != nil {
+		return m.DiscountedFee
 	}
-	if m.Side != 0 {
-		n += 1 + sovCodec(uint64(m.Side))
+	return false
+}
+
+func (m *CreateOrderMsg) GetSelfTradePrevention() SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
 	}
-	if m.OrderState != 0 {
-		n += 1 + sovCodec(uint64(m.OrderState))
+	return SelfTradePrevention_CancelNewest
+}
+
+func (m *CreateOrderMsg) GetPostOnly() PostOnly {
+	if m != nil {
+		return m.PostOnly
 	}
-	if m.OriginalOffer != nil {
-		l = m.OriginalOffer.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return PostOnly_Disabled
+}
+
+// CancelOrderMsg will remove a standing order.
+// It must be authorized by the trader who created the order.
+// All remaining funds return to that address.
+type CancelOrderMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderID  []byte          `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (m *CancelOrderMsg) Reset()         { *m = CancelOrderMsg{} }
+func (m *CancelOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderMsg) ProtoMessage()    {}
+func (*CancelOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{11}
+}
+func (m *CancelOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.RemainingOffer != nil {
-		l = m.RemainingOffer.Size()
-		n += 1 + l + sovCodec(uint64(l))
+}
+func (m *CancelOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderMsg.Merge(m, src)
+}
+func (m *CancelOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOrderMsg proto.InternalMessageInfo
+
+func (m *CancelOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if m.Price != nil {
-		l = m.Price.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CancelOrderMsg) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
 	}
-	if len(m.TradeIds) > 0 {
-		for _, b := range m.TradeIds {
-			l = len(b)
-			n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+// ReplaceOrderMsg changes the price and/or the remaining offer of an open
+// order in one step, so it never leaves the book. It must be authorized by
+// the trader who created the order. The escrow is adjusted by the difference
+// of the remaining offer.
+//
+// An order that is only reduced keeps its time priority. A new price or a
+// larger offer places it behind all orders at its price, and a new price
+// matches it again like a new order.
+type ReplaceOrderMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderID  []byte          `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// New price of the order, empty keeps the current price
+	Price *Amount `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	// New remaining offer of the order, in the same ticker. Empty keeps the
+	// current one
+	RemainingOffer *coin.Coin `protobuf:"bytes,4,opt,name=remaining_offer,json=remainingOffer,proto3" json:"remaining_offer,omitempty"`
+}
+
+func (m *ReplaceOrderMsg) Reset()         { *m = ReplaceOrderMsg{} }
+func (m *ReplaceOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*ReplaceOrderMsg) ProtoMessage()    {}
+func (*ReplaceOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{12}
+}
+func (m *ReplaceOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplaceOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplaceOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovCodec(uint64(m.CreatedAt))
+}
+func (m *ReplaceOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplaceOrderMsg.Merge(m, src)
+}
+func (m *ReplaceOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplaceOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplaceOrderMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplaceOrderMsg proto.InternalMessageInfo
+
+func (m *ReplaceOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	if m.UpdatedAt != 0 {
-		n += 1 + sovCodec(uint64(m.UpdatedAt))
+	return nil
+}
+
+func (m *ReplaceOrderMsg) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
 	}
-	return n
+	return nil
 }
 
-func (m *Trade) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReplaceOrderMsg) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *ReplaceOrderMsg) GetRemainingOffer() *coin.Coin {
+	if m != nil {
+		return m.RemainingOffer
 	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+// CreateOrdersMsg places several orders of one trader in a single
+// transaction. The orders are placed in the given order, and either all of
+// them are placed or none is.
+type CreateOrdersMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Trader is the Address that pays the offers of all orders
+	Trader github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	Orders []*BatchOrder                    `protobuf:"bytes,3,rep,name=orders,proto3" json:"orders,omitempty"`
+}
+
+func (m *CreateOrdersMsg) Reset()         { *m = CreateOrdersMsg{} }
+func (m *CreateOrdersMsg) String() string { return proto.CompactTextString(m) }
+func (*CreateOrdersMsg) ProtoMessage()    {}
+func (*CreateOrdersMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{13}
+}
+func (m *CreateOrdersMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateOrdersMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateOrdersMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.OrderBookID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+}
+func (m *CreateOrdersMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrdersMsg.Merge(m, src)
+}
+func (m *CreateOrdersMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateOrdersMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrdersMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrdersMsg proto.InternalMessageInfo
+
+func (m *CreateOrdersMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	l = len(m.OrderID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateOrdersMsg) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
 	}
-	l = len(m.Taker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateOrdersMsg) GetOrders() []*BatchOrder {
+	if m != nil {
+		return m.Orders
 	}
-	l = len(m.Maker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+// BatchOrder is one order of a CreateOrdersMsg, with the same fields as a
+// CreateOrderMsg
+type BatchOrder struct {
+	OrderBookID         []byte                            `protobuf:"bytes,1,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Offer               *coin.Coin                        `protobuf:"bytes,2,opt,name=offer,proto3" json:"offer,omitempty"`
+	Price               *Amount                           `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	OrderType           OrderType                         `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=orderbook.OrderType" json:"order_type,omitempty"`
+	TimeInForce         TimeInForce                       `protobuf:"varint,5,opt,name=time_in_force,json=timeInForce,proto3,enum=orderbook.TimeInForce" json:"time_in_force,omitempty"`
+	ExpiresAt           github_com_iov_one_weave.UnixTime `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"expires_at,omitempty"`
+	DiscountedFee       bool                              `protobuf:"varint,7,opt,name=discounted_fee,json=discountedFee,proto3" json:"discounted_fee,omitempty"`
+	SelfTradePrevention SelfTradePrevention               `protobuf:"varint,8,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=orderbook.SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	PostOnly            PostOnly                          `protobuf:"varint,9,opt,name=post_only,json=postOnly,proto3,enum=orderbook.PostOnly" json:"post_only,omitempty"`
+}
+
+func (m *BatchOrder) Reset()         { *m = BatchOrder{} }
+func (m *BatchOrder) String() string { return proto.CompactTextString(m) }
+func (*BatchOrder) ProtoMessage()    {}
+func (*BatchOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{14}
+}
+func (m *BatchOrder) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchOrder.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.MakerPaid != nil {
-		l = m.MakerPaid.Size()
-		n += 1 + l + sovCodec(uint64(l))
+}
+func (m *BatchOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchOrder.Merge(m, src)
+}
+func (m *BatchOrder) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchOrder proto.InternalMessageInfo
+
+func (m *BatchOrder) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
 	}
-	if m.TakerPaid != nil {
-		l = m.TakerPaid.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *BatchOrder) GetOffer() *coin.Coin {
+	if m != nil {
+		return m.Offer
 	}
-	if m.ExecutedAt != 0 {
-		n += 1 + sovCodec(uint64(m.ExecutedAt))
+	return nil
+}
+
+func (m *BatchOrder) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
 	}
-	return n
+	return nil
 }
 
-func (m *OrderBook) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BatchOrder) GetOrderType() OrderType {
+	if m != nil {
+		return m.OrderType
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return OrderType_Limit
+}
+
+func (m *BatchOrder) GetTimeInForce() TimeInForce {
+	if m != nil {
+		return m.TimeInForce
 	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return TimeInForce_GoodTillCancel
+}
+
+func (m *BatchOrder) GetExpiresAt() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.ExpiresAt
 	}
-	l = len(m.MarketID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
-	}
-	l = len(m.AskTicker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return 0
+}
+
+func (m *BatchOrder) GetDiscountedFee() bool {
+	if m != nil {
+		return m.DiscountedFee
 	}
-	l = len(m.BidTicker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return false
+}
+
+func (m *BatchOrder) GetSelfTradePrevention() SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
 	}
-	if m.TotalAskCount != 0 {
-		n += 1 + sovCodec(uint64(m.TotalAskCount))
+	return SelfTradePrevention_CancelNewest
+}
+
+func (m *BatchOrder) GetPostOnly() PostOnly {
+	if m != nil {
+		return m.PostOnly
 	}
-	if m.TotalBidCount != 0 {
-		n += 1 + sovCodec(uint64(m.TotalBidCount))
+	return PostOnly_Disabled
+}
+
+// CancelAllOrdersMsg cancels all open orders of the trader and refunds their
+// remaining offers. It must be authorized by the trader.
+type CancelAllOrdersMsg struct {
+	Metadata *weave.Metadata                  `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Trader   github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	// OrderBookID optionally restricts cancelling to the orders of one orderbook
+	OrderBookID []byte `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	// Side optionally restricts cancelling to the orders of one side
+	Side Side `protobuf:"varint,4,opt,name=side,proto3,enum=orderbook.Side" json:"side,omitempty"`
+}
+
+func (m *CancelAllOrdersMsg) Reset()         { *m = CancelAllOrdersMsg{} }
+func (m *CancelAllOrdersMsg) String() string { return proto.CompactTextString(m) }
+func (*CancelAllOrdersMsg) ProtoMessage()    {}
+func (*CancelAllOrdersMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{15}
+}
+func (m *CancelAllOrdersMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelAllOrdersMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelAllOrdersMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *CancelAllOrdersMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelAllOrdersMsg.Merge(m, src)
+}
+func (m *CancelAllOrdersMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelAllOrdersMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelAllOrdersMsg.DiscardUnknown(m)
 }
 
-func (m *Market) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_CancelAllOrdersMsg proto.InternalMessageInfo
+
+func (m *CancelAllOrdersMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CancelAllOrdersMsg) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
 	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CancelAllOrdersMsg) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
 	}
-	l = len(m.Owner)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CancelAllOrdersMsg) GetSide() Side {
+	if m != nil {
+		return m.Side
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return Side_Invalid
+}
+
+// CreateStopOrderMsg escrows the offer and creates a stop order, which is
+// placed as an order once the last trade price of the orderbook crosses the
+// trigger price. It must not be triggered by the current last price.
+type CreateStopOrderMsg struct {
+	Metadata    *weave.Metadata                  `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Trader      github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	OrderBookID []byte                           `protobuf:"bytes,3,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Direction   TriggerDirection                 `protobuf:"varint,4,opt,name=direction,proto3,enum=orderbook.TriggerDirection" json:"direction,omitempty"`
+	// TriggerPrice is in bid ticker per unit of the ask ticker
+	TriggerPrice *Amount `protobuf:"bytes,5,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`
+	// Offer, Price, OrderType and TimeInForce of the order placed once
+	// triggered, as for CreateOrderMsg. Fill or kill is not supported
+	Offer               *coin.Coin          `protobuf:"bytes,6,opt,name=offer,proto3" json:"offer,omitempty"`
+	Price               *Amount             `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	OrderType           OrderType           `protobuf:"varint,8,opt,name=order_type,json=orderType,proto3,enum=orderbook.OrderType" json:"order_type,omitempty"`
+	TimeInForce         TimeInForce         `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=orderbook.TimeInForce" json:"time_in_force,omitempty"`
+	SelfTradePrevention SelfTradePrevention `protobuf:"varint,10,opt,name=self_trade_prevention,json=selfTradePrevention,proto3,enum=orderbook.SelfTradePrevention" json:"self_trade_prevention,omitempty"`
+	DiscountedFee       bool                `protobuf:"varint,11,opt,name=discounted_fee,json=discountedFee,proto3" json:"discounted_fee,omitempty"`
+}
+
+func (m *CreateStopOrderMsg) Reset()         { *m = CreateStopOrderMsg{} }
+func (m *CreateStopOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*CreateStopOrderMsg) ProtoMessage()    {}
+func (*CreateStopOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{16}
+}
+func (m *CreateStopOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateStopOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateStopOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *CreateStopOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateStopOrderMsg.Merge(m, src)
+}
+func (m *CreateStopOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateStopOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateStopOrderMsg.DiscardUnknown(m)
 }
 
-func (m *CreateOrderMsg) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_CreateStopOrderMsg proto.InternalMessageInfo
+
+func (m *CreateStopOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateStopOrderMsg) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
 	}
-	l = len(m.Trader)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateStopOrderMsg) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
 	}
-	l = len(m.OrderBookID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateStopOrderMsg) GetDirection() TriggerDirection {
+	if m != nil {
+		return m.Direction
 	}
-	if m.Offer != nil {
-		l = m.Offer.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return TriggerDirection_Invalid
+}
+
+func (m *CreateStopOrderMsg) GetTriggerPrice() *Amount {
+	if m != nil {
+		return m.TriggerPrice
 	}
-	if m.Price != nil {
-		l = m.Price.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateStopOrderMsg) GetOffer() *coin.Coin {
+	if m != nil {
+		return m.Offer
 	}
-	return n
+	return nil
 }
 
-func (m *CancelOrderMsg) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateStopOrderMsg) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CreateStopOrderMsg) GetOrderType() OrderType {
+	if m != nil {
+		return m.OrderType
 	}
-	l = len(m.OrderID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return OrderType_Limit
+}
+
+func (m *CreateStopOrderMsg) GetTimeInForce() TimeInForce {
+	if m != nil {
+		return m.TimeInForce
 	}
-	return n
+	return TimeInForce_GoodTillCancel
 }
 
-func (m *CreateOrderBookMsg) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateStopOrderMsg) GetSelfTradePrevention() SelfTradePrevention {
+	if m != nil {
+		return m.SelfTradePrevention
 	}
-	var l int
-	_ = l
-	if m.Metadata != nil {
-		l = m.Metadata.Size()
-		n += 1 + l + sovCodec(uint64(l))
+	return SelfTradePrevention_CancelNewest
+}
+
+func (m *CreateStopOrderMsg) GetDiscountedFee() bool {
+	if m != nil {
+		return m.DiscountedFee
 	}
-	l = len(m.MarketID)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return false
+}
+
+// CancelStopOrderMsg refunds a pending stop order to its trader, who must
+// authorize it
+type CancelStopOrderMsg struct {
+	Metadata    *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	StopOrderID []byte          `protobuf:"bytes,2,opt,name=stop_order_id,json=stopOrderId,proto3" json:"stop_order_id,omitempty"`
+}
+
+func (m *CancelStopOrderMsg) Reset()         { *m = CancelStopOrderMsg{} }
+func (m *CancelStopOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*CancelStopOrderMsg) ProtoMessage()    {}
+func (*CancelStopOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{17}
+}
+func (m *CancelStopOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelStopOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelStopOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.AskTicker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+}
+func (m *CancelStopOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelStopOrderMsg.Merge(m, src)
+}
+func (m *CancelStopOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelStopOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelStopOrderMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelStopOrderMsg proto.InternalMessageInfo
+
+func (m *CancelStopOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
 	}
-	l = len(m.BidTicker)
-	if l > 0 {
-		n += 1 + l + sovCodec(uint64(l))
+	return nil
+}
+
+func (m *CancelStopOrderMsg) GetStopOrderID() []byte {
+	if m != nil {
+		return m.StopOrderID
 	}
-	return n
+	return nil
 }
 
-func sovCodec(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+// CreateOrderBookMsg creates a new orderbook in an existing market.
+// It must be executed by the owner of the market.
+type CreateOrderBookMsg struct {
+	Metadata  *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	MarketID  []byte          `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	AskTicker string          `protobuf:"bytes,3,opt,name=ask_ticker,json=askTicker,proto3" json:"ask_ticker,omitempty"`
+	BidTicker string          `protobuf:"bytes,4,opt,name=bid_ticker,json=bidTicker,proto3" json:"bid_ticker,omitempty"`
+	// Optional trading rules of the orderbook, see OrderBook
+	TickSize *Amount `protobuf:"bytes,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize  *Amount `protobuf:"bytes,6,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	MinOffer *Amount `protobuf:"bytes,7,opt,name=min_offer,json=minOffer,proto3" json:"min_offer,omitempty"`
+}
+
+func (m *CreateOrderBookMsg) Reset()         { *m = CreateOrderBookMsg{} }
+func (m *CreateOrderBookMsg) String() string { return proto.CompactTextString(m) }
+func (*CreateOrderBookMsg) ProtoMessage()    {}
+func (*CreateOrderBookMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{18}
+}
+func (m *CreateOrderBookMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateOrderBookMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateOrderBookMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
 }
-func sozCodec(x uint64) (n int) {
-	return sovCodec(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *CreateOrderBookMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrderBookMsg.Merge(m, src)
+}
+func (m *CreateOrderBookMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateOrderBookMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrderBookMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrderBookMsg proto.InternalMessageInfo
+
+func (m *CreateOrderBookMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CreateOrderBookMsg) GetMarketID() []byte {
+	if m != nil {
+		return m.MarketID
+	}
+	return nil
+}
+
+func (m *CreateOrderBookMsg) GetAskTicker() string {
+	if m != nil {
+		return m.AskTicker
+	}
+	return ""
+}
+
+func (m *CreateOrderBookMsg) GetBidTicker() string {
+	if m != nil {
+		return m.BidTicker
+	}
+	return ""
+}
+
+func (m *CreateOrderBookMsg) GetTickSize() *Amount {
+	if m != nil {
+		return m.TickSize
+	}
+	return nil
+}
+
+func (m *CreateOrderBookMsg) GetLotSize() *Amount {
+	if m != nil {
+		return m.LotSize
+	}
+	return nil
+}
+
+func (m *CreateOrderBookMsg) GetMinOffer() *Amount {
+	if m != nil {
+		return m.MinOffer
+	}
+	return nil
+}
+
+// ExpireOrderMsg cancels an order that reached its expiration time and refunds
+// the remaining offer. It is only executed by the cron scheduler, it cannot be
+// sent in a transaction.
+type ExpireOrderMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderID  []byte          `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+}
+
+func (m *ExpireOrderMsg) Reset()         { *m = ExpireOrderMsg{} }
+func (m *ExpireOrderMsg) String() string { return proto.CompactTextString(m) }
+func (*ExpireOrderMsg) ProtoMessage()    {}
+func (*ExpireOrderMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{19}
+}
+func (m *ExpireOrderMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExpireOrderMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExpireOrderMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExpireOrderMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpireOrderMsg.Merge(m, src)
+}
+func (m *ExpireOrderMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExpireOrderMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpireOrderMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpireOrderMsg proto.InternalMessageInfo
+
+func (m *ExpireOrderMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ExpireOrderMsg) GetOrderID() []byte {
+	if m != nil {
+		return m.OrderID
+	}
+	return nil
+}
+
+// ActivateStopsMsg places the pending stop orders of an orderbook that are
+// triggered by its last price. It is only executed by the cron scheduler, it
+// cannot be sent in a transaction.
+type ActivateStopsMsg struct {
+	Metadata    *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	OrderBookID []byte          `protobuf:"bytes,2,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+}
+
+func (m *ActivateStopsMsg) Reset()         { *m = ActivateStopsMsg{} }
+func (m *ActivateStopsMsg) String() string { return proto.CompactTextString(m) }
+func (*ActivateStopsMsg) ProtoMessage()    {}
+func (*ActivateStopsMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{20}
+}
+func (m *ActivateStopsMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ActivateStopsMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ActivateStopsMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ActivateStopsMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateStopsMsg.Merge(m, src)
+}
+func (m *ActivateStopsMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *ActivateStopsMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateStopsMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateStopsMsg proto.InternalMessageInfo
+
+func (m *ActivateStopsMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ActivateStopsMsg) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
+	}
+	return nil
+}
+
+// CreateMarketMsg creates a new market with a unique name.
+// It must be authorized by the owner of the new market.
+type CreateMarketMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Owner is allowed to create new orderbooks in this Market
+	Owner github_com_iov_one_weave.Address `protobuf:"bytes,2,opt,name=owner,proto3,casttype=github.com/iov-one/weave.Address" json:"owner,omitempty"`
+	// Market name
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// MakerFee and TakerFee are the fee rates of the market, each a fraction
+	// below 1. Empty charges no fee
+	MakerFee *Amount `protobuf:"bytes,4,opt,name=maker_fee,json=makerFee,proto3" json:"maker_fee,omitempty"`
+	TakerFee *Amount `protobuf:"bytes,5,opt,name=taker_fee,json=takerFee,proto3" json:"taker_fee,omitempty"`
+	// FeeCollector receives all fees, empty sends them to the owner
+	FeeCollector github_com_iov_one_weave.Address `protobuf:"bytes,6,opt,name=fee_collector,json=feeCollector,proto3,casttype=github.com/iov-one/weave.Address" json:"fee_collector,omitempty"`
+}
+
+func (m *CreateMarketMsg) Reset()         { *m = CreateMarketMsg{} }
+func (m *CreateMarketMsg) String() string { return proto.CompactTextString(m) }
+func (*CreateMarketMsg) ProtoMessage()    {}
+func (*CreateMarketMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{21}
+}
+func (m *CreateMarketMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateMarketMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateMarketMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateMarketMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMarketMsg.Merge(m, src)
+}
+func (m *CreateMarketMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateMarketMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMarketMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMarketMsg proto.InternalMessageInfo
+
+func (m *CreateMarketMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CreateMarketMsg) GetOwner() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *CreateMarketMsg) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateMarketMsg) GetMakerFee() *Amount {
+	if m != nil {
+		return m.MakerFee
+	}
+	return nil
+}
+
+func (m *CreateMarketMsg) GetTakerFee() *Amount {
+	if m != nil {
+		return m.TakerFee
+	}
+	return nil
+}
+
+func (m *CreateMarketMsg) GetFeeCollector() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.FeeCollector
+	}
+	return nil
+}
+
+// UpdateMarketOwnerMsg hands the ownership of a market to a new address,
+// for example a multisig contract or a governance election rule.
+// It must be authorized by the current owner of the market.
+type UpdateMarketOwnerMsg struct {
+	Metadata *weave.Metadata                  `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	MarketID []byte                           `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	NewOwner github_com_iov_one_weave.Address `protobuf:"bytes,3,opt,name=new_owner,json=newOwner,proto3,casttype=github.com/iov-one/weave.Address" json:"new_owner,omitempty"`
+}
+
+func (m *UpdateMarketOwnerMsg) Reset()         { *m = UpdateMarketOwnerMsg{} }
+func (m *UpdateMarketOwnerMsg) String() string { return proto.CompactTextString(m) }
+func (*UpdateMarketOwnerMsg) ProtoMessage()    {}
+func (*UpdateMarketOwnerMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{22}
+}
+func (m *UpdateMarketOwnerMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateMarketOwnerMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateMarketOwnerMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateMarketOwnerMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMarketOwnerMsg.Merge(m, src)
+}
+func (m *UpdateMarketOwnerMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateMarketOwnerMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMarketOwnerMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMarketOwnerMsg proto.InternalMessageInfo
+
+func (m *UpdateMarketOwnerMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *UpdateMarketOwnerMsg) GetMarketID() []byte {
+	if m != nil {
+		return m.MarketID
+	}
+	return nil
+}
+
+func (m *UpdateMarketOwnerMsg) GetNewOwner() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.NewOwner
+	}
+	return nil
+}
+
+// UpdateConfigurationMsg patches the configuration, all fields that are set
+// replace the current ones. It must be authorized by the configuration owner.
+type UpdateConfigurationMsg struct {
+	Metadata *weave.Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Patch    *Configuration  `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+}
+
+func (m *UpdateConfigurationMsg) Reset()         { *m = UpdateConfigurationMsg{} }
+func (m *UpdateConfigurationMsg) String() string { return proto.CompactTextString(m) }
+func (*UpdateConfigurationMsg) ProtoMessage()    {}
+func (*UpdateConfigurationMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{23}
+}
+func (m *UpdateConfigurationMsg) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateConfigurationMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateConfigurationMsg.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateConfigurationMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateConfigurationMsg.Merge(m, src)
+}
+func (m *UpdateConfigurationMsg) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateConfigurationMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateConfigurationMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateConfigurationMsg proto.InternalMessageInfo
+
+func (m *UpdateConfigurationMsg) GetMetadata() *weave.Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *UpdateConfigurationMsg) GetPatch() *Configuration {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
+}
+
+// DepthQuery requests the aggregated open orders of an orderbook, served at
+// /orderbooks/depth
+type DepthQuery struct {
+	OrderBookID []byte `protobuf:"bytes,1,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	// Levels is the maximum number of price levels returned per side.
+	// Zero requests the default.
+	Levels int32 `protobuf:"varint,2,opt,name=levels,proto3" json:"levels,omitempty"`
+}
+
+func (m *DepthQuery) Reset()         { *m = DepthQuery{} }
+func (m *DepthQuery) String() string { return proto.CompactTextString(m) }
+func (*DepthQuery) ProtoMessage()    {}
+func (*DepthQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{24}
+}
+func (m *DepthQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DepthQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DepthQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DepthQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepthQuery.Merge(m, src)
+}
+func (m *DepthQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *DepthQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepthQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepthQuery proto.InternalMessageInfo
+
+func (m *DepthQuery) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
+	}
+	return nil
+}
+
+func (m *DepthQuery) GetLevels() int32 {
+	if m != nil {
+		return m.Levels
+	}
+	return 0
+}
+
+// TickerQuery requests the ticker of an orderbook, served at /orderbooks/ticker
+type TickerQuery struct {
+	OrderBookID []byte `protobuf:"bytes,1,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	// Now is the time the 24h statistics are rolled forward to, usually the
+	// time of the latest block. Zero returns the statistics as of the last trade
+	Now github_com_iov_one_weave.UnixTime `protobuf:"varint,2,opt,name=now,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"now,omitempty"`
+}
+
+func (m *TickerQuery) Reset()         { *m = TickerQuery{} }
+func (m *TickerQuery) String() string { return proto.CompactTextString(m) }
+func (*TickerQuery) ProtoMessage()    {}
+func (*TickerQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{25}
+}
+func (m *TickerQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TickerQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TickerQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TickerQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerQuery.Merge(m, src)
+}
+func (m *TickerQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *TickerQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerQuery proto.InternalMessageInfo
+
+func (m *TickerQuery) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
+	}
+	return nil
+}
+
+func (m *TickerQuery) GetNow() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.Now
+	}
+	return 0
+}
+
+// PriceLevel sums all open orders of one side of an orderbook with the same price
+type PriceLevel struct {
+	// Price of all orders in this level, in tickers of the opposite side
+	Price *Amount `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	// TotalOffer is the sum of the remaining offers of all orders
+	TotalOffer *coin.Coin `protobuf:"bytes,2,opt,name=total_offer,json=totalOffer,proto3" json:"total_offer,omitempty"`
+	// OrderCount is the number of open orders
+	OrderCount int64 `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+}
+
+func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
+func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
+func (*PriceLevel) ProtoMessage()    {}
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{26}
+}
+func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PriceLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceLevel.Merge(m, src)
+}
+func (m *PriceLevel) XXX_Size() int {
+	return m.Size()
+}
+func (m *PriceLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+
+func (m *PriceLevel) GetPrice() *Amount {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *PriceLevel) GetTotalOffer() *coin.Coin {
+	if m != nil {
+		return m.TotalOffer
+	}
+	return nil
+}
+
+func (m *PriceLevel) GetOrderCount() int64 {
+	if m != nil {
+		return m.OrderCount
+	}
+	return 0
+}
+
+// OrderBookDepth is the response to a DepthQuery (Level 2 market data).
+// Both sides are sorted with the best price first.
+type OrderBookDepth struct {
+	OrderBookID []byte        `protobuf:"bytes,1,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Asks        []*PriceLevel `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	Bids        []*PriceLevel `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+}
+
+func (m *OrderBookDepth) Reset()         { *m = OrderBookDepth{} }
+func (m *OrderBookDepth) String() string { return proto.CompactTextString(m) }
+func (*OrderBookDepth) ProtoMessage()    {}
+func (*OrderBookDepth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{27}
+}
+func (m *OrderBookDepth) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderBookDepth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderBookDepth.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderBookDepth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBookDepth.Merge(m, src)
+}
+func (m *OrderBookDepth) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderBookDepth) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBookDepth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBookDepth proto.InternalMessageInfo
+
+func (m *OrderBookDepth) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
+	}
+	return nil
+}
+
+func (m *OrderBookDepth) GetAsks() []*PriceLevel {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+func (m *OrderBookDepth) GetBids() []*PriceLevel {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+// CandleQuery requests the candles of an orderbook in a time range, served at
+// /candles/range
+type CandleQuery struct {
+	OrderBookID []byte         `protobuf:"bytes,1,opt,name=order_book_id,json=orderBookId,proto3" json:"order_book_id,omitempty"`
+	Interval    CandleInterval `protobuf:"varint,2,opt,name=interval,proto3,enum=orderbook.CandleInterval" json:"interval,omitempty"`
+	// Candles starting at or after since are returned
+	Since github_com_iov_one_weave.UnixTime `protobuf:"varint,3,opt,name=since,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"since,omitempty"`
+	// Candles starting before until are returned, zero has no upper bound
+	Until github_com_iov_one_weave.UnixTime `protobuf:"varint,4,opt,name=until,proto3,casttype=github.com/iov-one/weave.UnixTime" json:"until,omitempty"`
+	// Limit is the maximum number of candles returned, zero requests the default
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *CandleQuery) Reset()         { *m = CandleQuery{} }
+func (m *CandleQuery) String() string { return proto.CompactTextString(m) }
+func (*CandleQuery) ProtoMessage()    {}
+func (*CandleQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{28}
+}
+func (m *CandleQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CandleQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CandleQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CandleQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandleQuery.Merge(m, src)
+}
+func (m *CandleQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *CandleQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandleQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandleQuery proto.InternalMessageInfo
+
+func (m *CandleQuery) GetOrderBookID() []byte {
+	if m != nil {
+		return m.OrderBookID
+	}
+	return nil
+}
+
+func (m *CandleQuery) GetInterval() CandleInterval {
+	if m != nil {
+		return m.Interval
+	}
+	return CandleInterval_Invalid
+}
+
+func (m *CandleQuery) GetSince() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *CandleQuery) GetUntil() github_com_iov_one_weave.UnixTime {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *CandleQuery) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// TraderOrdersQuery requests one page of the orders of a trader in one state,
+// newest first, served at /traders/orders
+type TraderOrdersQuery struct {
+	Trader github_com_iov_one_weave.Address `protobuf:"bytes,1,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	State  OrderState                       `protobuf:"varint,2,opt,name=state,proto3,enum=orderbook.OrderState" json:"state,omitempty"`
+	// After is the ID of the last order of the previous page, empty for the first page
+	After []byte `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	// Limit is the maximum number of orders returned, zero requests the default
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *TraderOrdersQuery) Reset()         { *m = TraderOrdersQuery{} }
+func (m *TraderOrdersQuery) String() string { return proto.CompactTextString(m) }
+func (*TraderOrdersQuery) ProtoMessage()    {}
+func (*TraderOrdersQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{29}
+}
+func (m *TraderOrdersQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraderOrdersQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraderOrdersQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraderOrdersQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraderOrdersQuery.Merge(m, src)
+}
+func (m *TraderOrdersQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraderOrdersQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraderOrdersQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraderOrdersQuery proto.InternalMessageInfo
+
+func (m *TraderOrdersQuery) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
+	}
+	return nil
+}
+
+func (m *TraderOrdersQuery) GetState() OrderState {
+	if m != nil {
+		return m.State
+	}
+	return OrderState_Invalid
+}
+
+func (m *TraderOrdersQuery) GetAfter() []byte {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TraderOrdersQuery) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// TraderTradesQuery requests one page of the trades of a trader, as maker or
+// taker, newest first, served at /traders/trades
+type TraderTradesQuery struct {
+	Trader github_com_iov_one_weave.Address `protobuf:"bytes,1,opt,name=trader,proto3,casttype=github.com/iov-one/weave.Address" json:"trader,omitempty"`
+	// After is the ID of the last trade of the previous page, empty for the first page
+	After []byte `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	// Limit is the maximum number of trades returned, zero requests the default
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *TraderTradesQuery) Reset()         { *m = TraderTradesQuery{} }
+func (m *TraderTradesQuery) String() string { return proto.CompactTextString(m) }
+func (*TraderTradesQuery) ProtoMessage()    {}
+func (*TraderTradesQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_492308ae36fa08c1, []int{30}
+}
+func (m *TraderTradesQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraderTradesQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraderTradesQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TraderTradesQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraderTradesQuery.Merge(m, src)
+}
+func (m *TraderTradesQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraderTradesQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraderTradesQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraderTradesQuery proto.InternalMessageInfo
+
+func (m *TraderTradesQuery) GetTrader() github_com_iov_one_weave.Address {
+	if m != nil {
+		return m.Trader
+	}
+	return nil
+}
+
+func (m *TraderTradesQuery) GetAfter() []byte {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+func (m *TraderTradesQuery) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("orderbook.OrderState", OrderState_name, OrderState_value)
+	proto.RegisterEnum("orderbook.Side", Side_name, Side_value)
+	proto.RegisterEnum("orderbook.OrderType", OrderType_name, OrderType_value)
+	proto.RegisterEnum("orderbook.TimeInForce", TimeInForce_name, TimeInForce_value)
+	proto.RegisterEnum("orderbook.SelfTradePrevention", SelfTradePrevention_name, SelfTradePrevention_value)
+	proto.RegisterEnum("orderbook.PostOnly", PostOnly_name, PostOnly_value)
+	proto.RegisterEnum("orderbook.CancelReason", CancelReason_name, CancelReason_value)
+	proto.RegisterEnum("orderbook.TriggerDirection", TriggerDirection_name, TriggerDirection_value)
+	proto.RegisterEnum("orderbook.StopOrderState", StopOrderState_name, StopOrderState_value)
+	proto.RegisterEnum("orderbook.CandleInterval", CandleInterval_name, CandleInterval_value)
+	proto.RegisterType((*Amount)(nil), "orderbook.Amount")
+	proto.RegisterType((*Order)(nil), "orderbook.Order")
+	proto.RegisterType((*StopOrder)(nil), "orderbook.StopOrder")
+	proto.RegisterType((*Trade)(nil), "orderbook.Trade")
+	proto.RegisterType((*OrderBook)(nil), "orderbook.OrderBook")
+	proto.RegisterType((*Market)(nil), "orderbook.Market")
+	proto.RegisterType((*Candle)(nil), "orderbook.Candle")
+	proto.RegisterType((*Ticker)(nil), "orderbook.Ticker")
+	proto.RegisterType((*Configuration)(nil), "orderbook.Configuration")
+	proto.RegisterType((*FeePrice)(nil), "orderbook.FeePrice")
+	proto.RegisterType((*CreateOrderMsg)(nil), "orderbook.CreateOrderMsg")
+	proto.RegisterType((*CancelOrderMsg)(nil), "orderbook.CancelOrderMsg")
+	proto.RegisterType((*ReplaceOrderMsg)(nil), "orderbook.ReplaceOrderMsg")
+	proto.RegisterType((*CreateOrdersMsg)(nil), "orderbook.CreateOrdersMsg")
+	proto.RegisterType((*BatchOrder)(nil), "orderbook.BatchOrder")
+	proto.RegisterType((*CancelAllOrdersMsg)(nil), "orderbook.CancelAllOrdersMsg")
+	proto.RegisterType((*CreateStopOrderMsg)(nil), "orderbook.CreateStopOrderMsg")
+	proto.RegisterType((*CancelStopOrderMsg)(nil), "orderbook.CancelStopOrderMsg")
+	proto.RegisterType((*CreateOrderBookMsg)(nil), "orderbook.CreateOrderBookMsg")
+	proto.RegisterType((*ExpireOrderMsg)(nil), "orderbook.ExpireOrderMsg")
+	proto.RegisterType((*ActivateStopsMsg)(nil), "orderbook.ActivateStopsMsg")
+	proto.RegisterType((*CreateMarketMsg)(nil), "orderbook.CreateMarketMsg")
+	proto.RegisterType((*UpdateMarketOwnerMsg)(nil), "orderbook.UpdateMarketOwnerMsg")
+	proto.RegisterType((*UpdateConfigurationMsg)(nil), "orderbook.UpdateConfigurationMsg")
+	proto.RegisterType((*DepthQuery)(nil), "orderbook.DepthQuery")
+	proto.RegisterType((*TickerQuery)(nil), "orderbook.TickerQuery")
+	proto.RegisterType((*PriceLevel)(nil), "orderbook.PriceLevel")
+	proto.RegisterType((*OrderBookDepth)(nil), "orderbook.OrderBookDepth")
+	proto.RegisterType((*CandleQuery)(nil), "orderbook.CandleQuery")
+	proto.RegisterType((*TraderOrdersQuery)(nil), "orderbook.TraderOrdersQuery")
+	proto.RegisterType((*TraderTradesQuery)(nil), "orderbook.TraderTradesQuery")
+}
+
+func init() { proto.RegisterFile("x/orderbook/codec.proto", fileDescriptor_492308ae36fa08c1) }
+
+var fileDescriptor_492308ae36fa08c1 = []byte{
+	// 3036 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4d, 0x6c, 0x1b, 0xc7,
+	0xf5, 0xf7, 0xf2, 0x9b, 0x8f, 0x1f, 0x5a, 0x8f, 0x65, 0x7b, 0xa3, 0x20, 0x12, 0xc3, 0xd8, 0x8e,
+	0x2c, 0xc7, 0x72, 0xfe, 0x76, 0x92, 0x3f, 0x92, 0x06, 0x05, 0xf8, 0xb1, 0xb2, 0xb7, 0x96, 0x48,
+	0x65, 0x49, 0x3b, 0xf5, 0x69, 0xb1, 0xe2, 0x0e, 0xe5, 0xa9, 0x96, 0xbb, 0x0c, 0x77, 0x25, 0x59,
+	0x29, 0x7a, 0x28, 0x7a, 0xaa, 0x80, 0x7e, 0x5d, 0x7a, 0x29, 0x74, 0x2f, 0xda, 0x53, 0x6f, 0xed,
+	0xbd, 0x87, 0x1c, 0x73, 0x29, 0x90, 0x02, 0x85, 0x5a, 0x28, 0x87, 0x5e, 0x7b, 0x6a, 0x81, 0x9c,
+	0x8a, 0x99, 0xd9, 0x5d, 0x2e, 0x45, 0x51, 0xd2, 0xd2, 0x4a, 0x83, 0xa2, 0x17, 0x61, 0x67, 0xde,
+	0xef, 0xcd, 0xbc, 0x79, 0xf3, 0xde, 0x9b, 0xf7, 0x9e, 0x08, 0xd7, 0x5f, 0xdc, 0xb3, 0x07, 0x06,
+	0x1e, 0x6c, 0xd8, 0xf6, 0xd6, 0xbd, 0x8e, 0x6d, 0xe0, 0xce, 0x72, 0x7f, 0x60, 0xbb, 0x36, 0xca,
+	0x06, 0xd3, 0x73, 0xb9, 0xd0, 0xfc, 0x9c, 0xd8, 0xb1, 0x89, 0x15, 0x46, 0xce, 0xcd, 0x6e, 0xda,
+	0x9b, 0x36, 0xfb, 0xbc, 0x47, 0xbf, 0xf8, 0x6c, 0xf9, 0xdb, 0x90, 0xaa, 0xf4, 0xec, 0x6d, 0xcb,
+	0x45, 0xb3, 0x90, 0xdc, 0x7d, 0x6e, 0x9b, 0x58, 0x12, 0x4a, 0xc2, 0x62, 0x5c, 0xe5, 0x03, 0x34,
+	0x0f, 0xd0, 0x1d, 0xe8, 0x1d, 0x97, 0xd8, 0x96, 0x6e, 0x4a, 0x31, 0x46, 0x0a, 0xcd, 0x94, 0xbf,
+	0xc8, 0x40, 0xb2, 0x49, 0x45, 0x40, 0x77, 0x20, 0xd3, 0xc3, 0xae, 0x6e, 0xe8, 0xae, 0xce, 0x96,
+	0xc8, 0xdd, 0x9f, 0x59, 0xde, 0xc5, 0xfa, 0x0e, 0x5e, 0x5e, 0xf3, 0xa6, 0xd5, 0x00, 0x80, 0xae,
+	0x41, 0x8c, 0x18, 0x6c, 0xb9, 0x7c, 0x35, 0x75, 0x74, 0xb8, 0x10, 0x53, 0xea, 0x6a, 0x8c, 0x18,
+	0xe8, 0x43, 0x48, 0xb9, 0x03, 0xdd, 0xc0, 0x03, 0x29, 0xce, 0x68, 0x37, 0xbe, 0x3a, 0x5c, 0x28,
+	0x6d, 0x12, 0xf7, 0xf9, 0xf6, 0xc6, 0x72, 0xc7, 0xee, 0xdd, 0x23, 0xf6, 0xce, 0x5d, 0xdb, 0xc2,
+	0xf7, 0xf8, 0xc2, 0x15, 0xc3, 0x18, 0x60, 0xc7, 0x51, 0x3d, 0x1e, 0xf4, 0x00, 0x0a, 0x4c, 0x1d,
+	0x1a, 0xd5, 0x87, 0x46, 0x0c, 0x29, 0xc1, 0x16, 0x99, 0x39, 0x3a, 0x5c, 0xc8, 0x31, 0x21, 0xab,
+	0xb6, 0xbd, 0xa5, 0xd4, 0xd5, 0x9c, 0x1d, 0x0c, 0x0c, 0xf4, 0x06, 0x24, 0x1c, 0x62, 0x60, 0x29,
+	0x59, 0x12, 0x16, 0x8b, 0xf7, 0x67, 0x96, 0x03, 0x85, 0x2e, 0xb7, 0x88, 0x81, 0x55, 0x46, 0x44,
+	0xef, 0x01, 0xe7, 0xd1, 0x1c, 0x57, 0x77, 0xb1, 0x94, 0x62, 0xd8, 0xab, 0x21, 0x2c, 0x5b, 0xbe,
+	0x45, 0x89, 0x2a, 0xd8, 0xc1, 0x37, 0xfa, 0x3f, 0x28, 0xda, 0x03, 0xb2, 0x49, 0x2c, 0xdd, 0xd4,
+	0xec, 0x6e, 0x17, 0x0f, 0xa4, 0x34, 0x53, 0x0d, 0x2c, 0xd3, 0xfb, 0x59, 0xae, 0xd9, 0xc4, 0x52,
+	0x0b, 0x3e, 0xa2, 0x49, 0x01, 0xe8, 0x01, 0xcc, 0x0c, 0x70, 0x4f, 0x27, 0x16, 0xb1, 0x36, 0x3d,
+	0x9e, 0xcc, 0x18, 0x4f, 0x31, 0x80, 0x70, 0xa6, 0x37, 0x21, 0xd9, 0x1f, 0x90, 0x0e, 0x96, 0xb2,
+	0x0c, 0x7a, 0x39, 0x24, 0x19, 0xbf, 0x5e, 0x95, 0xd3, 0xd1, 0xab, 0x90, 0x65, 0xca, 0xd2, 0x88,
+	0xe1, 0x48, 0x50, 0x8a, 0x2f, 0xe6, 0xd5, 0x0c, 0x9b, 0x50, 0x0c, 0x07, 0xd5, 0x01, 0x3a, 0x03,
+	0xac, 0xbb, 0xd8, 0xd0, 0x74, 0x57, 0xca, 0xd1, 0xcb, 0xae, 0xde, 0xfc, 0xea, 0x70, 0xe1, 0xf5,
+	0x89, 0x37, 0xf0, 0xc4, 0x22, 0x2f, 0xda, 0xa4, 0x87, 0xd5, 0xac, 0xc7, 0x58, 0x71, 0xe9, 0x2a,
+	0xdb, 0x7d, 0xc3, 0x5f, 0x25, 0x1f, 0x69, 0x15, 0x8f, 0xb1, 0xe2, 0xa2, 0x07, 0xc0, 0xf5, 0xa8,
+	0xb9, 0x7b, 0x7d, 0x2c, 0x15, 0x98, 0xc2, 0x67, 0x8f, 0x2b, 0xbc, 0xbd, 0xd7, 0xc7, 0x6a, 0xd6,
+	0xf6, 0x3f, 0xd1, 0x07, 0x50, 0x70, 0x49, 0x0f, 0x6b, 0xc4, 0xd2, 0xba, 0xf6, 0xa0, 0x83, 0xa5,
+	0x22, 0xe3, 0xbb, 0x16, 0xe2, 0xa3, 0xfb, 0x28, 0xd6, 0x0a, 0xa5, 0xaa, 0x39, 0x77, 0x38, 0xa0,
+	0x62, 0xe3, 0x17, 0x7d, 0x32, 0xc0, 0x0e, 0x15, 0x7b, 0x26, 0x92, 0xd8, 0x1e, 0x63, 0xc5, 0x45,
+	0x55, 0x40, 0x6c, 0xa0, 0x53, 0xff, 0xd0, 0x5c, 0xdd, 0x61, 0x76, 0x28, 0x32, 0x3b, 0x9c, 0x3d,
+	0x3a, 0x5c, 0x10, 0xe5, 0x80, 0xda, 0xd6, 0x1d, 0x6a, 0x8c, 0x22, 0x1e, 0x9d, 0x31, 0xd0, 0x4d,
+	0x28, 0x1a, 0xc4, 0xe9, 0xd0, 0x6b, 0xc3, 0x86, 0xd6, 0xc5, 0x58, 0xba, 0x5c, 0x12, 0x16, 0x33,
+	0x6a, 0x61, 0x38, 0xbb, 0x82, 0x31, 0x52, 0xe1, 0xaa, 0x83, 0xcd, 0xae, 0xc6, 0xef, 0xb3, 0x3f,
+	0xc0, 0x3b, 0xd8, 0xa2, 0xab, 0x48, 0x88, 0x1d, 0x7a, 0x3e, 0x6c, 0xc9, 0xd8, 0xec, 0xb6, 0x29,
+	0x6c, 0x3d, 0x40, 0xa9, 0x57, 0x9c, 0xf1, 0x49, 0xf4, 0x21, 0x14, 0x3a, 0xba, 0xd5, 0xc1, 0xa6,
+	0x36, 0xc0, 0xba, 0x63, 0x5b, 0xd2, 0x15, 0xb6, 0xd6, 0xf5, 0xd0, 0x5a, 0x35, 0x46, 0x57, 0x19,
+	0x59, 0xcd, 0x77, 0x42, 0x23, 0xf4, 0x36, 0x64, 0xfb, 0xb6, 0xe3, 0x6a, 0xb6, 0x65, 0xee, 0x49,
+	0xb3, 0x8c, 0xf3, 0x4a, 0x88, 0x73, 0xdd, 0x76, 0xdc, 0xa6, 0x65, 0xee, 0xa9, 0x99, 0xbe, 0xf7,
+	0x85, 0xe6, 0x20, 0xe3, 0xe0, 0x4f, 0xb6, 0xb1, 0xd5, 0xc1, 0xd2, 0xd5, 0x92, 0xb0, 0x98, 0x50,
+	0x83, 0x71, 0xf9, 0x5f, 0x29, 0xc8, 0xb6, 0x5c, 0xbb, 0xff, 0x3f, 0x10, 0x5e, 0xee, 0x41, 0x32,
+	0x1c, 0x58, 0x5e, 0x09, 0xa3, 0x7c, 0x0d, 0xf0, 0xe0, 0xc2, 0x71, 0xe8, 0x7d, 0xc8, 0x1a, 0x64,
+	0x80, 0x59, 0x14, 0x66, 0x21, 0xa5, 0x78, 0xff, 0xd5, 0xb0, 0x91, 0x0f, 0xc8, 0xe6, 0x26, 0x1e,
+	0xd4, 0x7d, 0x88, 0x3a, 0x44, 0xa3, 0xf7, 0xa0, 0xe0, 0x72, 0xb2, 0xc6, 0x43, 0x46, 0x66, 0x52,
+	0xc8, 0xc8, 0x7b, 0xb8, 0x75, 0x16, 0x39, 0x4a, 0x90, 0xe4, 0xd1, 0x28, 0x3b, 0x16, 0x8d, 0x92,
+	0xf6, 0x68, 0x10, 0x82, 0x33, 0x82, 0xd0, 0xa8, 0x6f, 0xe7, 0xa6, 0xf4, 0xed, 0xfc, 0xf9, 0x7d,
+	0x7b, 0xa2, 0xab, 0x14, 0xa6, 0x77, 0x95, 0x71, 0x2f, 0x2d, 0x9e, 0xe4, 0xa5, 0xa3, 0x31, 0x75,
+	0xe6, 0x42, 0x62, 0xaa, 0x38, 0x65, 0x4c, 0xbd, 0x05, 0x19, 0xae, 0x77, 0x62, 0xb0, 0x90, 0x92,
+	0xaf, 0xe6, 0x8e, 0x0e, 0x17, 0xd2, 0x4c, 0xdd, 0x4a, 0x5d, 0x4d, 0x33, 0xa2, 0x62, 0x94, 0x7f,
+	0x91, 0x80, 0x24, 0x3b, 0xee, 0xc5, 0x78, 0xdd, 0x98, 0xdf, 0xc4, 0xcf, 0xe1, 0x37, 0x61, 0x59,
+	0x13, 0x93, 0x65, 0x45, 0x1f, 0x40, 0xd2, 0xd5, 0xb7, 0xf0, 0x40, 0x4a, 0x46, 0xf0, 0x68, 0xce,
+	0x42, 0x79, 0x7b, 0x8c, 0x37, 0x15, 0x85, 0x97, 0xb1, 0xa0, 0xdb, 0x00, 0xec, 0x43, 0xeb, 0xeb,
+	0xc4, 0x38, 0xe1, 0x55, 0xcf, 0x32, 0xea, 0xba, 0x4e, 0x0c, 0x0a, 0x75, 0x87, 0xd0, 0xf1, 0xc7,
+	0x3c, 0xeb, 0x06, 0xd0, 0x15, 0xc8, 0xe1, 0x17, 0xb8, 0xb3, 0xed, 0x5d, 0x74, 0x36, 0xca, 0x45,
+	0x83, 0xcf, 0x59, 0x71, 0xd1, 0x9b, 0xc0, 0xf7, 0x67, 0x76, 0x09, 0x63, 0x3b, 0x66, 0x18, 0x91,
+	0x9a, 0xe7, 0x9b, 0x90, 0x75, 0x03, 0x60, 0x6e, 0x1c, 0xe8, 0x7a, 0xc0, 0xf2, 0x3f, 0xe2, 0x90,
+	0x0d, 0x2e, 0xeb, 0x62, 0xec, 0xe2, 0x36, 0x15, 0x72, 0xb0, 0x85, 0xdd, 0xa1, 0x4d, 0xe4, 0x8f,
+	0x0e, 0x17, 0x32, 0x6b, 0x6c, 0x52, 0xa9, 0x53, 0x31, 0xd9, 0x97, 0x81, 0x5e, 0x03, 0xa0, 0x4f,
+	0xa9, 0x4b, 0x3a, 0xf4, 0xba, 0xa8, 0x3d, 0x64, 0xd5, 0xac, 0xee, 0x6c, 0xb5, 0xd9, 0x04, 0x25,
+	0x6f, 0x10, 0xc3, 0x27, 0x27, 0x39, 0x79, 0x83, 0x18, 0x1e, 0xf9, 0x16, 0xcc, 0xb8, 0xb6, 0xab,
+	0x9b, 0x1a, 0x5d, 0x83, 0xf9, 0x26, 0xbb, 0xf1, 0xb8, 0x5a, 0x60, 0xd3, 0x15, 0x67, 0xab, 0x46,
+	0x27, 0x87, 0x38, 0xba, 0x18, 0xc7, 0xa5, 0x43, 0xb8, 0x2a, 0x31, 0x38, 0x6e, 0x19, 0xb2, 0x74,
+	0x2b, 0xcd, 0x21, 0x9f, 0x9e, 0x12, 0x3e, 0x33, 0x14, 0xd3, 0x22, 0x9f, 0x62, 0xf4, 0x16, 0x64,
+	0x4c, 0xdb, 0xe5, 0xf0, 0x89, 0x09, 0x5a, 0xda, 0xb4, 0x5d, 0x86, 0x5e, 0x86, 0x6c, 0x8f, 0x58,
+	0x5e, 0xea, 0x37, 0x31, 0x94, 0x66, 0x7a, 0xc4, 0xe2, 0xb9, 0xdf, 0xdb, 0x90, 0x77, 0x5c, 0xbb,
+	0x1f, 0x24, 0x1b, 0x39, 0xa6, 0xc9, 0xe2, 0xd1, 0xe1, 0x02, 0xd0, 0xc7, 0xc3, 0x4b, 0x33, 0xc0,
+	0xf1, 0xbf, 0x59, 0x82, 0xe1, 0x65, 0xb3, 0xfe, 0xdb, 0x9b, 0x67, 0x6f, 0x2f, 0x77, 0xd3, 0x96,
+	0xff, 0x00, 0x7f, 0x16, 0x83, 0x14, 0xbf, 0x8b, 0x8b, 0xb9, 0xef, 0x0f, 0x20, 0x69, 0xef, 0x5a,
+	0x11, 0x1f, 0x5f, 0xce, 0x82, 0x10, 0x24, 0x2c, 0xbd, 0x87, 0xbd, 0xab, 0x67, 0xdf, 0x4c, 0x51,
+	0x81, 0xed, 0x26, 0x27, 0x2b, 0xca, 0xb7, 0xf5, 0xe5, 0xb0, 0xad, 0xa7, 0x26, 0x5f, 0x9b, 0x8f,
+	0x57, 0xa0, 0xd0, 0xc5, 0x58, 0xeb, 0xd8, 0xa6, 0x89, 0x3b, 0xae, 0xcd, 0x73, 0xf7, 0xf3, 0xca,
+	0x9d, 0xef, 0x62, 0x5c, 0xf3, 0x39, 0xcb, 0x3f, 0x4b, 0x40, 0xaa, 0xa6, 0x5b, 0x86, 0xf9, 0x4d,
+	0x86, 0xd4, 0x77, 0x21, 0x43, 0x2c, 0x17, 0x0f, 0x76, 0x74, 0x53, 0x4a, 0x8c, 0x25, 0x1a, 0x5c,
+	0x3c, 0xc5, 0x03, 0xa8, 0x01, 0x14, 0x7d, 0x8b, 0x25, 0x27, 0x03, 0x57, 0x4a, 0x46, 0x89, 0x46,
+	0x9c, 0x07, 0xdd, 0x84, 0x84, 0xdd, 0xc7, 0xd6, 0x64, 0x75, 0x33, 0x32, 0x85, 0x3d, 0x27, 0x9b,
+	0xcf, 0xa5, 0xf4, 0x44, 0x18, 0x25, 0xa3, 0x37, 0x20, 0x6e, 0xda, 0xbb, 0x93, 0x5d, 0x8e, 0x52,
+	0x69, 0x1a, 0xd2, 0x31, 0x6d, 0xe7, 0xb4, 0x5a, 0x88, 0xd1, 0xd1, 0x1d, 0xc8, 0x6d, 0xe8, 0x0e,
+	0xd6, 0x76, 0x6c, 0x73, 0xbb, 0x77, 0x52, 0x98, 0x04, 0x4a, 0x7e, 0xca, 0xa8, 0xe8, 0x2e, 0xe4,
+	0x3f, 0xd9, 0xb6, 0xdd, 0x00, 0x3d, 0x1e, 0x2b, 0x73, 0x8c, 0xee, 0xc1, 0x17, 0x20, 0xc7, 0x93,
+	0x0d, 0x1e, 0x46, 0xf2, 0xbc, 0x70, 0x66, 0x53, 0x2c, 0x86, 0x94, 0xff, 0x92, 0x80, 0x94, 0x17,
+	0x9e, 0x2e, 0xc4, 0x22, 0xde, 0x06, 0x30, 0x75, 0xc7, 0xf5, 0x72, 0xba, 0xf8, 0xa4, 0xa3, 0x67,
+	0x29, 0x88, 0x27, 0x74, 0x0a, 0x14, 0x18, 0x07, 0x97, 0x53, 0x77, 0xa5, 0x44, 0x94, 0xfb, 0xcd,
+	0x51, 0x5e, 0x96, 0x24, 0x54, 0x5c, 0x1a, 0xe0, 0x36, 0xb0, 0xe3, 0xd2, 0xb8, 0x39, 0xd9, 0x11,
+	0xd3, 0x14, 0x52, 0x25, 0x46, 0x80, 0xd6, 0x9d, 0x2d, 0x29, 0x75, 0x2a, 0xba, 0xe2, 0x6c, 0xa1,
+	0x47, 0x90, 0xdf, 0x25, 0x96, 0x61, 0xef, 0x6a, 0xdc, 0x0a, 0xd3, 0x91, 0xa4, 0xe4, 0xac, 0x2d,
+	0x66, 0x8b, 0x6f, 0x41, 0xc6, 0xd0, 0xf7, 0x34, 0x66, 0x68, 0x13, 0x4d, 0x28, 0x6d, 0xe8, 0x7b,
+	0x8f, 0xa8, 0xad, 0x2d, 0x01, 0xfd, 0xd4, 0xa8, 0xbd, 0x4d, 0x34, 0xa4, 0x94, 0xa1, 0xef, 0xad,
+	0xda, 0xbb, 0xe8, 0x3e, 0xcc, 0x50, 0xec, 0xe9, 0xd6, 0x54, 0x30, 0xf4, 0xbd, 0xea, 0xd0, 0xa0,
+	0xde, 0x01, 0x91, 0xf2, 0x9c, 0x61, 0x54, 0x45, 0x43, 0xdf, 0xfb, 0x28, 0x64, 0x57, 0xb7, 0xf8,
+	0x4e, 0xe3, 0xb6, 0x45, 0x57, 0x6f, 0x0f, 0xcd, 0xeb, 0x87, 0x31, 0x28, 0xd4, 0x6c, 0xab, 0x4b,
+	0x36, 0xb7, 0x79, 0x6d, 0x19, 0xcd, 0xca, 0x82, 0x50, 0x1d, 0x8b, 0x1e, 0xaa, 0x5f, 0x03, 0xa0,
+	0x61, 0xd3, 0x7b, 0x8c, 0xe3, 0xfc, 0x31, 0xee, 0x62, 0xec, 0x59, 0xfb, 0x3b, 0x40, 0x43, 0xa3,
+	0xe6, 0x67, 0xc9, 0x52, 0x62, 0x92, 0x72, 0x73, 0x5d, 0x8c, 0xeb, 0x1e, 0x0a, 0xdd, 0xe7, 0x8b,
+	0x32, 0xeb, 0x76, 0xa4, 0x64, 0x29, 0xbe, 0x98, 0x1b, 0xa9, 0x2d, 0x57, 0x30, 0x66, 0x56, 0xcd,
+	0x76, 0x62, 0x5f, 0x4e, 0xf9, 0x31, 0x64, 0xfc, 0x69, 0x74, 0x0d, 0x52, 0x9e, 0x40, 0x02, 0x13,
+	0xc8, 0x1b, 0x0d, 0x6b, 0x96, 0xd8, 0xe9, 0x35, 0x4b, 0xf9, 0xaf, 0x09, 0x28, 0xd6, 0x58, 0x3e,
+	0xce, 0xe2, 0xeb, 0x9a, 0xb3, 0x19, 0x4d, 0xa3, 0xc3, 0xd2, 0x33, 0x76, 0x11, 0xa5, 0xe7, 0x79,
+	0xe2, 0x7d, 0x50, 0xb1, 0x25, 0xce, 0xac, 0xd8, 0x92, 0x91, 0x2a, 0xb6, 0xd4, 0x94, 0x15, 0x5b,
+	0x7a, 0xda, 0x6e, 0x4c, 0x66, 0xca, 0x6e, 0xcc, 0x78, 0x8d, 0x96, 0x8d, 0xd4, 0x49, 0x81, 0xe9,
+	0xcb, 0xc3, 0x91, 0x5e, 0x48, 0xee, 0x1c, 0xbd, 0x90, 0x32, 0x86, 0x22, 0xef, 0xad, 0x4c, 0x67,
+	0x60, 0xe1, 0x82, 0x29, 0x76, 0x4a, 0x71, 0xf7, 0x47, 0x01, 0x66, 0x54, 0xdc, 0x37, 0xf5, 0x0e,
+	0xfe, 0x5a, 0x37, 0x1a, 0x1a, 0x57, 0xfc, 0x4c, 0xe3, 0x1a, 0xeb, 0x78, 0x26, 0xce, 0xea, 0x78,
+	0x96, 0x7f, 0x2b, 0xc0, 0x4c, 0xc8, 0x1f, 0x9d, 0xff, 0xb0, 0x43, 0xde, 0x85, 0x14, 0x3b, 0x8e,
+	0x23, 0xc5, 0x59, 0x2c, 0x0a, 0xf7, 0x82, 0xab, 0xba, 0xdb, 0x79, 0xce, 0xa4, 0x52, 0x3d, 0x10,
+	0xad, 0x9e, 0x60, 0x38, 0x3d, 0xee, 0xce, 0x42, 0x14, 0x77, 0x8e, 0x9d, 0xe9, 0xce, 0xf1, 0x48,
+	0xee, 0x9c, 0x98, 0xd2, 0x9d, 0x93, 0xd3, 0xba, 0x73, 0xea, 0xc2, 0xdc, 0x39, 0x1d, 0xc9, 0x9d,
+	0x33, 0x17, 0xe4, 0xce, 0xd9, 0xf3, 0xb8, 0xf3, 0x9f, 0x05, 0x40, 0xdc, 0x9f, 0x2b, 0xa6, 0xf9,
+	0x8d, 0xd8, 0xe8, 0x54, 0x8f, 0x86, 0xdf, 0xaf, 0x4c, 0x9c, 0xd2, 0xaf, 0x2c, 0x1f, 0x26, 0x00,
+	0x71, 0xe7, 0x0b, 0xda, 0x93, 0xff, 0x0d, 0x67, 0x1b, 0xe9, 0x9a, 0x26, 0x5e, 0xae, 0x6b, 0x9a,
+	0x8c, 0xd8, 0x35, 0x4d, 0x9d, 0xe9, 0xb4, 0xe9, 0x48, 0x4e, 0x9b, 0x99, 0xd2, 0x69, 0xb3, 0x17,
+	0xd0, 0x35, 0x85, 0x8b, 0xec, 0x9a, 0xe6, 0x4e, 0x70, 0xe1, 0xf2, 0x8e, 0xef, 0x3b, 0xd3, 0xdb,
+	0xd7, 0x03, 0x28, 0xb0, 0xb6, 0xc8, 0xb1, 0xb7, 0x8a, 0x59, 0x48, 0xb0, 0x2a, 0xb5, 0x10, 0x27,
+	0x18, 0x18, 0xe5, 0xdf, 0xc7, 0x7c, 0xc3, 0x0e, 0x8c, 0x28, 0xf2, 0xc6, 0x23, 0x6d, 0xad, 0x58,
+	0x84, 0xb6, 0x56, 0xfc, 0xf4, 0xb6, 0x56, 0xe2, 0x78, 0x5b, 0x6b, 0xa4, 0x0d, 0x95, 0x8c, 0xd6,
+	0x86, 0x4a, 0x45, 0x6b, 0x43, 0xa5, 0xcf, 0x6c, 0x43, 0xd1, 0xf4, 0x85, 0xfd, 0x6f, 0xeb, 0xeb,
+	0xcd, 0x2a, 0xca, 0x2e, 0x88, 0x95, 0x8e, 0x4b, 0x76, 0xbc, 0xd8, 0xe3, 0x4c, 0x63, 0x17, 0xa3,
+	0x91, 0x23, 0x76, 0x76, 0xe4, 0x28, 0xff, 0x21, 0xe6, 0x67, 0x1b, 0xfc, 0x16, 0x23, 0xef, 0xfa,
+	0x32, 0x05, 0x95, 0xdf, 0xfb, 0x8a, 0x4f, 0xea, 0x7d, 0x25, 0x22, 0xf6, 0xbe, 0x92, 0x53, 0xf4,
+	0xbe, 0x52, 0x53, 0xf7, 0xbe, 0x7e, 0x27, 0xc0, 0xec, 0x93, 0xbe, 0x11, 0xe8, 0xae, 0x49, 0x0f,
+	0xf5, 0x75, 0x7a, 0x55, 0x05, 0xb2, 0x16, 0xde, 0xd5, 0xa2, 0xf7, 0x1a, 0x33, 0x16, 0xde, 0x65,
+	0xd2, 0x95, 0xb7, 0xe1, 0x1a, 0x17, 0x79, 0xa4, 0x86, 0x8e, 0x2c, 0xf4, 0x32, 0x24, 0xfb, 0x34,
+	0xeb, 0xf3, 0x52, 0x36, 0x29, 0xdc, 0x6e, 0x0b, 0x2f, 0xac, 0x72, 0x58, 0xf9, 0x19, 0x40, 0x1d,
+	0xf7, 0xdd, 0xe7, 0x1f, 0x6d, 0xe3, 0xc1, 0xde, 0x74, 0x59, 0xe2, 0x35, 0x48, 0x99, 0x78, 0x07,
+	0x9b, 0x0e, 0xdb, 0x33, 0xa9, 0x7a, 0xa3, 0xf2, 0xf7, 0x21, 0xc7, 0xc3, 0xc6, 0x4b, 0xac, 0xfd,
+	0xff, 0x10, 0xb7, 0xec, 0x5d, 0x29, 0x16, 0x25, 0x7d, 0xa3, 0x1c, 0xe5, 0x1f, 0x09, 0x00, 0xec,
+	0x3d, 0x5c, 0xa5, 0xc2, 0x0c, 0x9f, 0x3c, 0xe1, 0x8c, 0x27, 0xef, 0x0e, 0xe4, 0x78, 0x43, 0x7e,
+	0x52, 0xe2, 0x0b, 0x8c, 0xcc, 0xfb, 0xe0, 0x0b, 0xfe, 0x6f, 0x34, 0x78, 0x5f, 0x21, 0xce, 0x5b,
+	0x6e, 0x6c, 0x8a, 0xf7, 0x44, 0x7e, 0x25, 0x40, 0x31, 0x38, 0x1b, 0xd3, 0xf3, 0x74, 0x6a, 0xb8,
+	0x0d, 0x09, 0xdd, 0xd9, 0xa2, 0x0a, 0x3e, 0x9e, 0xf9, 0x0f, 0xcf, 0xa8, 0x32, 0x08, 0x85, 0x6e,
+	0x10, 0xe3, 0xa4, 0x22, 0x21, 0x0c, 0xa5, 0x90, 0xf2, 0x8f, 0x63, 0x90, 0xe3, 0x3d, 0xd8, 0x97,
+	0xb8, 0xa1, 0x70, 0x8b, 0x37, 0x16, 0xad, 0xc5, 0x4b, 0x2c, 0xaf, 0x70, 0x88, 0xd0, 0xe2, 0xa5,
+	0x3c, 0x94, 0x79, 0xdb, 0x72, 0x89, 0x19, 0xad, 0x7f, 0xc8, 0x79, 0xe8, 0xaf, 0x8e, 0x4c, 0xd2,
+	0x23, 0xbc, 0xb9, 0x9c, 0x54, 0xf9, 0xa0, 0xfc, 0x1b, 0x01, 0x2e, 0xb3, 0xcc, 0x61, 0xc0, 0x13,
+	0x67, 0xae, 0x91, 0x61, 0xc6, 0x28, 0x4c, 0x91, 0x31, 0xde, 0xf1, 0xff, 0xc7, 0x1e, 0x3b, 0xed,
+	0xc7, 0x3b, 0x1c, 0x43, 0xc5, 0xd2, 0xbb, 0xae, 0x1f, 0x3e, 0x54, 0x3e, 0x18, 0x0a, 0x9b, 0x08,
+	0x0b, 0xfb, 0x03, 0x5f, 0x56, 0xf6, 0xf7, 0x42, 0x64, 0x0d, 0xb6, 0x8f, 0x9d, 0xb8, 0x7d, 0x3c,
+	0xb4, 0xfd, 0xd2, 0x2f, 0x05, 0x80, 0xe1, 0x01, 0xd0, 0x0d, 0xb8, 0xd2, 0x54, 0xeb, 0xb2, 0xaa,
+	0xb5, 0xda, 0x95, 0xb6, 0xac, 0x29, 0x8d, 0xa7, 0x95, 0x55, 0xa5, 0x2e, 0x5e, 0x9a, 0xcb, 0xed,
+	0x1f, 0x94, 0xd2, 0x8a, 0xb5, 0xa3, 0x9b, 0xc4, 0x40, 0xf3, 0x20, 0x86, 0x51, 0xcd, 0x75, 0xb9,
+	0x21, 0x0a, 0x73, 0x99, 0xfd, 0x83, 0x52, 0xa2, 0x49, 0xfb, 0xf1, 0xc7, 0xe8, 0xf5, 0x66, 0x43,
+	0x16, 0x63, 0x9c, 0x5e, 0xb7, 0x2d, 0x8c, 0xca, 0x80, 0xc2, 0xf4, 0x5a, 0xa5, 0x51, 0x93, 0x57,
+	0xc5, 0xf8, 0x1c, 0xec, 0x1f, 0x94, 0x52, 0x3c, 0x73, 0x5b, 0x6a, 0x41, 0x82, 0x96, 0x0c, 0xe8,
+	0x35, 0xc8, 0xb7, 0x94, 0xfa, 0x44, 0x51, 0xae, 0x42, 0x86, 0x91, 0x2b, 0xad, 0xc7, 0xa2, 0x30,
+	0x97, 0xde, 0x3f, 0x28, 0xc5, 0x69, 0xdb, 0xd7, 0x9f, 0xae, 0x2a, 0x75, 0x31, 0xc6, 0xa7, 0xab,
+	0xc4, 0x58, 0x6a, 0x7a, 0xff, 0x85, 0x64, 0xc9, 0xed, 0x82, 0x2f, 0x65, 0xfb, 0xd9, 0xba, 0xac,
+	0xad, 0x2a, 0x6b, 0x4a, 0x5b, 0xbc, 0x34, 0x97, 0xdd, 0x3f, 0x28, 0x25, 0x57, 0xa9, 0x6e, 0xd0,
+	0xeb, 0x70, 0x39, 0x04, 0x58, 0xab, 0xa8, 0x8f, 0xe5, 0xb6, 0x28, 0x70, 0x29, 0xf9, 0xc3, 0xb1,
+	0xf4, 0x13, 0x81, 0x06, 0xc6, 0x61, 0xd2, 0x7b, 0x1b, 0x2e, 0xb7, 0x95, 0x35, 0x2a, 0xad, 0xb6,
+	0xd2, 0x54, 0x6b, 0xb2, 0xf6, 0xb0, 0x5d, 0x13, 0x2f, 0xcd, 0xa1, 0xfd, 0x83, 0x52, 0xf1, 0xa1,
+	0x6d, 0x1b, 0x6d, 0x62, 0x9a, 0xfc, 0x80, 0xe8, 0xad, 0xe3, 0x50, 0xa5, 0x59, 0x13, 0x85, 0xb9,
+	0xab, 0xfb, 0x07, 0xa5, 0xcb, 0x4a, 0xaf, 0x87, 0x0d, 0xc2, 0xf2, 0x48, 0x0f, 0x7d, 0xf3, 0x38,
+	0x7a, 0xa5, 0xf9, 0x58, 0x8c, 0xcd, 0x15, 0xf7, 0x0f, 0x4a, 0xb0, 0x42, 0x68, 0x89, 0xf8, 0x98,
+	0x98, 0xe6, 0xd2, 0x3f, 0x05, 0xb8, 0x72, 0x42, 0x36, 0x8d, 0xde, 0x87, 0x37, 0x5a, 0xf2, 0xea,
+	0x8a, 0xd6, 0x56, 0x2b, 0x75, 0x59, 0x5b, 0x57, 0xe5, 0xa7, 0x72, 0xa3, 0xad, 0x34, 0x1b, 0x9e,
+	0xee, 0xb5, 0x86, 0xfc, 0xb1, 0xdc, 0xa2, 0xc7, 0x17, 0xf7, 0x0f, 0x4a, 0x79, 0xbe, 0x67, 0x03,
+	0xef, 0x62, 0xc7, 0x3d, 0x93, 0xb5, 0xb9, 0x5a, 0xa7, 0xac, 0x42, 0x98, 0xb5, 0x69, 0x1a, 0x94,
+	0xf5, 0x5d, 0x78, 0xfd, 0x54, 0xd6, 0x6a, 0xb3, 0xfd, 0xc8, 0x3f, 0x04, 0x67, 0xac, 0xda, 0xee,
+	0x73, 0x74, 0x1f, 0x16, 0x4e, 0x66, 0xab, 0xcb, 0x35, 0x55, 0x5e, 0x93, 0x1b, 0x6d, 0x31, 0x3e,
+	0x57, 0xd8, 0x3f, 0x28, 0x65, 0xeb, 0xb8, 0x33, 0xc0, 0x3d, 0x6c, 0xb9, 0x4b, 0x3b, 0x90, 0xf1,
+	0xab, 0x68, 0x74, 0x03, 0xd0, 0x7a, 0xb3, 0xd5, 0xd6, 0x9a, 0x8d, 0xd5, 0x67, 0x5a, 0x5d, 0x69,
+	0x55, 0xaa, 0xab, 0x32, 0x35, 0x9c, 0xfc, 0xfe, 0x41, 0x29, 0x53, 0x27, 0x8e, 0xbe, 0x61, 0x62,
+	0xda, 0x10, 0x11, 0x87, 0x28, 0x55, 0xfe, 0x8e, 0x5c, 0x0b, 0x2e, 0x57, 0xc5, 0xdf, 0xc3, 0x1d,
+	0x17, 0x95, 0xe1, 0x72, 0x18, 0xb1, 0xae, 0x2a, 0x35, 0x6a, 0xc7, 0xcc, 0xfe, 0x54, 0xcc, 0xde,
+	0x98, 0xa5, 0x3f, 0x09, 0x90, 0x0f, 0xff, 0xa6, 0x09, 0x95, 0x00, 0x79, 0xa7, 0x53, 0xe5, 0x4a,
+	0xab, 0xd9, 0xd0, 0x1a, 0xd4, 0xfa, 0x2f, 0x71, 0xeb, 0x6f, 0x50, 0xeb, 0xbf, 0x01, 0xb3, 0xa3,
+	0x08, 0x76, 0x4e, 0xd5, 0xdf, 0x9c, 0x47, 0x03, 0x74, 0x0b, 0xae, 0x8e, 0xa2, 0xe4, 0xef, 0xae,
+	0x2b, 0xaa, 0x5c, 0xf7, 0x05, 0xe0, 0xd9, 0xb2, 0x81, 0x16, 0xe1, 0xda, 0x28, 0xee, 0x49, 0x63,
+	0x45, 0x59, 0xa5, 0x07, 0x8e, 0xf3, 0x03, 0x3f, 0xb1, 0xba, 0xc4, 0xa4, 0x07, 0xbe, 0x03, 0xd2,
+	0x28, 0x72, 0xa8, 0x64, 0x31, 0xc1, 0xf5, 0x19, 0x98, 0xce, 0xd2, 0x4f, 0x05, 0x10, 0x8f, 0x57,
+	0xb4, 0x68, 0x09, 0x5e, 0x69, 0xab, 0xca, 0xc3, 0x87, 0xb2, 0xaa, 0xd5, 0x15, 0x55, 0xae, 0xb1,
+	0x4b, 0x99, 0xe0, 0x98, 0xb7, 0xe0, 0xfa, 0x38, 0xb6, 0x52, 0x6d, 0x3e, 0x95, 0x45, 0x81, 0x3b,
+	0x59, 0x65, 0xc3, 0xde, 0xc1, 0x27, 0xe3, 0xaa, 0xf2, 0x6a, 0xf3, 0x63, 0x31, 0xc6, 0x71, 0x55,
+	0x6c, 0xda, 0xbb, 0x4b, 0x7f, 0x17, 0xa0, 0x38, 0xfa, 0x6b, 0x26, 0x74, 0x1b, 0xa4, 0x56, 0xbb,
+	0xb9, 0xae, 0x9d, 0x23, 0x62, 0x9d, 0x04, 0x5d, 0x97, 0x1b, 0x75, 0xa5, 0xf1, 0x50, 0x14, 0x38,
+	0x74, 0x1d, 0x5b, 0x06, 0xb1, 0x36, 0xd1, 0x5d, 0x98, 0x1b, 0x83, 0x7a, 0x12, 0x32, 0xed, 0x33,
+	0x45, 0x79, 0xaa, 0xc1, 0xb4, 0x4f, 0x79, 0x7d, 0x0c, 0x7e, 0x52, 0x40, 0x3b, 0x11, 0xb8, 0x52,
+	0x51, 0xe8, 0x4d, 0x25, 0x38, 0x70, 0x45, 0x27, 0x26, 0x36, 0x96, 0x7e, 0x2d, 0x40, 0x71, 0xf4,
+	0xad, 0x45, 0x8b, 0x70, 0xbd, 0x56, 0x69, 0xd4, 0x57, 0xe9, 0xf9, 0xda, 0xb2, 0xfa, 0xb4, 0xb2,
+	0x3a, 0x59, 0xed, 0xd7, 0x8e, 0x23, 0xd7, 0x94, 0xc6, 0x93, 0xb6, 0x1c, 0x04, 0x2e, 0x62, 0x6d,
+	0xbb, 0x34, 0x04, 0xcf, 0x1e, 0xc7, 0x3d, 0x6a, 0x3e, 0x51, 0xfd, 0x30, 0xfd, 0xc8, 0xde, 0x1e,
+	0xa0, 0x12, 0x5c, 0x39, 0x8e, 0xa9, 0x57, 0x9e, 0x89, 0x71, 0x1e, 0x4f, 0xeb, 0xfa, 0x5e, 0x55,
+	0xfa, 0xec, 0x68, 0x5e, 0xf8, 0xfc, 0x68, 0x5e, 0xf8, 0xdb, 0xd1, 0xbc, 0xf0, 0xf3, 0x2f, 0xe7,
+	0x2f, 0x7d, 0xfe, 0xe5, 0xfc, 0xa5, 0x2f, 0xbe, 0x9c, 0xbf, 0xb4, 0x91, 0x62, 0x3f, 0x10, 0x7e,
+	0xf0, 0xef, 0x01, 0x00, 0x32, 0x1e, 0x68, 0xa6, 0x7b, 0x2c, 0x00, 0x00,
+}
+
+func (m *Amount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Amount) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Whole != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Whole))
+	}
+	if m.Fractional != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Fractional))
+	}
+	return i, nil
+}
+
+func (m *Order) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Order) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n1, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Side != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Side))
+	}
+	if m.OrderState != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderState))
+	}
+	if m.OriginalOffer != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OriginalOffer.Size()))
+		n2, err := m.OriginalOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.RemainingOffer != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.RemainingOffer.Size()))
+		n3, err := m.RemainingOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n4, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if len(m.TradeIds) > 0 {
+		for _, b := range m.TradeIds {
+			dAtA[i] = 0x52
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.UpdatedAt))
+	}
+	if m.OrderType != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ExpiresAt))
+	}
+	if len(m.ExpirationTaskID) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ExpirationTaskID)))
+		i += copy(dAtA[i:], m.ExpirationTaskID)
+	}
+	if m.DiscountedFee {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.DiscountedFee {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SelfTradePrevention != 0 {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SelfTradePrevention))
+	}
+	if m.CancelReason != 0 {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.CancelReason))
+	}
+	if m.PostOnly != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.PostOnly))
+	}
+	if m.Sequence != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Sequence))
+	}
+	return i, nil
+}
+
+func (m *StopOrder) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StopOrder) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n5, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Side != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Side))
+	}
+	if m.State != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.State))
+	}
+	if m.Direction != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Direction))
+	}
+	if m.TriggerPrice != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TriggerPrice.Size()))
+		n6, err := m.TriggerPrice.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.Offer != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Offer.Size()))
+		n7, err := m.Offer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n8, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.OrderType != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TimeInForce))
+	}
+	if m.SelfTradePrevention != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SelfTradePrevention))
+	}
+	if m.DiscountedFee {
+		dAtA[i] = 0x70
+		i++
+		if m.DiscountedFee {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.UpdatedAt))
+	}
+	if len(m.OrderID) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
+		i += copy(dAtA[i:], m.OrderID)
+	}
+	return i, nil
+}
+
+func (m *Trade) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Trade) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n9, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if len(m.OrderID) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
+		i += copy(dAtA[i:], m.OrderID)
+	}
+	if len(m.Taker) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Taker)))
+		i += copy(dAtA[i:], m.Taker)
+	}
+	if len(m.Maker) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Maker)))
+		i += copy(dAtA[i:], m.Maker)
+	}
+	if m.MakerPaid != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MakerPaid.Size()))
+		n10, err := m.MakerPaid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.TakerPaid != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TakerPaid.Size()))
+		n11, err := m.TakerPaid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.ExecutedAt != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ExecutedAt))
+	}
+	if m.MakerFee != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MakerFee.Size()))
+		n12, err := m.MakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.TakerFee != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TakerFee.Size()))
+		n13, err := m.TakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+
+func (m *OrderBook) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderBook) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n14, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.MarketID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.MarketID)))
+		i += copy(dAtA[i:], m.MarketID)
+	}
+	if len(m.AskTicker) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.AskTicker)))
+		i += copy(dAtA[i:], m.AskTicker)
+	}
+	if len(m.BidTicker) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.BidTicker)))
+		i += copy(dAtA[i:], m.BidTicker)
+	}
+	if m.TotalAskCount != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TotalAskCount))
+	}
+	if m.TotalBidCount != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TotalBidCount))
+	}
+	if m.TickSize != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TickSize.Size()))
+		n15, err := m.TickSize.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.LotSize != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.LotSize.Size()))
+		n16, err := m.LotSize.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.MinOffer != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MinOffer.Size()))
+		n17, err := m.MinOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if len(m.StopTaskID) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.StopTaskID)))
+		i += copy(dAtA[i:], m.StopTaskID)
+	}
+	if m.OrderSequence != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderSequence))
+	}
+	return i, nil
+}
+
+func (m *Market) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Market) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n18, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.MakerFee != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MakerFee.Size()))
+		n19, err := m.MakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.TakerFee != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TakerFee.Size()))
+		n20, err := m.TakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if len(m.FeeCollector) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.FeeCollector)))
+		i += copy(dAtA[i:], m.FeeCollector)
+	}
+	return i, nil
+}
+
+func (m *Candle) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Candle) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n21, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Interval))
+	}
+	if m.Start != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Start))
+	}
+	if m.Open != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Open.Size()))
+		n22, err := m.Open.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if m.High != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.High.Size()))
+		n23, err := m.High.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.Low != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Low.Size()))
+		n24, err := m.Low.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.Close != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Close.Size()))
+		n25, err := m.Close.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if m.BaseVolume != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.BaseVolume.Size()))
+		n26, err := m.BaseVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	if m.QuoteVolume != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.QuoteVolume.Size()))
+		n27, err := m.QuoteVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if m.TradeCount != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TradeCount))
+	}
+	return i, nil
+}
+
+func (m *Ticker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Ticker) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n28, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if m.LastPrice != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.LastPrice.Size()))
+		n29, err := m.LastPrice.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
+	}
+	if m.LastTradeAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.LastTradeAt))
+	}
+	if m.BestBid != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.BestBid.Size()))
+		n30, err := m.BestBid.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
+	}
+	if m.BestAsk != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.BestAsk.Size()))
+		n31, err := m.BestAsk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
+	}
+	if m.WindowStart != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.WindowStart))
+	}
+	if m.DayHigh != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DayHigh.Size()))
+		n32, err := m.DayHigh.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	if m.DayLow != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DayLow.Size()))
+		n33, err := m.DayLow.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
+	}
+	if m.DayBaseVolume != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DayBaseVolume.Size()))
+		n34, err := m.DayBaseVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	if m.DayQuoteVolume != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DayQuoteVolume.Size()))
+		n35, err := m.DayQuoteVolume.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
+	}
+	if m.DayTradeCount != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.DayTradeCount))
+	}
+	return i, nil
+}
+
+func (m *Configuration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Configuration) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n36, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	if len(m.FeeTicker) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.FeeTicker)))
+		i += copy(dAtA[i:], m.FeeTicker)
+	}
+	if m.FeeDiscount != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.FeeDiscount.Size()))
+		n37, err := m.FeeDiscount.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
+	}
+	if len(m.FeePrices) > 0 {
+		for _, msg := range m.FeePrices {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *FeePrice) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeePrice) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ticker) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Ticker)))
+		i += copy(dAtA[i:], m.Ticker)
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n38, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
+	}
+	return i, nil
+}
+
+func (m *CreateOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n39, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Offer != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Offer.Size()))
+		n40, err := m.Offer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n41, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
+	}
+	if m.OrderType != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ExpiresAt))
+	}
+	if m.DiscountedFee {
+		dAtA[i] = 0x48
+		i++
+		if m.DiscountedFee {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SelfTradePrevention != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SelfTradePrevention))
+	}
+	if m.PostOnly != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.PostOnly))
+	}
+	return i, nil
+}
+
+func (m *CancelOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n42, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	if len(m.OrderID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
+		i += copy(dAtA[i:], m.OrderID)
+	}
+	return i, nil
+}
+
+func (m *ReplaceOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplaceOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n43, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	if len(m.OrderID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
+		i += copy(dAtA[i:], m.OrderID)
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n44, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	if m.RemainingOffer != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.RemainingOffer.Size()))
+		n45, err := m.RemainingOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
+	}
+	return i, nil
+}
+
+func (m *CreateOrdersMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateOrdersMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n46, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.Orders) > 0 {
+		for _, msg := range m.Orders {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *BatchOrder) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchOrder) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Offer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Offer.Size()))
+		n47, err := m.Offer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n48, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n48
+	}
+	if m.OrderType != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.ExpiresAt))
+	}
+	if m.DiscountedFee {
+		dAtA[i] = 0x38
+		i++
+		if m.DiscountedFee {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SelfTradePrevention != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SelfTradePrevention))
+	}
+	if m.PostOnly != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.PostOnly))
+	}
+	return i, nil
+}
+
+func (m *CancelAllOrdersMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelAllOrdersMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n49, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Side != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Side))
+	}
+	return i, nil
+}
+
+func (m *CreateStopOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateStopOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n50, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Direction != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Direction))
+	}
+	if m.TriggerPrice != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TriggerPrice.Size()))
+		n51, err := m.TriggerPrice.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.Offer != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Offer.Size()))
+		n52, err := m.Offer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	if m.Price != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n53, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.OrderType != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TimeInForce))
+	}
+	if m.SelfTradePrevention != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.SelfTradePrevention))
+	}
+	if m.DiscountedFee {
+		dAtA[i] = 0x58
+		i++
+		if m.DiscountedFee {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *CancelStopOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelStopOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n54, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if len(m.StopOrderID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.StopOrderID)))
+		i += copy(dAtA[i:], m.StopOrderID)
+	}
+	return i, nil
+}
+
+func (m *CreateOrderBookMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateOrderBookMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n55, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if len(m.MarketID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.MarketID)))
+		i += copy(dAtA[i:], m.MarketID)
+	}
+	if len(m.AskTicker) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.AskTicker)))
+		i += copy(dAtA[i:], m.AskTicker)
+	}
+	if len(m.BidTicker) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.BidTicker)))
+		i += copy(dAtA[i:], m.BidTicker)
+	}
+	if m.TickSize != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TickSize.Size()))
+		n56, err := m.TickSize.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	if m.LotSize != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.LotSize.Size()))
+		n57, err := m.LotSize.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	if m.MinOffer != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MinOffer.Size()))
+		n58, err := m.MinOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	return i, nil
+}
+
+func (m *ExpireOrderMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExpireOrderMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n59, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n59
+	}
+	if len(m.OrderID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderID)))
+		i += copy(dAtA[i:], m.OrderID)
+	}
+	return i, nil
+}
+
+func (m *ActivateStopsMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivateStopsMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n60, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
+	}
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	return i, nil
+}
+
+func (m *CreateMarketMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateMarketMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n61, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n61
+	}
+	if len(m.Owner) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Owner)))
+		i += copy(dAtA[i:], m.Owner)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.MakerFee != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.MakerFee.Size()))
+		n62, err := m.MakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n62
+	}
+	if m.TakerFee != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TakerFee.Size()))
+		n63, err := m.TakerFee.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n63
+	}
+	if len(m.FeeCollector) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.FeeCollector)))
+		i += copy(dAtA[i:], m.FeeCollector)
+	}
+	return i, nil
+}
+
+func (m *UpdateMarketOwnerMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateMarketOwnerMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n64, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n64
+	}
+	if len(m.MarketID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.MarketID)))
+		i += copy(dAtA[i:], m.MarketID)
+	}
+	if len(m.NewOwner) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.NewOwner)))
+		i += copy(dAtA[i:], m.NewOwner)
+	}
+	return i, nil
+}
+
+func (m *UpdateConfigurationMsg) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateConfigurationMsg) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Metadata.Size()))
+		n65, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n65
+	}
+	if m.Patch != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Patch.Size()))
+		n66, err := m.Patch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n66
+	}
+	return i, nil
+}
+
+func (m *DepthQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DepthQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Levels != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Levels))
+	}
+	return i, nil
+}
+
+func (m *TickerQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TickerQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Now != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Now))
+	}
+	return i, nil
+}
+
+func (m *PriceLevel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PriceLevel) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Price != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Price.Size()))
+		n67, err := m.Price.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n67
+	}
+	if m.TotalOffer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.TotalOffer.Size()))
+		n68, err := m.TotalOffer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n68
+	}
+	if m.OrderCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.OrderCount))
+	}
+	return i, nil
+}
+
+func (m *OrderBookDepth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderBookDepth) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if len(m.Asks) > 0 {
+		for _, msg := range m.Asks {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Bids) > 0 {
+		for _, msg := range m.Bids {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintCodec(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *CandleQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CandleQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.OrderBookID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.OrderBookID)))
+		i += copy(dAtA[i:], m.OrderBookID)
+	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Interval))
+	}
+	if m.Since != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Since))
+	}
+	if m.Until != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Until))
+	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Limit))
+	}
+	return i, nil
+}
+
+func (m *TraderOrdersQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraderOrdersQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if m.State != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.State))
+	}
+	if len(m.After) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.After)))
+		i += copy(dAtA[i:], m.After)
+	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Limit))
+	}
+	return i, nil
+}
+
+func (m *TraderTradesQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraderTradesQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Trader) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.Trader)))
+		i += copy(dAtA[i:], m.Trader)
+	}
+	if len(m.After) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(len(m.After)))
+		i += copy(dAtA[i:], m.After)
+	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCodec(dAtA, i, uint64(m.Limit))
+	}
+	return i, nil
+}
+
+func encodeVarintCodec(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Amount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Whole != 0 {
+		n += 1 + sovCodec(uint64(m.Whole))
+	}
+	if m.Fractional != 0 {
+		n += 1 + sovCodec(uint64(m.Fractional))
+	}
+	return n
+}
+
+func (m *Order) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Side != 0 {
+		n += 1 + sovCodec(uint64(m.Side))
+	}
+	if m.OrderState != 0 {
+		n += 1 + sovCodec(uint64(m.OrderState))
+	}
+	if m.OriginalOffer != nil {
+		l = m.OriginalOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.RemainingOffer != nil {
+		l = m.RemainingOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if len(m.TradeIds) > 0 {
+		for _, b := range m.TradeIds {
+			l = len(b)
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovCodec(uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		n += 1 + sovCodec(uint64(m.UpdatedAt))
+	}
+	if m.OrderType != 0 {
+		n += 1 + sovCodec(uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		n += 1 + sovCodec(uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovCodec(uint64(m.ExpiresAt))
+	}
+	l = len(m.ExpirationTaskID)
+	if l > 0 {
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	if m.DiscountedFee {
+		n += 3
+	}
+	if m.SelfTradePrevention != 0 {
+		n += 2 + sovCodec(uint64(m.SelfTradePrevention))
+	}
+	if m.CancelReason != 0 {
+		n += 2 + sovCodec(uint64(m.CancelReason))
+	}
+	if m.PostOnly != 0 {
+		n += 2 + sovCodec(uint64(m.PostOnly))
+	}
+	if m.Sequence != 0 {
+		n += 2 + sovCodec(uint64(m.Sequence))
+	}
+	return n
+}
+
+func (m *StopOrder) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Side != 0 {
+		n += 1 + sovCodec(uint64(m.Side))
+	}
+	if m.State != 0 {
+		n += 1 + sovCodec(uint64(m.State))
+	}
+	if m.Direction != 0 {
+		n += 1 + sovCodec(uint64(m.Direction))
+	}
+	if m.TriggerPrice != nil {
+		l = m.TriggerPrice.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Offer != nil {
+		l = m.Offer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderType != 0 {
+		n += 1 + sovCodec(uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		n += 1 + sovCodec(uint64(m.TimeInForce))
+	}
+	if m.SelfTradePrevention != 0 {
+		n += 1 + sovCodec(uint64(m.SelfTradePrevention))
+	}
+	if m.DiscountedFee {
+		n += 2
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovCodec(uint64(m.CreatedAt))
+	}
+	if m.UpdatedAt != 0 {
+		n += 2 + sovCodec(uint64(m.UpdatedAt))
+	}
+	l = len(m.OrderID)
+	if l > 0 {
+		n += 2 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *Trade) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Taker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Maker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MakerPaid != nil {
+		l = m.MakerPaid.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TakerPaid != nil {
+		l = m.TakerPaid.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.ExecutedAt != 0 {
+		n += 1 + sovCodec(uint64(m.ExecutedAt))
+	}
+	if m.MakerFee != nil {
+		l = m.MakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TakerFee != nil {
+		l = m.TakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *OrderBook) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.MarketID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.AskTicker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.BidTicker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TotalAskCount != 0 {
+		n += 1 + sovCodec(uint64(m.TotalAskCount))
+	}
+	if m.TotalBidCount != 0 {
+		n += 1 + sovCodec(uint64(m.TotalBidCount))
+	}
+	if m.TickSize != nil {
+		l = m.TickSize.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.LotSize != nil {
+		l = m.LotSize.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MinOffer != nil {
+		l = m.MinOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.StopTaskID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderSequence != 0 {
+		n += 1 + sovCodec(uint64(m.OrderSequence))
+	}
+	return n
+}
+
+func (m *Market) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MakerFee != nil {
+		l = m.MakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TakerFee != nil {
+		l = m.TakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.FeeCollector)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *Candle) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovCodec(uint64(m.Interval))
+	}
+	if m.Start != 0 {
+		n += 1 + sovCodec(uint64(m.Start))
+	}
+	if m.Open != nil {
+		l = m.Open.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.High != nil {
+		l = m.High.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Low != nil {
+		l = m.Low.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Close != nil {
+		l = m.Close.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.BaseVolume != nil {
+		l = m.BaseVolume.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.QuoteVolume != nil {
+		l = m.QuoteVolume.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TradeCount != 0 {
+		n += 1 + sovCodec(uint64(m.TradeCount))
+	}
+	return n
+}
+
+func (m *Ticker) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.LastPrice != nil {
+		l = m.LastPrice.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.LastTradeAt != 0 {
+		n += 1 + sovCodec(uint64(m.LastTradeAt))
+	}
+	if m.BestBid != nil {
+		l = m.BestBid.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.BestAsk != nil {
+		l = m.BestAsk.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.WindowStart != 0 {
+		n += 1 + sovCodec(uint64(m.WindowStart))
+	}
+	if m.DayHigh != nil {
+		l = m.DayHigh.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.DayLow != nil {
+		l = m.DayLow.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.DayBaseVolume != nil {
+		l = m.DayBaseVolume.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.DayQuoteVolume != nil {
+		l = m.DayQuoteVolume.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.DayTradeCount != 0 {
+		n += 1 + sovCodec(uint64(m.DayTradeCount))
+	}
+	return n
+}
+
+func (m *Configuration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.FeeTicker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.FeeDiscount != nil {
+		l = m.FeeDiscount.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if len(m.FeePrices) > 0 {
+		for _, e := range m.FeePrices {
+			l = e.Size()
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FeePrice) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Ticker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Offer != nil {
+		l = m.Offer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderType != 0 {
+		n += 1 + sovCodec(uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		n += 1 + sovCodec(uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovCodec(uint64(m.ExpiresAt))
+	}
+	if m.DiscountedFee {
+		n += 2
+	}
+	if m.SelfTradePrevention != 0 {
+		n += 1 + sovCodec(uint64(m.SelfTradePrevention))
+	}
+	if m.PostOnly != 0 {
+		n += 1 + sovCodec(uint64(m.PostOnly))
+	}
+	return n
+}
+
+func (m *CancelOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *ReplaceOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.RemainingOffer != nil {
+		l = m.RemainingOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateOrdersMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if len(m.Orders) > 0 {
+		for _, e := range m.Orders {
+			l = e.Size()
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BatchOrder) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Offer != nil {
+		l = m.Offer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderType != 0 {
+		n += 1 + sovCodec(uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		n += 1 + sovCodec(uint64(m.TimeInForce))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovCodec(uint64(m.ExpiresAt))
+	}
+	if m.DiscountedFee {
+		n += 2
+	}
+	if m.SelfTradePrevention != 0 {
+		n += 1 + sovCodec(uint64(m.SelfTradePrevention))
+	}
+	if m.PostOnly != 0 {
+		n += 1 + sovCodec(uint64(m.PostOnly))
+	}
+	return n
+}
+
+func (m *CancelAllOrdersMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Side != 0 {
+		n += 1 + sovCodec(uint64(m.Side))
+	}
+	return n
+}
+
+func (m *CreateStopOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Direction != 0 {
+		n += 1 + sovCodec(uint64(m.Direction))
+	}
+	if m.TriggerPrice != nil {
+		l = m.TriggerPrice.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Offer != nil {
+		l = m.Offer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderType != 0 {
+		n += 1 + sovCodec(uint64(m.OrderType))
+	}
+	if m.TimeInForce != 0 {
+		n += 1 + sovCodec(uint64(m.TimeInForce))
+	}
+	if m.SelfTradePrevention != 0 {
+		n += 1 + sovCodec(uint64(m.SelfTradePrevention))
+	}
+	if m.DiscountedFee {
+		n += 2
+	}
+	return n
+}
+
+func (m *CancelStopOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.StopOrderID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateOrderBookMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.MarketID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.AskTicker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.BidTicker)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TickSize != nil {
+		l = m.TickSize.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.LotSize != nil {
+		l = m.LotSize.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MinOffer != nil {
+		l = m.MinOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *ExpireOrderMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivateStopsMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateMarketMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.MakerFee != nil {
+		l = m.MakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TakerFee != nil {
+		l = m.TakerFee.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.FeeCollector)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateMarketOwnerMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.MarketID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.NewOwner)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateConfigurationMsg) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Patch != nil {
+		l = m.Patch.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	return n
+}
+
+func (m *DepthQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Levels != 0 {
+		n += 1 + sovCodec(uint64(m.Levels))
+	}
+	return n
+}
+
+func (m *TickerQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Now != 0 {
+		n += 1 + sovCodec(uint64(m.Now))
+	}
+	return n
+}
+
+func (m *PriceLevel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Price != nil {
+		l = m.Price.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.TotalOffer != nil {
+		l = m.TotalOffer.Size()
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.OrderCount != 0 {
+		n += 1 + sovCodec(uint64(m.OrderCount))
+	}
+	return n
+}
+
+func (m *OrderBookDepth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if len(m.Asks) > 0 {
+		for _, e := range m.Asks {
+			l = e.Size()
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	if len(m.Bids) > 0 {
+		for _, e := range m.Bids {
+			l = e.Size()
+			n += 1 + l + sovCodec(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CandleQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OrderBookID)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovCodec(uint64(m.Interval))
+	}
+	if m.Since != 0 {
+		n += 1 + sovCodec(uint64(m.Since))
+	}
+	if m.Until != 0 {
+		n += 1 + sovCodec(uint64(m.Until))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovCodec(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *TraderOrdersQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovCodec(uint64(m.State))
+	}
+	l = len(m.After)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovCodec(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *TraderTradesQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	l = len(m.After)
+	if l > 0 {
+		n += 1 + l + sovCodec(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovCodec(uint64(m.Limit))
+	}
+	return n
+}
+
+func sovCodec(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozCodec(x uint64) (n int) {
+	return sovCodec(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Amount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Amount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Amount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Whole", wireType)
+			}
+			m.Whole = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Whole |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fractional", wireType)
+			}
+			m.Fractional = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fractional |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Order) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Order: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Order: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Side", wireType)
+			}
+			m.Side = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Side |= Side(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderState", wireType)
+			}
+			m.OrderState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderState |= OrderState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalOffer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OriginalOffer == nil {
+				m.OriginalOffer = &coin.Coin{}
+			}
+			if err := m.OriginalOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemainingOffer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RemainingOffer == nil {
+				m.RemainingOffer = &coin.Coin{}
+			}
+			if err := m.RemainingOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TradeIds", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TradeIds = append(m.TradeIds, make([]byte, postIndex-iNdEx))
+			copy(m.TradeIds[len(m.TradeIds)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderType", wireType)
+			}
+			m.OrderType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderType |= OrderType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeInForce", wireType)
+			}
+			m.TimeInForce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeInForce |= TimeInForce(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpirationTaskID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpirationTaskID = append(m.ExpirationTaskID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ExpirationTaskID == nil {
+				m.ExpirationTaskID = []byte{}
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscountedFee", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DiscountedFee = bool(v != 0)
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfTradePrevention", wireType)
+			}
+			m.SelfTradePrevention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelfTradePrevention |= SelfTradePrevention(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelReason", wireType)
+			}
+			m.CancelReason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CancelReason |= CancelReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostOnly", wireType)
+			}
+			m.PostOnly = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PostOnly |= PostOnly(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StopOrder) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StopOrder: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StopOrder: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Side", wireType)
+			}
+			m.Side = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Side |= Side(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= StopOrderState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
+			}
+			m.Direction = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Direction |= TriggerDirection(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggerPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TriggerPrice == nil {
+				m.TriggerPrice = &Amount{}
+			}
+			if err := m.TriggerPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Offer == nil {
+				m.Offer = &coin.Coin{}
+			}
+			if err := m.Offer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderType", wireType)
+			}
+			m.OrderType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderType |= OrderType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeInForce", wireType)
+			}
+			m.TimeInForce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeInForce |= TimeInForce(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfTradePrevention", wireType)
+			}
+			m.SelfTradePrevention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelfTradePrevention |= SelfTradePrevention(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscountedFee", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DiscountedFee = bool(v != 0)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderID == nil {
+				m.OrderID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Trade) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Trade: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Trade: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderID == nil {
+				m.OrderID = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Taker", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Taker = append(m.Taker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Taker == nil {
+				m.Taker = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Maker", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Maker = append(m.Maker[:0], dAtA[iNdEx:postIndex]...)
+			if m.Maker == nil {
+				m.Maker = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MakerPaid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MakerPaid == nil {
+				m.MakerPaid = &coin.Coin{}
+			}
+			if err := m.MakerPaid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerPaid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TakerPaid == nil {
+				m.TakerPaid = &coin.Coin{}
+			}
+			if err := m.TakerPaid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutedAt", wireType)
+			}
+			m.ExecutedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecutedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MakerFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MakerFee == nil {
+				m.MakerFee = &coin.Coin{}
+			}
+			if err := m.MakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TakerFee == nil {
+				m.TakerFee = &coin.Coin{}
+			}
+			if err := m.TakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OrderBook) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderBook: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderBook: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MarketID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MarketID = append(m.MarketID[:0], dAtA[iNdEx:postIndex]...)
+			if m.MarketID == nil {
+				m.MarketID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskTicker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AskTicker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BidTicker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BidTicker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalAskCount", wireType)
+			}
+			m.TotalAskCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalAskCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalBidCount", wireType)
+			}
+			m.TotalBidCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalBidCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickSize", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TickSize == nil {
+				m.TickSize = &Amount{}
+			}
+			if err := m.TickSize.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LotSize", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LotSize == nil {
+				m.LotSize = &Amount{}
+			}
+			if err := m.LotSize.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinOffer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MinOffer == nil {
+				m.MinOffer = &Amount{}
+			}
+			if err := m.MinOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StopTaskID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StopTaskID = append(m.StopTaskID[:0], dAtA[iNdEx:postIndex]...)
+			if m.StopTaskID == nil {
+				m.StopTaskID = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderSequence", wireType)
+			}
+			m.OrderSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderSequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Market) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Market: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Market: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = append(m.Owner[:0], dAtA[iNdEx:postIndex]...)
+			if m.Owner == nil {
+				m.Owner = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MakerFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MakerFee == nil {
+				m.MakerFee = &Amount{}
+			}
+			if err := m.MakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TakerFee == nil {
+				m.TakerFee = &Amount{}
+			}
+			if err := m.TakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeCollector", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeeCollector = append(m.FeeCollector[:0], dAtA[iNdEx:postIndex]...)
+			if m.FeeCollector == nil {
+				m.FeeCollector = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Candle) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Candle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Candle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= CandleInterval(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Open", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Open == nil {
+				m.Open = &Amount{}
+			}
+			if err := m.Open.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field High", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.High == nil {
+				m.High = &Amount{}
+			}
+			if err := m.High.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Low", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Low == nil {
+				m.Low = &Amount{}
+			}
+			if err := m.Low.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Close", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Close == nil {
+				m.Close = &Amount{}
+			}
+			if err := m.Close.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BaseVolume == nil {
+				m.BaseVolume = &coin.Coin{}
+			}
+			if err := m.BaseVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuoteVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QuoteVolume == nil {
+				m.QuoteVolume = &coin.Coin{}
+			}
+			if err := m.QuoteVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TradeCount", wireType)
+			}
+			m.TradeCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TradeCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Ticker) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Ticker: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Ticker: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastPrice == nil {
+				m.LastPrice = &Amount{}
+			}
+			if err := m.LastPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastTradeAt", wireType)
+			}
+			m.LastTradeAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastTradeAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BestBid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BestBid == nil {
+				m.BestBid = &Amount{}
+			}
+			if err := m.BestBid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BestAsk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BestAsk == nil {
+				m.BestAsk = &Amount{}
+			}
+			if err := m.BestAsk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowStart", wireType)
+			}
+			m.WindowStart = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowStart |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DayHigh", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DayHigh == nil {
+				m.DayHigh = &Amount{}
+			}
+			if err := m.DayHigh.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DayLow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DayLow == nil {
+				m.DayLow = &Amount{}
+			}
+			if err := m.DayLow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DayBaseVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DayBaseVolume == nil {
+				m.DayBaseVolume = &coin.Coin{}
+			}
+			if err := m.DayBaseVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DayQuoteVolume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DayQuoteVolume == nil {
+				m.DayQuoteVolume = &coin.Coin{}
+			}
+			if err := m.DayQuoteVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DayTradeCount", wireType)
+			}
+			m.DayTradeCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DayTradeCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Configuration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Configuration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Configuration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = append(m.Owner[:0], dAtA[iNdEx:postIndex]...)
+			if m.Owner == nil {
+				m.Owner = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeTicker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeeTicker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeDiscount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FeeDiscount == nil {
+				m.FeeDiscount = &Amount{}
+			}
+			if err := m.FeeDiscount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeePrices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeePrices = append(m.FeePrices, &FeePrice{})
+			if err := m.FeePrices[len(m.FeePrices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeePrice) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeePrice: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeePrice: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ticker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ticker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateOrderMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Offer == nil {
+				m.Offer = &coin.Coin{}
+			}
+			if err := m.Offer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderType", wireType)
+			}
+			m.OrderType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderType |= OrderType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeInForce", wireType)
+			}
+			m.TimeInForce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeInForce |= TimeInForce(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscountedFee", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DiscountedFee = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfTradePrevention", wireType)
+			}
+			m.SelfTradePrevention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelfTradePrevention |= SelfTradePrevention(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostOnly", wireType)
+			}
+			m.PostOnly = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PostOnly |= PostOnly(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelOrderMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderID == nil {
+				m.OrderID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplaceOrderMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplaceOrderMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplaceOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderID == nil {
+				m.OrderID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemainingOffer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RemainingOffer == nil {
+				m.RemainingOffer = &coin.Coin{}
+			}
+			if err := m.RemainingOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateOrdersMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateOrdersMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateOrdersMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Orders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Orders = append(m.Orders, &BatchOrder{})
+			if err := m.Orders[len(m.Orders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchOrder) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchOrder: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchOrder: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Offer == nil {
+				m.Offer = &coin.Coin{}
+			}
+			if err := m.Offer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderType", wireType)
+			}
+			m.OrderType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderType |= OrderType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeInForce", wireType)
+			}
+			m.TimeInForce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeInForce |= TimeInForce(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscountedFee", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DiscountedFee = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfTradePrevention", wireType)
+			}
+			m.SelfTradePrevention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelfTradePrevention |= SelfTradePrevention(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostOnly", wireType)
+			}
+			m.PostOnly = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PostOnly |= PostOnly(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelAllOrdersMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelAllOrdersMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelAllOrdersMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Side", wireType)
+			}
+			m.Side = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Side |= Side(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateStopOrderMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateStopOrderMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateStopOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
+			}
+			m.Direction = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Direction |= TriggerDirection(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggerPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TriggerPrice == nil {
+				m.TriggerPrice = &Amount{}
+			}
+			if err := m.TriggerPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Offer == nil {
+				m.Offer = &coin.Coin{}
+			}
+			if err := m.Offer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderType", wireType)
+			}
+			m.OrderType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderType |= OrderType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeInForce", wireType)
+			}
+			m.TimeInForce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeInForce |= TimeInForce(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelfTradePrevention", wireType)
+			}
+			m.SelfTradePrevention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SelfTradePrevention |= SelfTradePrevention(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscountedFee", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DiscountedFee = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelStopOrderMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelStopOrderMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelStopOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StopOrderID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StopOrderID = append(m.StopOrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.StopOrderID == nil {
+				m.StopOrderID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Amount) Unmarshal(dAtA []byte) error {
+func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1613,30 +11758,200 @@ func (m *Amount) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowCodec
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateOrderBookMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateOrderBookMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MarketID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MarketID = append(m.MarketID[:0], dAtA[iNdEx:postIndex]...)
+			if m.MarketID == nil {
+				m.MarketID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskTicker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AskTicker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BidTicker", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BidTicker = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TickSize", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.TickSize == nil {
+				m.TickSize = &Amount{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Amount: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Amount: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Whole", wireType)
+			if err := m.TickSize.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Whole = 0
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LotSize", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1646,16 +11961,33 @@ func (m *Amount) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Whole |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fractional", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
 			}
-			m.Fractional = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LotSize == nil {
+				m.LotSize = &Amount{}
+			}
+			if err := m.LotSize.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinOffer", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1665,11 +11997,28 @@ func (m *Amount) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Fractional |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MinOffer == nil {
+				m.MinOffer = &Amount{}
+			}
+			if err := m.MinOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -1694,7 +12043,7 @@ func (m *Amount) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Order) Unmarshal(dAtA []byte) error {
+func (m *ExpireOrderMsg) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1717,10 +12066,10 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Order: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExpireOrderMsg: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Order: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExpireOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1761,7 +12110,7 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1788,16 +12137,69 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderID == nil {
+				m.OrderID = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivateStopsMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivateStopsMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivateStopsMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1807,27 +12209,29 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
-			if m.Trader == nil {
-				m.Trader = []byte{}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
 			}
@@ -1861,11 +12265,64 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 				m.OrderBookID = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Side", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Side = 0
+			if skippy < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateMarketMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateMarketMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateMarketMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1875,16 +12332,33 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Side |= Side(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderState", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthCodec
 			}
-			m.OrderState = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1894,16 +12368,31 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.OrderState |= OrderState(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			if byteLen < 0 {
+				return ErrInvalidLengthCodec
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCodec
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = append(m.Owner[:0], dAtA[iNdEx:postIndex]...)
+			if m.Owner == nil {
+				m.Owner = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalOffer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -1913,31 +12402,27 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OriginalOffer == nil {
-				m.OriginalOffer = &coin.Coin{}
-			}
-			if err := m.OriginalOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemainingOffer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MakerFee", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1964,16 +12449,16 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RemainingOffer == nil {
-				m.RemainingOffer = &coin.Coin{}
+			if m.MakerFee == nil {
+				m.MakerFee = &Amount{}
 			}
-			if err := m.RemainingOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.MakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TakerFee", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2000,16 +12485,16 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Price == nil {
-				m.Price = &Amount{}
+			if m.TakerFee == nil {
+				m.TakerFee = &Amount{}
 			}
-			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.TakerFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TradeIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeCollector", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2036,47 +12521,11 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TradeIds = append(m.TradeIds, make([]byte, postIndex-iNdEx))
-			copy(m.TradeIds[len(m.TradeIds)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
-			}
-			m.CreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CreatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
-			}
-			m.UpdatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UpdatedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.FeeCollector = append(m.FeeCollector[:0], dAtA[iNdEx:postIndex]...)
+			if m.FeeCollector == nil {
+				m.FeeCollector = []byte{}
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -2101,7 +12550,7 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Trade) Unmarshal(dAtA []byte) error {
+func (m *UpdateMarketOwnerMsg) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2124,10 +12573,10 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Trade: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateMarketOwnerMsg: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Trade: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateMarketOwnerMsg: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2168,7 +12617,7 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MarketID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2195,14 +12644,14 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.MarketID = append(m.MarketID[:0], dAtA[iNdEx:postIndex]...)
+			if m.MarketID == nil {
+				m.MarketID = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewOwner", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2229,50 +12678,69 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
-			if m.OrderBookID == nil {
-				m.OrderBookID = []byte{}
+			m.NewOwner = append(m.NewOwner[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewOwner == nil {
+				m.NewOwner = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthCodec
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
-			if m.OrderID == nil {
-				m.OrderID = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateConfigurationMsg) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
 			}
-			iNdEx = postIndex
-		case 5:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateConfigurationMsg: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateConfigurationMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Taker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2282,31 +12750,33 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Taker = append(m.Taker[:0], dAtA[iNdEx:postIndex]...)
-			if m.Taker == nil {
-				m.Taker = []byte{}
+			if m.Metadata == nil {
+				m.Metadata = &weave.Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Maker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Patch", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2316,67 +12786,86 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Maker = append(m.Maker[:0], dAtA[iNdEx:postIndex]...)
-			if m.Maker == nil {
-				m.Maker = []byte{}
+			if m.Patch == nil {
+				m.Patch = &Configuration{}
 			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MakerPaid", wireType)
+			if err := m.Patch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthCodec
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.MakerPaid == nil {
-				m.MakerPaid = &coin.Coin{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DepthQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
 			}
-			if err := m.MakerPaid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DepthQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DepthQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TakerPaid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2386,33 +12875,31 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TakerPaid == nil {
-				m.TakerPaid = &coin.Coin{}
-			}
-			if err := m.TakerPaid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
 			}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecutedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Levels", wireType)
 			}
-			m.ExecutedAt = 0
+			m.Levels = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2422,7 +12909,7 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExecutedAt |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
+				m.Levels |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2451,7 +12938,7 @@ func (m *Trade) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OrderBook) Unmarshal(dAtA []byte) error {
+func (m *TickerQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2474,51 +12961,15 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OrderBook: wiretype end group for non-group")
+			return fmt.Errorf("proto: TickerQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OrderBook: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TickerQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Metadata == nil {
-				m.Metadata = &weave.Metadata{}
-			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2545,16 +12996,16 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MarketID", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Now", wireType)
 			}
-			var byteLen int
+			m.Now = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2564,31 +13015,69 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Now |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCodec(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthCodec
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MarketID = append(m.MarketID[:0], dAtA[iNdEx:postIndex]...)
-			if m.MarketID == nil {
-				m.MarketID = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PriceLevel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCodec
 			}
-			iNdEx = postIndex
-		case 4:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PriceLevel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PriceLevel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskTicker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2598,29 +13087,33 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AskTicker = string(dAtA[iNdEx:postIndex])
+			if m.Price == nil {
+				m.Price = &Amount{}
+			}
+			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BidTicker", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalOffer", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2630,48 +13123,33 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BidTicker = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalAskCount", wireType)
+			if m.TotalOffer == nil {
+				m.TotalOffer = &coin.Coin{}
 			}
-			m.TotalAskCount = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TotalAskCount |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.TotalOffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 7:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalBidCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderCount", wireType)
 			}
-			m.TotalBidCount = 0
+			m.OrderCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2681,7 +13159,7 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalBidCount |= int64(b&0x7F) << shift
+				m.OrderCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2710,7 +13188,7 @@ func (m *OrderBook) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Market) Unmarshal(dAtA []byte) error {
+func (m *OrderBookDepth) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2733,51 +13211,15 @@ func (m *Market) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Market: wiretype end group for non-group")
+			return fmt.Errorf("proto: OrderBookDepth: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Market: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OrderBookDepth: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Metadata == nil {
-				m.Metadata = &weave.Metadata{}
-			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2804,16 +13246,16 @@ func (m *Market) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Asks", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2823,31 +13265,31 @@ func (m *Market) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owner = append(m.Owner[:0], dAtA[iNdEx:postIndex]...)
-			if m.Owner == nil {
-				m.Owner = []byte{}
+			m.Asks = append(m.Asks, &PriceLevel{})
+			if err := m.Asks[len(m.Asks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Bids", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2857,23 +13299,25 @@ func (m *Market) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Bids = append(m.Bids, &PriceLevel{})
+			if err := m.Bids[len(m.Bids)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2899,7 +13343,7 @@ func (m *Market) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
+func (m *CandleQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2922,17 +13366,17 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateOrderMsg: wiretype end group for non-group")
+			return fmt.Errorf("proto: CandleQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CandleQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2942,33 +13386,31 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Metadata == nil {
-				m.Metadata = &weave.Metadata{}
-			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
+			if m.OrderBookID == nil {
+				m.OrderBookID = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
 			}
-			var byteLen int
+			m.Interval = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -2978,31 +13420,16 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Interval |= CandleInterval(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
-			if m.Trader == nil {
-				m.Trader = []byte{}
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderBookID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
 			}
-			var byteLen int
+			m.Since = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3012,31 +13439,16 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Since |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.OrderBookID = append(m.OrderBookID[:0], dAtA[iNdEx:postIndex]...)
-			if m.OrderBookID == nil {
-				m.OrderBookID = []byte{}
-			}
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Until", wireType)
 			}
-			var msglen int
+			m.Until = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3046,33 +13458,16 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Until |= github_com_iov_one_weave.UnixTime(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Offer == nil {
-				m.Offer = &coin.Coin{}
-			}
-			if err := m.Offer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Price", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var msglen int
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3082,28 +13477,11 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Price == nil {
-				m.Price = &Amount{}
-			}
-			if err := m.Price.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -3128,7 +13506,7 @@ func (m *CreateOrderMsg) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
+func (m *TraderOrdersQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3151,17 +13529,17 @@ func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CancelOrderMsg: wiretype end group for non-group")
+			return fmt.Errorf("proto: TraderOrdersQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CancelOrderMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TraderOrdersQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3171,31 +13549,48 @@ func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Metadata == nil {
-				m.Metadata = &weave.Metadata{}
-			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= OrderState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3222,11 +13617,30 @@ func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OrderID = append(m.OrderID[:0], dAtA[iNdEx:postIndex]...)
-			if m.OrderID == nil {
-				m.OrderID = []byte{}
+			m.After = append(m.After[:0], dAtA[iNdEx:postIndex]...)
+			if m.After == nil {
+				m.After = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCodec
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -3251,7 +13665,7 @@ func (m *CancelOrderMsg) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
+func (m *TraderTradesQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3274,17 +13688,17 @@ func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateOrderBookMsg: wiretype end group for non-group")
+			return fmt.Errorf("proto: TraderTradesQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateOrderBookMsg: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TraderTradesQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3294,31 +13708,29 @@ func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthCodec
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthCodec
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Metadata == nil {
-				m.Metadata = &weave.Metadata{}
-			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Trader = append(m.Trader[:0], dAtA[iNdEx:postIndex]...)
+			if m.Trader == nil {
+				m.Trader = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MarketID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3345,48 +13757,16 @@ func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MarketID = append(m.MarketID[:0], dAtA[iNdEx:postIndex]...)
-			if m.MarketID == nil {
-				m.MarketID = []byte{}
+			m.After = append(m.After[:0], dAtA[iNdEx:postIndex]...)
+			if m.After == nil {
+				m.After = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskTicker", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowCodec
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AskTicker = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BidTicker", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var stringLen uint64
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowCodec
@@ -3396,24 +13776,11 @@ func (m *CreateOrderBookMsg) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthCodec
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthCodec
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.BidTicker = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCodec(dAtA[iNdEx:])
@@ -0,0 +1,94 @@
+package orderbook
+
+import (
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
+)
+
+var _ gconf.OwnedConfig = (*Configuration)(nil)
+
+// Validate ensures the configuration is consistent. It must have an owner
+// to be updated. Without a fee ticker fees cannot be paid at a discount
+func (c *Configuration) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Owner", c.Owner.Validate())
+	if c.FeeTicker != "" && !coin.IsCC(c.FeeTicker) {
+		errs = errors.AppendField(errs, "FeeTicker", errors.ErrCurrency)
+	}
+	errs = errors.AppendField(errs, "FeeDiscount", validateFeeRate(c.FeeDiscount))
+	if len(c.FeePrices) != 0 && c.FeeTicker == "" {
+		errs = errors.AppendField(errs, "FeeTicker", errors.Wrap(errors.ErrEmpty, "required to convert fees"))
+	}
+	errs = errors.AppendField(errs, "FeePrices", validateFeePrices(c.FeePrices, c.FeeTicker))
+
+	return errs
+}
+
+// validateFeePrices ensures there is at most one positive price for every
+// ticker, other than the fee ticker itself
+func validateFeePrices(prices []*FeePrice, feeTicker string) error {
+	seen := make(map[string]bool)
+	for _, p := range prices {
+		if p == nil {
+			return errors.Wrap(errors.ErrEmpty, "fee price")
+		}
+		if !coin.IsCC(p.Ticker) {
+			return errors.Wrapf(errors.ErrCurrency, "ticker %q", p.Ticker)
+		}
+		if p.Ticker == feeTicker {
+			return errors.Wrap(errors.ErrInput, "fee ticker has no price")
+		}
+		if seen[p.Ticker] {
+			return errors.Wrapf(errors.ErrDuplicate, "ticker %s", p.Ticker)
+		}
+		seen[p.Ticker] = true
+		if err := p.Price.Validate(); err != nil {
+			return errors.Wrapf(err, "price of %s", p.Ticker)
+		}
+		if !p.Price.IsPositive() {
+			return errors.Wrapf(errors.ErrInput, "price of %s must be positive", p.Ticker)
+		}
+	}
+	return nil
+}
+
+// discountedFee converts a fee into the fee ticker, less the discount. It
+// returns nil if the fee cannot be paid in the fee ticker.
+// Rounding is in favor of the trader.
+func (c *Configuration) discountedFee(fee coin.Coin) (*coin.Coin, error) {
+	if c.FeeTicker == "" {
+		return nil, nil
+	}
+	price := NewAmountp(1, 0)
+	if fee.Ticker != c.FeeTicker {
+		price = nil
+		for _, p := range c.FeePrices {
+			if p.Ticker == fee.Ticker {
+				price = p.Price
+				break
+			}
+		}
+		if price == nil {
+			return nil, nil
+		}
+	}
+
+	discounted := fee
+	if c.FeeDiscount.IsPositive() {
+		rest, err := NewAmountp(1, 0).Subtract(c.FeeDiscount)
+		if err != nil {
+			return nil, errors.Wrap(err, "discount")
+		}
+		if discounted, err = rest.MulCoinRound(fee, RoundDown); err != nil {
+			return nil, errors.Wrap(err, "discount")
+		}
+	}
+	converted, err := price.MulCoinRound(discounted, RoundDown)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert fee")
+	}
+	converted.Ticker = c.FeeTicker
+	return &converted, nil
+}
@@ -0,0 +1,156 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	owner := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		conf    *Configuration
+		wantErr *errors.Error
+	}{
+		"success": {
+			conf: &Configuration{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Owner:       owner,
+				FeeTicker:   "IDEX",
+				FeeDiscount: NewAmountp(0, 250000000),
+				FeePrices: []*FeePrice{
+					{Ticker: "ETH", Price: NewAmountp(2, 0)},
+					{Ticker: "BTC", Price: NewAmountp(40, 0)},
+				},
+			},
+		},
+		"no discount": {
+			conf: &Configuration{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+			},
+		},
+		"no owner": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				FeeTicker: "IDEX",
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"invalid fee ticker": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				Owner:     owner,
+				FeeTicker: "idex",
+			},
+			wantErr: errors.ErrCurrency,
+		},
+		"discount too high": {
+			conf: &Configuration{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Owner:       owner,
+				FeeTicker:   "IDEX",
+				FeeDiscount: NewAmountp(1, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"prices without fee ticker": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				Owner:     owner,
+				FeePrices: []*FeePrice{{Ticker: "ETH", Price: NewAmountp(2, 0)}},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"price of the fee ticker": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				Owner:     owner,
+				FeeTicker: "IDEX",
+				FeePrices: []*FeePrice{{Ticker: "IDEX", Price: NewAmountp(2, 0)}},
+			},
+			wantErr: errors.ErrInput,
+		},
+		"duplicate price": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				Owner:     owner,
+				FeeTicker: "IDEX",
+				FeePrices: []*FeePrice{
+					{Ticker: "ETH", Price: NewAmountp(2, 0)},
+					{Ticker: "ETH", Price: NewAmountp(3, 0)},
+				},
+			},
+			wantErr: errors.ErrDuplicate,
+		},
+		"zero price": {
+			conf: &Configuration{
+				Metadata:  &weave.Metadata{Schema: 1},
+				Owner:     owner,
+				FeeTicker: "IDEX",
+				FeePrices: []*FeePrice{{Ticker: "ETH", Price: NewAmountp(0, 0)}},
+			},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.conf.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestConfigurationDiscountedFee(t *testing.T) {
+	conf := &Configuration{
+		Metadata:    &weave.Metadata{Schema: 1},
+		FeeTicker:   "IDEX",
+		FeeDiscount: NewAmountp(0, 250000000),
+		FeePrices:   []*FeePrice{{Ticker: "ETH", Price: NewAmountp(2, 0)}},
+	}
+
+	cases := map[string]struct {
+		conf *Configuration
+		fee  coin.Coin
+		want *coin.Coin
+	}{
+		"converted and discounted": {
+			conf: conf,
+			fee:  coin.NewCoin(2, 0, "ETH"),
+			want: coin.NewCoinp(3, 0, "IDEX"),
+		},
+		"fee ticker is only discounted": {
+			conf: conf,
+			fee:  coin.NewCoin(2, 0, "IDEX"),
+			want: coin.NewCoinp(1, 500000000, "IDEX"),
+		},
+		"rounded down": {
+			conf: conf,
+			fee:  coin.NewCoin(0, 3, "ETH"),
+			want: coin.NewCoinp(0, 4, "IDEX"),
+		},
+		"no price": {
+			conf: conf,
+			fee:  coin.NewCoin(2, 0, "BTC"),
+		},
+		"no fee ticker": {
+			conf: &Configuration{Metadata: &weave.Metadata{Schema: 1}},
+			fee:  coin.NewCoin(2, 0, "ETH"),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := tc.conf.discountedFee(tc.fee)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package orderbook
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
+	"github.com/iov-one/weave/x/cash"
+)
+
+// feeCharger charges the trading fees of the fills of one market to the
+// traders and pays them to the fee collector. The configuration is only
+// loaded once an order asks for a discounted fee.
+type feeCharger struct {
+	bank   cash.CoinMover
+	market *Market
+	// conf is nil if there is no configuration
+	conf   *Configuration
+	loaded bool
+}
+
+func newFeeCharger(bank cash.CoinMover, market *Market) *feeCharger {
+	return &feeCharger{
+		bank:   bank,
+		market: market,
+	}
+}
+
+// charge takes the fee at the given rate on what the trader of the order
+// received from a fill, and returns it, nil if there is none.
+//
+// An order with a discounted fee pays it in the fee ticker when possible.
+// Otherwise the fee is paid in the ticker received, so the trader can always
+// afford it.
+func (c *feeCharger) charge(db weave.KVStore, order *Order, rate *Amount, received coin.Coin) (*coin.Coin, error) {
+	fee, err := feeAt(rate, received)
+	if err != nil {
+		return nil, err
+	}
+	if !fee.IsPositive() {
+		return nil, nil
+	}
+
+	if order.DiscountedFee {
+		discounted, err := c.discountedFee(db, fee)
+		if err != nil {
+			return nil, err
+		}
+		if discounted != nil {
+			if !discounted.IsPositive() {
+				return nil, nil
+			}
+			err := c.bank.MoveCoins(db, order.Trader, c.market.feeCollector(), *discounted)
+			switch {
+			case err == nil:
+				return discounted, nil
+			case errors.ErrAmount.Is(err):
+				// not enough of the fee ticker, the fee is charged as usual
+			default:
+				return nil, errors.Wrap(err, "cannot pay discounted fee")
+			}
+		}
+	}
+
+	if err := c.bank.MoveCoins(db, order.Trader, c.market.feeCollector(), fee); err != nil {
+		return nil, errors.Wrap(err, "cannot pay fee")
+	}
+	return &fee, nil
+}
+
+// discountedFee returns the fee in the fee ticker of the configuration, nil if
+// it cannot be paid in the fee ticker
+func (c *feeCharger) discountedFee(db weave.ReadOnlyKVStore, fee coin.Coin) (*coin.Coin, error) {
+	if !c.loaded {
+		var conf Configuration
+		switch err := gconf.Load(db, packageName, &conf); {
+		case errors.ErrNotFound.Is(err):
+		case err != nil:
+			return nil, errors.Wrap(err, "cannot load configuration")
+		default:
+			c.conf = &conf
+		}
+		c.loaded = true
+	}
+	if c.conf == nil {
+		return nil, nil
+	}
+	return c.conf.discountedFee(fee)
+}
+
+// feeAt returns the fee at the given rate on what a trader receives,
+// rounded down. A missing rate charges no fee
+func feeAt(rate *Amount, received coin.Coin) (coin.Coin, error) {
+	if rate.IsZero() {
+		return coin.NewCoin(0, 0, received.Ticker), nil
+	}
+	return rate.MulCoinRound(received, RoundDown)
+}
@@ -1,28 +1,239 @@
 package orderbook
 
 import (
+	"bytes"
+	"fmt"
+
+	"github.com/iov-one/tutorial/morm"
 	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
 	"github.com/iov-one/weave/migration"
 	"github.com/iov-one/weave/x"
+	"github.com/iov-one/weave/x/cash"
 )
 
 const (
 	packageName            = "orderbook"
 	newOrderBookCost int64 = 100
+	newOrderCost     int64 = 100
+	cancelOrderCost  int64 = 0
+	replaceOrderCost int64 = 100
+	expireOrderCost  int64 = 0
+	activateStopCost int64 = 0
+	newMarketCost    int64 = 100
+	updateMarketCost int64 = 10
+
+	newStopOrderCost    int64 = 100
+	cancelStopOrderCost int64 = 0
+
+	// batches pay newOrderCost for every order they place, mass cancels
+	// pay newOrderCost for the scan and cancelAllOrdersCost for every
+	// order they cancel
+	cancelAllOrdersCost int64 = 10
+	// maxBatchOrders bounds the orders placed by a single CreateOrdersMsg
+	maxBatchOrders = 50
 )
 
+// EscrowAddress is the module account holding the remaining offers of all
+// open orders until they are either matched or cancelled
+var EscrowAddress = weave.NewCondition(packageName, "escrow", nil).Address()
+
 // RegisterQuery registers exchange buckets for querying.
 func RegisterQuery(qr weave.QueryRouter) {
 	NewMarketBucket().Register("markets", qr)
 	NewOrderBookBucket().Register("orderbooks", qr)
+	qr.Register("/orderbooks/depth", NewDepthQueryHandler())
+	qr.Register("/orderbooks/ticker", NewTickerQueryHandler())
+	NewOrderBucket().Register("orders", qr)
+	NewStopOrderBucket().Register("stoporders", qr)
+	NewTradeBucket().Register("trades", qr)
+	qr.Register("/traders/orders", NewTraderOrdersQueryHandler())
+	qr.Register("/traders/trades", NewTraderTradesQueryHandler())
+	NewCandleBucket().Register("candles", qr)
+	qr.Register("/candles/range", NewCandleQueryHandler())
 }
 
 // RegisterRoutes registers handlers for orderbook message processing.
-func RegisterRoutes(r weave.Registry, auth x.Authenticator) {
+// The scheduler is used to expire orders and activate stop orders, it must use
+// the same task marshaler as the ticker running the cron routes.
+func RegisterRoutes(r weave.Registry, auth x.Authenticator, cashctrl cash.Controller, scheduler weave.Scheduler) {
 	r = migration.SchemaMigratingRegistry(packageName, r)
 
 	r.Handle(&CreateOrderBookMsg{}, NewOrderBookHandler(auth))
+	r.Handle(&CreateOrderMsg{}, NewOrderHandler(auth, cashctrl, scheduler))
+	r.Handle(&CancelOrderMsg{}, NewCancelOrderHandler(auth, cashctrl))
+	r.Handle(&ReplaceOrderMsg{}, NewReplaceOrderHandler(auth, cashctrl, scheduler))
+	r.Handle(&CreateOrdersMsg{}, NewCreateOrdersHandler(auth, cashctrl, scheduler))
+	r.Handle(&CancelAllOrdersMsg{}, NewCancelAllOrdersHandler(auth, cashctrl))
+	r.Handle(&CreateStopOrderMsg{}, NewStopOrderHandler(auth, cashctrl))
+	r.Handle(&CancelStopOrderMsg{}, NewCancelStopOrderHandler(auth, cashctrl))
+	r.Handle(&CreateMarketMsg{}, NewMarketHandler(auth))
+	r.Handle(&UpdateMarketOwnerMsg{}, NewUpdateMarketOwnerHandler(auth))
+	r.Handle(&UpdateConfigurationMsg{}, NewConfigHandler(auth))
+}
+
+// RegisterCronRoutes registers handlers for the orderbook tasks executed by
+// the cron ticker. They must never be registered with the transaction router.
+// The scheduler is used to continue activating stop orders in the next block.
+func RegisterCronRoutes(r weave.Registry, cashctrl cash.Controller, scheduler weave.Scheduler) {
+	r = migration.SchemaMigratingRegistry(packageName, r)
+
+	r.Handle(&ExpireOrderMsg{}, NewExpireOrderHandler(cashctrl))
+	r.Handle(&ActivateStopsMsg{}, NewActivateStopsHandler(cashctrl, scheduler))
+}
+
+// NewConfigHandler creates a handler that allows the owner of the
+// configuration to update it
+func NewConfigHandler(auth x.Authenticator) weave.Handler {
+	var conf Configuration
+	return gconf.NewUpdateConfigurationHandler(packageName, &conf, auth)
+}
+
+// ------------------- MARKET HANDLER -------------------
+
+// MarketHandler will handle creating markets
+type MarketHandler struct {
+	auth         x.Authenticator
+	marketBucket *MarketBucket
+}
+
+var _ weave.Handler = MarketHandler{}
+
+// NewMarketHandler creates a handler that allows anyone to create a
+// market with a unique name. The owner must authorize the creation
+func NewMarketHandler(auth x.Authenticator) weave.Handler {
+	return MarketHandler{
+		auth:         auth,
+		marketBucket: NewMarketBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h MarketHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: newMarketCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h MarketHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*CreateMarketMsg, error) {
+	var msg CreateMarketMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	if !h.auth.HasAddress(ctx, msg.Owner) {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "only owner can create market")
+	}
+
+	var markets []Market
+	if err := h.marketBucket.ByIndex(db, "name", []byte(msg.Name), &markets); err != nil {
+		return nil, err
+	}
+	if len(markets) != 0 {
+		return nil, errors.Wrapf(errors.ErrDuplicate, "market %s already exists", msg.Name)
+	}
+
+	return &msg, nil
+}
+
+// Deliver creates a market and saves if all preconditions are met
+func (h MarketHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	msg, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	market := &Market{
+		Metadata:     &weave.Metadata{Schema: 1},
+		Owner:        msg.Owner,
+		Name:         msg.Name,
+		MakerFee:     msg.MakerFee,
+		TakerFee:     msg.TakerFee,
+		FeeCollector: msg.FeeCollector,
+	}
+
+	// the unique index "name" ensures there are no duplicates
+	if err := h.marketBucket.Put(db, market); err != nil {
+		return nil, err
+	}
+
+	// we return the new id on creation to enable easier queries
+	return &weave.DeliverResult{Data: market.ID}, nil
+}
+
+// ------------------- UPDATE MARKET OWNER HANDLER -------------------
+
+// UpdateMarketOwnerHandler will handle changing the owner of a market
+type UpdateMarketOwnerHandler struct {
+	auth         x.Authenticator
+	marketBucket *MarketBucket
+}
+
+var _ weave.Handler = UpdateMarketOwnerHandler{}
+
+// NewUpdateMarketOwnerHandler creates a handler that allows the owner
+// of a market to hand it over to another address, such as a multisig
+// contract
+func NewUpdateMarketOwnerHandler(auth x.Authenticator) weave.Handler {
+	return UpdateMarketOwnerHandler{
+		auth:         auth,
+		marketBucket: NewMarketBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h UpdateMarketOwnerHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, _, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: updateMarketCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h UpdateMarketOwnerHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*UpdateMarketOwnerMsg, *Market, error) {
+	var msg UpdateMarketOwnerMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, nil, errors.Wrap(err, "load msg")
+	}
+
+	var market Market
+	if err := h.marketBucket.One(db, msg.MarketID, &market); err != nil {
+		return nil, nil, err
+	}
+
+	// And ensure the current owner has authorized this change
+	if !h.auth.HasAddress(ctx, market.Owner) {
+		return nil, nil, errors.Wrap(errors.ErrUnauthorized, "only market owner can change owner")
+	}
+
+	return &msg, &market, nil
+}
+
+// Deliver updates the market owner if all preconditions are met
+func (h UpdateMarketOwnerHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	msg, market, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	market.Owner = msg.NewOwner
+	if err := h.marketBucket.Put(db, market); err != nil {
+		return nil, err
+	}
+
+	return &weave.DeliverResult{Data: market.ID}, nil
 }
 
 // ------------------- ORDERBOOK HANDLER -------------------
@@ -95,6 +306,9 @@ func (h OrderBookHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.
 		BidTicker:     msg.BidTicker,
 		TotalAskCount: 0,
 		TotalBidCount: 0,
+		TickSize:      msg.TickSize,
+		LotSize:       msg.LotSize,
+		MinOffer:      msg.MinOffer,
 	}
 
 	// the unique index "marketWithTickers" ensures there are no duplicates, would return error here
@@ -105,3 +319,1042 @@ func (h OrderBookHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.
 	// we return the new id on creation to enable easier queries
 	return &weave.DeliverResult{Data: orderbook.ID}, err
 }
+
+// ------------------- ORDER HANDLER -------------------
+
+// OrderHandler will handle creating orders
+type OrderHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	engine          matchingEngine
+	scheduler       weave.Scheduler
+	orderBucket     *OrderBucket
+	orderBookBucket *OrderBookBucket
+}
+
+var _ weave.Handler = OrderHandler{}
+
+// NewOrderHandler creates a handler that allows traders to place
+// orders on an existing orderbook. The offer is moved from the trader
+// into the escrow account and immediately matched against the resting
+// orders. Whatever is not filled stays on the book until it is traded,
+// cancelled or expired by a task added to the scheduler
+func NewOrderHandler(auth x.Authenticator, bank cash.CoinMover, scheduler weave.Scheduler) weave.Handler {
+	return OrderHandler{
+		auth:            auth,
+		bank:            bank,
+		engine:          newMatchingEngine(bank),
+		scheduler:       scheduler,
+		orderBucket:     NewOrderBucket(),
+		orderBookBucket: NewOrderBookBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h OrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, _, _, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: newOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver.
+// It returns the message along with the orderbook it refers to and the
+// side of the book inferred from the offer ticker
+func (h OrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*CreateOrderMsg, *OrderBook, Side, error) {
+	var msg CreateOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, nil, Side_Invalid, errors.Wrap(err, "load msg")
+	}
+
+	orderbook, side, err := h.validateOrder(ctx, db, &msg)
+	if err != nil {
+		return nil, nil, Side_Invalid, err
+	}
+	return &msg, orderbook, side, nil
+}
+
+// validateOrder checks an order against the current state of its orderbook.
+// The price of a post-only order is updated in place
+func (h OrderHandler) validateOrder(ctx weave.Context, db weave.KVStore, msg *CreateOrderMsg) (*OrderBook, Side, error) {
+	// Trader must authorize paying the offer
+	if !h.auth.HasAddress(ctx, msg.Trader) {
+		return nil, Side_Invalid, errors.Wrap(errors.ErrUnauthorized, "only trader can create order")
+	}
+
+	var orderbook OrderBook
+	if err := h.orderBookBucket.One(db, msg.OrderBookID, &orderbook); err != nil {
+		return nil, Side_Invalid, errors.Wrap(err, "cannot load orderbook")
+	}
+
+	side, err := orderSide(&orderbook, msg.Offer.Ticker)
+	if err != nil {
+		return nil, Side_Invalid, err
+	}
+	if err := orderbook.checkOrderRules(*msg.Offer, msg.Price, msg.OrderType); err != nil {
+		return nil, Side_Invalid, err
+	}
+	if msg.PostOnly != PostOnly_Disabled {
+		if msg.Price, err = h.engine.PostOnlyPrice(db, &orderbook, side, msg.Price, msg.PostOnly); err != nil {
+			return nil, Side_Invalid, err
+		}
+	}
+
+	if msg.ExpiresAt != 0 {
+		now, err := weave.BlockTime(ctx)
+		if err != nil {
+			return nil, Side_Invalid, errors.Wrap(err, "block time")
+		}
+		if !msg.ExpiresAt.Time().After(now) {
+			return nil, Side_Invalid, errors.Wrap(errors.ErrInput, "order expiration must be in the future")
+		}
+	}
+
+	return &orderbook, side, nil
+}
+
+// orderSide infers the side of the orderbook an offer with the given ticker belongs to
+func orderSide(orderbook *OrderBook, ticker string) (Side, error) {
+	switch ticker {
+	case orderbook.AskTicker:
+		return Side_Ask, nil
+	case orderbook.BidTicker:
+		return Side_Bid, nil
+	default:
+		return Side_Invalid, errors.Wrapf(errors.ErrCurrency, "orderbook does not trade %s", ticker)
+	}
+}
+
+// Deliver escrows the offer, stores a new order and matches it if all preconditions are met
+func (h OrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	msg, orderbook, side, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := h.placeOrder(ctx, db, msg, orderbook, side)
+	if err != nil {
+		return nil, err
+	}
+
+	// we return the new id on creation to enable easier queries
+	return &weave.DeliverResult{Data: order.ID}, nil
+}
+
+// placeOrder escrows the offer of a validated order, stores it and matches it
+func (h OrderHandler) placeOrder(ctx weave.Context, db weave.KVStore, msg *CreateOrderMsg, orderbook *OrderBook, side Side) (*Order, error) {
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	// the offer is held by the module until the order is matched or cancelled
+	if err := h.bank.MoveCoins(db, msg.Trader, EscrowAddress, *msg.Offer); err != nil {
+		return nil, errors.Wrap(err, "cannot escrow offer")
+	}
+
+	order := &Order{
+		Metadata:            &weave.Metadata{Schema: 1},
+		Trader:              msg.Trader,
+		OrderBookID:         msg.OrderBookID,
+		Side:                side,
+		OrderState:          OrderState_Open,
+		OriginalOffer:       msg.Offer.Clone(),
+		RemainingOffer:      msg.Offer.Clone(),
+		Price:               msg.Price.Clone(),
+		CreatedAt:           weave.AsUnixTime(now),
+		UpdatedAt:           weave.AsUnixTime(now),
+		OrderType:           msg.OrderType,
+		TimeInForce:         msg.TimeInForce,
+		ExpiresAt:           msg.ExpiresAt,
+		DiscountedFee:       msg.DiscountedFee,
+		SelfTradePrevention: msg.SelfTradePrevention,
+		PostOnly:            msg.PostOnly,
+		Sequence:            nextSequence(orderbook),
+	}
+	// store first, so the trades can reference the order id
+	if err := h.orderBucket.Put(db, order); err != nil {
+		return nil, errors.Wrap(err, "cannot store order")
+	}
+
+	// match against resting orders, whatever is left stays open on the book
+	// or is refunded, depending on the time in force
+	if err := h.engine.Match(db, orderbook, order, order.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "matching")
+	}
+
+	// only an order that rests on the book has something left to expire
+	if order.OrderState == OrderState_Open && order.ExpiresAt != 0 {
+		expire := &ExpireOrderMsg{
+			Metadata: &weave.Metadata{Schema: 1},
+			OrderID:  order.ID,
+		}
+		// expiring requires no authentication, the handler is only
+		// registered for cron
+		taskID, err := h.scheduler.Schedule(db, order.ExpiresAt.Time(), nil, expire)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot schedule expiration")
+		}
+		order.ExpirationTaskID = taskID
+		if err := h.orderBucket.Put(db, order); err != nil {
+			return nil, errors.Wrap(err, "cannot update order")
+		}
+	}
+
+	// the trades may trigger stop orders, they are activated by the
+	// cron ticker in the next block
+	if len(order.TradeIds) != 0 {
+		if err := h.engine.ScheduleStops(db, h.scheduler, orderbook, now); err != nil {
+			return nil, errors.Wrap(err, "stop orders")
+		}
+	}
+	if err := h.orderBookBucket.Put(db, orderbook); err != nil {
+		return nil, errors.Wrap(err, "cannot update orderbook")
+	}
+	return order, nil
+}
+
+// ------------------- CREATE ORDERS HANDLER -------------------
+
+// CreateOrdersHandler will handle placing batches of orders
+type CreateOrdersHandler struct {
+	orders OrderHandler
+}
+
+var _ weave.Handler = CreateOrdersHandler{}
+
+// NewCreateOrdersHandler creates a handler that allows traders to place
+// many orders at once. Every order is placed exactly as by the order
+// handler, one after the other, and the batch fails as a whole if any
+// of them fails
+func NewCreateOrdersHandler(auth x.Authenticator, bank cash.CoinMover, scheduler weave.Scheduler) weave.Handler {
+	return CreateOrdersHandler{
+		orders: NewOrderHandler(auth, bank, scheduler).(OrderHandler),
+	}
+}
+
+// Check verifies every order against the current state and returns
+// the cost of placing all of them.
+func (h CreateOrdersHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	var msg CreateOrdersMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+	for i := range msg.Orders {
+		if _, _, err := h.orders.validateOrder(ctx, db, msg.orderMsg(i)); err != nil {
+			return nil, errors.Wrapf(err, "order %d", i)
+		}
+	}
+
+	return &weave.CheckResult{GasAllocated: newOrderCost * int64(len(msg.Orders))}, nil
+}
+
+// Deliver validates and places the orders in turn, so every order sees
+// the book as left by the ones before. It returns the concatenated IDs
+// of the new orders
+func (h CreateOrdersHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	var msg CreateOrdersMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	ids := make([]byte, 0, len(msg.Orders)*idByteSize)
+	for i := range msg.Orders {
+		orderMsg := msg.orderMsg(i)
+		orderbook, side, err := h.orders.validateOrder(ctx, db, orderMsg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "order %d", i)
+		}
+		order, err := h.orders.placeOrder(ctx, db, orderMsg, orderbook, side)
+		if err != nil {
+			return nil, errors.Wrapf(err, "order %d", i)
+		}
+		ids = append(ids, order.ID...)
+	}
+
+	return &weave.DeliverResult{Data: ids}, nil
+}
+
+// ------------------- CANCEL ORDER HANDLER -------------------
+
+// CancelOrderHandler will handle cancelling open orders
+type CancelOrderHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	orderBucket     *OrderBucket
+	orderBookBucket *OrderBookBucket
+	tickerBucket    *TickerBucket
+}
+
+var _ weave.Handler = CancelOrderHandler{}
+
+// NewCancelOrderHandler creates a handler that allows the trader to
+// cancel an open order. The remaining offer is returned from the
+// escrow account to the trader
+func NewCancelOrderHandler(auth x.Authenticator, bank cash.CoinMover) weave.Handler {
+	return CancelOrderHandler{
+		auth:            auth,
+		bank:            bank,
+		orderBucket:     NewOrderBucket(),
+		orderBookBucket: NewOrderBookBucket(),
+		tickerBucket:    NewTickerBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h CancelOrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: cancelOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h CancelOrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*Order, error) {
+	var msg CancelOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	var order Order
+	if err := h.orderBucket.One(db, msg.OrderID, &order); err != nil {
+		return nil, errors.Wrap(err, "cannot load order")
+	}
+
+	// Only the trader who created the order can cancel it
+	if !h.auth.HasAddress(ctx, order.Trader) {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "only trader can cancel order")
+	}
+
+	if order.OrderState != OrderState_Open {
+		return nil, errors.Wrapf(errors.ErrState, "order is %s", order.OrderState)
+	}
+
+	return &order, nil
+}
+
+// Deliver refunds the remaining offer and marks the order as cancelled
+// if all preconditions are met
+func (h CancelOrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	order, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	if err := cancelOrder(db, h.bank, h.orderBucket, h.orderBookBucket, h.tickerBucket, order, CancelReason_Trader, weave.AsUnixTime(now)); err != nil {
+		return nil, err
+	}
+
+	return &weave.DeliverResult{Data: order.ID}, nil
+}
+
+// ------------------- CANCEL ALL ORDERS HANDLER -------------------
+
+// CancelAllOrdersHandler will handle cancelling all open orders of a trader
+type CancelAllOrdersHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	orderBucket     *OrderBucket
+	orderBookBucket *OrderBookBucket
+	tickerBucket    *TickerBucket
+}
+
+var _ weave.Handler = CancelAllOrdersHandler{}
+
+// NewCancelAllOrdersHandler creates a handler that allows the trader to
+// cancel all its open orders, or those of one orderbook or side, at once.
+// The remaining offers are returned from the escrow account to the trader
+func NewCancelAllOrdersHandler(auth x.Authenticator, bank cash.CoinMover) weave.Handler {
+	return CancelAllOrdersHandler{
+		auth:            auth,
+		bank:            bank,
+		orderBucket:     NewOrderBucket(),
+		orderBookBucket: NewOrderBookBucket(),
+		tickerBucket:    NewTickerBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of finding and cancelling every matching order.
+func (h CancelAllOrdersHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	orders, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: newOrderCost + cancelAllOrdersCost*int64(len(orders))}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver.
+// It returns the open orders to cancel, found through the "trader" index
+func (h CancelAllOrdersHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) ([]*Order, error) {
+	var msg CancelAllOrdersMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	// Only the trader can cancel its orders
+	if !h.auth.HasAddress(ctx, msg.Trader) {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "only trader can cancel orders")
+	}
+
+	prefix, err := BuildOrderTraderPrefix(msg.Trader, OrderState_Open)
+	if err != nil {
+		return nil, errors.Wrap(err, "trader orders prefix")
+	}
+	iter, err := h.orderBucket.IndexScan(db, "trader", prefix, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan trader orders")
+	}
+	defer iter.Release()
+
+	// the orders are collected first, so we never modify the store
+	// under an open iterator
+	var orders []*Order
+	for {
+		var order Order
+		err := iter.LoadNext(&order)
+		if morm.ErrIteratorDone.Is(err) {
+			return orders, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "load order")
+		}
+		if len(msg.OrderBookID) != 0 && !bytes.Equal(order.OrderBookID, msg.OrderBookID) {
+			continue
+		}
+		if msg.Side != Side_Invalid && order.Side != msg.Side {
+			continue
+		}
+		orders = append(orders, &order)
+	}
+}
+
+// Deliver refunds the remaining offers and marks all matching orders as
+// cancelled. It returns the concatenated IDs of the cancelled orders
+func (h CancelAllOrdersHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	orders, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	ids := make([]byte, 0, len(orders)*idByteSize)
+	for _, order := range orders {
+		if err := cancelOrder(db, h.bank, h.orderBucket, h.orderBookBucket, h.tickerBucket, order, CancelReason_Trader, weave.AsUnixTime(now)); err != nil {
+			return nil, errors.Wrapf(err, "order %X", order.ID)
+		}
+		ids = append(ids, order.ID...)
+	}
+
+	return &weave.DeliverResult{Data: ids}, nil
+}
+
+// ------------------- REPLACE ORDER HANDLER -------------------
+
+// ReplaceOrderHandler will handle changing the price and the remaining
+// offer of open orders
+type ReplaceOrderHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	scheduler       weave.Scheduler
+	engine          matchingEngine
+	orderBucket     *OrderBucket
+	orderBookBucket *OrderBookBucket
+}
+
+var _ weave.Handler = ReplaceOrderHandler{}
+
+// NewReplaceOrderHandler creates a handler that allows the trader to change
+// an open order without cancelling it. The difference of the remaining offer
+// is moved between the trader and the escrow account, and a new price is
+// matched against the resting orders
+func NewReplaceOrderHandler(auth x.Authenticator, bank cash.CoinMover, scheduler weave.Scheduler) weave.Handler {
+	return ReplaceOrderHandler{
+		auth:            auth,
+		bank:            bank,
+		scheduler:       scheduler,
+		engine:          newMatchingEngine(bank),
+		orderBucket:     NewOrderBucket(),
+		orderBookBucket: NewOrderBookBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h ReplaceOrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, _, _, _, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: replaceOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver.
+// It returns the order along with its orderbook, and the new price and
+// remaining offer of the order
+func (h ReplaceOrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*Order, *OrderBook, *Amount, *coin.Coin, error) {
+	var msg ReplaceOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "load msg")
+	}
+
+	var order Order
+	if err := h.orderBucket.One(db, msg.OrderID, &order); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "cannot load order")
+	}
+
+	// Only the trader who created the order can replace it
+	if !h.auth.HasAddress(ctx, order.Trader) {
+		return nil, nil, nil, nil, errors.Wrap(errors.ErrUnauthorized, "only trader can replace order")
+	}
+
+	if order.OrderState != OrderState_Open {
+		return nil, nil, nil, nil, errors.Wrapf(errors.ErrState, "order is %s", order.OrderState)
+	}
+
+	var orderbook OrderBook
+	if err := h.orderBookBucket.One(db, order.OrderBookID, &orderbook); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "cannot load orderbook")
+	}
+
+	// the rules only apply to what changes, a partially filled order may
+	// have any remaining offer
+	price, offer := order.Price, order.RemainingOffer
+	if msg.Price != nil && !msg.Price.Equals(order.Price) {
+		if err := orderbook.checkPriceRules(msg.Price, order.OrderType); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		price = msg.Price
+		if order.PostOnly != PostOnly_Disabled {
+			var err error
+			if price, err = h.engine.PostOnlyPrice(db, &orderbook, order.Side, price, order.PostOnly); err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	}
+	if msg.RemainingOffer != nil && !msg.RemainingOffer.Equals(*order.RemainingOffer) {
+		if !msg.RemainingOffer.SameType(*order.RemainingOffer) {
+			return nil, nil, nil, nil, errors.Wrapf(errors.ErrCurrency, "order offers %s", order.RemainingOffer.Ticker)
+		}
+		if err := orderbook.checkOfferRules(*msg.RemainingOffer); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		offer = msg.RemainingOffer
+	}
+	if price == order.Price && offer == order.RemainingOffer {
+		return nil, nil, nil, nil, errors.Wrap(errors.ErrInput, "order is unchanged")
+	}
+
+	return &order, &orderbook, price, offer, nil
+}
+
+// Deliver settles the difference of the remaining offer and updates the
+// order if all preconditions are met. An order with a new price leaves the
+// book and is matched again
+func (h ReplaceOrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	order, orderbook, price, offer, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTime, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+	now := weave.AsUnixTime(blockTime)
+
+	increased := offer.Compare(*order.RemainingOffer) > 0
+	if increased {
+		diff, err := offer.Subtract(*order.RemainingOffer)
+		if err != nil {
+			return nil, errors.Wrap(err, "offer difference")
+		}
+		if err := h.bank.MoveCoins(db, order.Trader, EscrowAddress, diff); err != nil {
+			return nil, errors.Wrap(err, "cannot escrow offer")
+		}
+	} else if diff, err := order.RemainingOffer.Subtract(*offer); err != nil {
+		return nil, errors.Wrap(err, "offer difference")
+	} else if diff.IsPositive() {
+		if err := h.bank.MoveCoins(db, EscrowAddress, order.Trader, diff); err != nil {
+			return nil, errors.Wrap(err, "cannot refund offer")
+		}
+	}
+
+	// the original offer still covers everything filled so far
+	filled, err := order.OriginalOffer.Subtract(*order.RemainingOffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "filled offer")
+	}
+	original, err := filled.Add(*offer)
+	if err != nil {
+		return nil, errors.Wrap(err, "original offer")
+	}
+
+	repriced := !price.Equals(order.Price)
+	order.OriginalOffer = &original
+	order.RemainingOffer = offer.Clone()
+	order.Price = price.Clone()
+	order.UpdatedAt = now
+	// only a reduced order keeps its place at the price level, any other
+	// goes behind the orders placed before in the same block as well
+	if repriced || increased {
+		order.CreatedAt = now
+		order.Sequence = nextSequence(orderbook)
+	}
+
+	if !repriced {
+		if err := h.orderBucket.Put(db, order); err != nil {
+			return nil, errors.Wrap(err, "cannot update order")
+		}
+		if increased {
+			if err := h.orderBookBucket.Put(db, orderbook); err != nil {
+				return nil, errors.Wrap(err, "cannot update orderbook")
+			}
+		}
+		return &weave.DeliverResult{Data: order.ID}, nil
+	}
+
+	// the order is matched like a new one, and rests on the book again
+	// with whatever is left
+	decrementOpenCount(orderbook, order.Side)
+	trades := len(order.TradeIds)
+	if err := h.engine.Match(db, orderbook, order, now); err != nil {
+		return nil, errors.Wrap(err, "matching")
+	}
+	if len(order.TradeIds) != trades {
+		if err := h.engine.ScheduleStops(db, h.scheduler, orderbook, blockTime); err != nil {
+			return nil, errors.Wrap(err, "stop orders")
+		}
+	}
+	if err := h.orderBookBucket.Put(db, orderbook); err != nil {
+		return nil, errors.Wrap(err, "cannot update orderbook")
+	}
+
+	return &weave.DeliverResult{Data: order.ID}, nil
+}
+
+// ------------------- EXPIRE ORDER HANDLER -------------------
+
+// ExpireOrderHandler will handle cancelling orders that reached their
+// expiration time. It is executed by the cron ticker only
+type ExpireOrderHandler struct {
+	bank            cash.CoinMover
+	orderBucket     *OrderBucket
+	orderBookBucket *OrderBookBucket
+	tickerBucket    *TickerBucket
+}
+
+var _ weave.Handler = ExpireOrderHandler{}
+
+// NewExpireOrderHandler creates a handler that cancels an expired order.
+// The remaining offer is returned from the escrow account to the trader
+func NewExpireOrderHandler(bank cash.CoinMover) weave.Handler {
+	return ExpireOrderHandler{
+		bank:            bank,
+		orderBucket:     NewOrderBucket(),
+		orderBookBucket: NewOrderBookBucket(),
+		tickerBucket:    NewTickerBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h ExpireOrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: expireOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h ExpireOrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*Order, error) {
+	var msg ExpireOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	var order Order
+	if err := h.orderBucket.One(db, msg.OrderID, &order); err != nil {
+		return nil, errors.Wrap(err, "cannot load order")
+	}
+
+	if order.ExpiresAt == 0 {
+		return nil, errors.Wrap(errors.ErrState, "order does not expire")
+	}
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+	if order.ExpiresAt.Time().After(now) {
+		return nil, errors.Wrap(errors.ErrState, "order not expired yet")
+	}
+
+	return &order, nil
+}
+
+// Deliver refunds the remaining offer and marks the order as cancelled,
+// unless the order was filled or cancelled before it expired
+func (h ExpireOrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	order, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.OrderState != OrderState_Open {
+		return &weave.DeliverResult{Log: "order already closed"}, nil
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	if err := cancelOrder(db, h.bank, h.orderBucket, h.orderBookBucket, h.tickerBucket, order, CancelReason_Expired, weave.AsUnixTime(now)); err != nil {
+		return nil, err
+	}
+
+	return &weave.DeliverResult{Data: order.ID, Log: "order expired"}, nil
+}
+
+// ------------------- ACTIVATE STOPS HANDLER -------------------
+
+// ActivateStopsHandler will handle placing the stop orders of an orderbook
+// that are triggered by its last price. It is executed by the cron ticker only
+type ActivateStopsHandler struct {
+	scheduler       weave.Scheduler
+	engine          matchingEngine
+	orderBookBucket *OrderBookBucket
+}
+
+var _ weave.Handler = ActivateStopsHandler{}
+
+// NewActivateStopsHandler creates a handler that activates triggered stop
+// orders. Stop orders left triggered are activated by a task scheduled for the
+// next block
+func NewActivateStopsHandler(bank cash.CoinMover, scheduler weave.Scheduler) weave.Handler {
+	return ActivateStopsHandler{
+		scheduler:       scheduler,
+		engine:          newMatchingEngine(bank),
+		orderBookBucket: NewOrderBookBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h ActivateStopsHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: activateStopCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h ActivateStopsHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*OrderBook, error) {
+	var msg ActivateStopsMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	var orderbook OrderBook
+	if err := h.orderBookBucket.One(db, msg.OrderBookID, &orderbook); err != nil {
+		return nil, errors.Wrap(err, "cannot load orderbook")
+	}
+	return &orderbook, nil
+}
+
+// Deliver activates the triggered stop orders of the orderbook, and schedules
+// the next activation if any are left
+func (h ActivateStopsHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	orderbook, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	// every stop order is activated in its own cache
+	cstore, ok := db.(weave.CacheableKVStore)
+	if !ok {
+		return nil, errors.Wrap(errors.ErrHuman, "stop activation requires a cacheable store")
+	}
+
+	// this task is done, whatever is left needs a new one
+	orderbook.StopTaskID = nil
+	processed, err := h.engine.ActivateStops(cstore, orderbook, weave.AsUnixTime(now))
+	if err != nil {
+		return nil, errors.Wrap(err, "stop orders")
+	}
+	if err := h.engine.ScheduleStops(db, h.scheduler, orderbook, now); err != nil {
+		return nil, errors.Wrap(err, "stop orders")
+	}
+	if err := h.orderBookBucket.Put(db, orderbook); err != nil {
+		return nil, errors.Wrap(err, "cannot update orderbook")
+	}
+
+	return &weave.DeliverResult{Data: orderbook.ID, Log: fmt.Sprintf("%d stop orders processed", processed)}, nil
+}
+
+// cancelOrder refunds the remaining offer of an open order from the escrow,
+// and closes the order for the given reason. Closing removes it from the "open" index, the
+// open order count of its orderbook and possibly the best prices of its ticker
+func cancelOrder(db weave.KVStore, bank cash.CoinMover, orders *OrderBucket, orderbooks *OrderBookBucket, tickers *TickerBucket, order *Order, reason CancelReason, now weave.UnixTime) error {
+	if order.RemainingOffer.IsPositive() {
+		if err := bank.MoveCoins(db, EscrowAddress, order.Trader, *order.RemainingOffer); err != nil {
+			return errors.Wrap(err, "cannot refund offer")
+		}
+	}
+
+	order.OrderState = OrderState_Cancel
+	order.CancelReason = reason
+	order.UpdatedAt = now
+	if err := orders.Put(db, order); err != nil {
+		return errors.Wrap(err, "cannot update order")
+	}
+
+	var orderbook OrderBook
+	if err := orderbooks.One(db, order.OrderBookID, &orderbook); err != nil {
+		return errors.Wrap(err, "cannot load orderbook")
+	}
+	decrementOpenCount(&orderbook, order.Side)
+	if err := orderbooks.Put(db, &orderbook); err != nil {
+		return errors.Wrap(err, "cannot update orderbook")
+	}
+	if err := tickers.UpdateBestPrices(db, orders, order.OrderBookID); err != nil {
+		return errors.Wrap(err, "cannot update ticker")
+	}
+	return nil
+}
+
+// ------------------- STOP ORDER HANDLER -------------------
+
+// StopOrderHandler will handle creating stop orders
+type StopOrderHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	orderBookBucket *OrderBookBucket
+	stopOrderBucket *StopOrderBucket
+	tickerBucket    *TickerBucket
+}
+
+var _ weave.Handler = StopOrderHandler{}
+
+// NewStopOrderHandler creates a handler that allows traders to place stop
+// orders on an existing orderbook. The offer is moved from the trader into
+// the escrow account until the stop order is triggered or cancelled
+func NewStopOrderHandler(auth x.Authenticator, bank cash.CoinMover) weave.Handler {
+	return StopOrderHandler{
+		auth:            auth,
+		bank:            bank,
+		orderBookBucket: NewOrderBookBucket(),
+		stopOrderBucket: NewStopOrderBucket(),
+		tickerBucket:    NewTickerBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h StopOrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, _, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: newStopOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver.
+// It returns the message along with the side of the book inferred from
+// the offer ticker
+func (h StopOrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*CreateStopOrderMsg, Side, error) {
+	var msg CreateStopOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, Side_Invalid, errors.Wrap(err, "load msg")
+	}
+
+	// Trader must authorize paying the offer
+	if !h.auth.HasAddress(ctx, msg.Trader) {
+		return nil, Side_Invalid, errors.Wrap(errors.ErrUnauthorized, "only trader can create stop order")
+	}
+
+	var orderbook OrderBook
+	if err := h.orderBookBucket.One(db, msg.OrderBookID, &orderbook); err != nil {
+		return nil, Side_Invalid, errors.Wrap(err, "cannot load orderbook")
+	}
+
+	side, err := orderSide(&orderbook, msg.Offer.Ticker)
+	if err != nil {
+		return nil, Side_Invalid, err
+	}
+	if err := orderbook.checkOrderRules(*msg.Offer, msg.Price, msg.OrderType); err != nil {
+		return nil, Side_Invalid, err
+	}
+
+	// stop orders are only activated by trades, one that is triggered
+	// already must be placed as an order
+	ticker, err := h.tickerBucket.load(db, orderbook.ID)
+	if err != nil {
+		return nil, Side_Invalid, err
+	}
+	if ticker.LastPrice != nil && triggered(msg.Direction, msg.TriggerPrice, ticker.LastPrice) {
+		return nil, Side_Invalid, errors.Wrap(errors.ErrState, "stop order is triggered by the last price")
+	}
+
+	return &msg, side, nil
+}
+
+// Deliver escrows the offer and stores a new pending stop order if all
+// preconditions are met
+func (h StopOrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	msg, side, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	if err := h.bank.MoveCoins(db, msg.Trader, EscrowAddress, *msg.Offer); err != nil {
+		return nil, errors.Wrap(err, "cannot escrow offer")
+	}
+
+	stop := &StopOrder{
+		Metadata:            &weave.Metadata{Schema: 1},
+		Trader:              msg.Trader,
+		OrderBookID:         msg.OrderBookID,
+		Side:                side,
+		State:               StopOrderState_Pending,
+		Direction:           msg.Direction,
+		TriggerPrice:        msg.TriggerPrice.Clone(),
+		Offer:               msg.Offer.Clone(),
+		Price:               msg.Price.Clone(),
+		OrderType:           msg.OrderType,
+		TimeInForce:         msg.TimeInForce,
+		SelfTradePrevention: msg.SelfTradePrevention,
+		DiscountedFee:       msg.DiscountedFee,
+		CreatedAt:           weave.AsUnixTime(now),
+		UpdatedAt:           weave.AsUnixTime(now),
+	}
+	if err := h.stopOrderBucket.Put(db, stop); err != nil {
+		return nil, errors.Wrap(err, "cannot store stop order")
+	}
+
+	return &weave.DeliverResult{Data: stop.ID}, nil
+}
+
+// ------------------- CANCEL STOP ORDER HANDLER -------------------
+
+// CancelStopOrderHandler will handle cancelling pending stop orders
+type CancelStopOrderHandler struct {
+	auth            x.Authenticator
+	bank            cash.CoinMover
+	stopOrderBucket *StopOrderBucket
+}
+
+var _ weave.Handler = CancelStopOrderHandler{}
+
+// NewCancelStopOrderHandler creates a handler that allows the trader to
+// cancel a stop order that was not triggered yet. The offer is returned
+// from the escrow account to the trader
+func NewCancelStopOrderHandler(auth x.Authenticator, bank cash.CoinMover) weave.Handler {
+	return CancelStopOrderHandler{
+		auth:            auth,
+		bank:            bank,
+		stopOrderBucket: NewStopOrderBucket(),
+	}
+}
+
+// Check just verifies it is properly formed and returns
+// the cost of executing it.
+func (h CancelStopOrderHandler) Check(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.CheckResult, error) {
+	_, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weave.CheckResult{GasAllocated: cancelStopOrderCost}, nil
+}
+
+// validate does all common pre-processing between Check and Deliver
+func (h CancelStopOrderHandler) validate(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*StopOrder, error) {
+	var msg CancelStopOrderMsg
+
+	if err := weave.LoadMsg(tx, &msg); err != nil {
+		return nil, errors.Wrap(err, "load msg")
+	}
+
+	var stop StopOrder
+	if err := h.stopOrderBucket.One(db, msg.StopOrderID, &stop); err != nil {
+		return nil, errors.Wrap(err, "cannot load stop order")
+	}
+
+	// Only the trader who created the stop order can cancel it
+	if !h.auth.HasAddress(ctx, stop.Trader) {
+		return nil, errors.Wrap(errors.ErrUnauthorized, "only trader can cancel stop order")
+	}
+
+	if stop.State != StopOrderState_Pending {
+		return nil, errors.Wrapf(errors.ErrState, "stop order is %s", stop.State)
+	}
+
+	return &stop, nil
+}
+
+// Deliver refunds the offer and marks the stop order as cancelled
+// if all preconditions are met
+func (h CancelStopOrderHandler) Deliver(ctx weave.Context, db weave.KVStore, tx weave.Tx) (*weave.DeliverResult, error) {
+	stop, err := h.validate(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := weave.BlockTime(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "block time")
+	}
+
+	if err := h.bank.MoveCoins(db, EscrowAddress, stop.Trader, *stop.Offer); err != nil {
+		return nil, errors.Wrap(err, "cannot refund offer")
+	}
+	stop.State = StopOrderState_Cancel
+	stop.UpdatedAt = weave.AsUnixTime(now)
+	if err := h.stopOrderBucket.Put(db, stop); err != nil {
+		return nil, errors.Wrap(err, "cannot update stop order")
+	}
+
+	return &weave.DeliverResult{Data: stop.ID}, nil
+}
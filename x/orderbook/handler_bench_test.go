@@ -0,0 +1,109 @@
+package orderbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/migration"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/x/cash"
+)
+
+// BenchmarkCreateOrder places orders on a book that already holds resting
+// orders on both sides.
+func BenchmarkCreateOrder(b *testing.B) {
+	cases := map[string]struct {
+		// msg returns the order placed in the nth iteration
+		msg func(n int, maker, taker weave.Address) *CreateOrderMsg
+	}{
+		"resting": {
+			msg: func(n int, maker, taker weave.Address) *CreateOrderMsg {
+				return benchOrderMsg(maker, coin.NewCoinp(1, 0, "BTC"), NewAmountp(int64(3+n%50), 0))
+			},
+		},
+		"filled": {
+			msg: func(n int, maker, taker weave.Address) *CreateOrderMsg {
+				if n%2 == 0 {
+					return benchOrderMsg(maker, coin.NewCoinp(1, 0, "BTC"), NewAmountp(2, 0))
+				}
+				return benchOrderMsg(taker, coin.NewCoinp(2, 0, "ETH"), NewAmountp(0, 500000000))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		b.Run(name, func(b *testing.B) {
+			maker := weavetest.NewCondition()
+			taker := weavetest.NewCondition()
+			auth := &weavetest.Auth{Signers: []weave.Condition{maker, taker}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+			market := &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    weavetest.NewCondition().Address(),
+				Name:     "bench",
+			}
+			if err := NewMarketBucket().Put(kv, market); err != nil {
+				b.Fatal(err)
+			}
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			if err := NewOrderBookBucket().Put(kv, orderbook); err != nil {
+				b.Fatal(err)
+			}
+			for _, addr := range []weave.Address{maker.Address(), taker.Address()} {
+				for _, ticker := range []string{"BTC", "ETH"} {
+					if err := ctrl.CoinMint(kv, addr, coin.NewCoin(100000000, 0, ticker)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			now := time.Now()
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+
+			// resting orders on both sides, that do not cross
+			for i := 0; i < 100; i++ {
+				for _, msg := range []*CreateOrderMsg{
+					benchOrderMsg(maker.Address(), coin.NewCoinp(1, 0, "BTC"), NewAmountp(int64(3+i), 0)),
+					benchOrderMsg(taker.Address(), coin.NewCoinp(1, 0, "ETH"), NewAmountp(0, int64(100000000-i))),
+				} {
+					if _, err := h.Deliver(ctx, kv, &weavetest.Tx{Msg: msg}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// one order per block, as trades of the same block share an index entry
+				ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now.Add(time.Duration(i)*time.Second)), int64(i+1))
+				tx := &weavetest.Tx{Msg: tc.msg(i, maker.Address(), taker.Address())}
+				if _, err := h.Deliver(ctx, kv, tx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchOrderMsg(trader weave.Address, offer *coin.Coin, price *Amount) *CreateOrderMsg {
+	return &CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      trader,
+		OrderBookID: weavetest.SequenceID(1),
+		Offer:       offer,
+		Price:       price,
+	}
+}
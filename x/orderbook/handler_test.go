@@ -1,20 +1,321 @@
 package orderbook
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
 	"github.com/iov-one/weave/migration"
 	"github.com/iov-one/weave/store"
 	"github.com/iov-one/weave/weavetest"
 	"github.com/iov-one/weave/weavetest/assert"
+	"github.com/iov-one/weave/x/cash"
 )
 
 type checkErr func(error) bool
 
 func noErr(err error) bool { return err == nil }
 
+func TestCreateMarket(t *testing.T) {
+	owner := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	meta := &weave.Metadata{Schema: 1}
+
+	existing := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    other.Address(),
+		Name:     "taken-name",
+	}
+
+	cases := map[string]struct {
+		signers        []weave.Condition
+		msg            weave.Msg
+		expected       *Market
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"nil message": {
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &CreateMarketMsg{
+				Metadata: meta,
+				Owner:    owner.Address(),
+				Name:     "new-market",
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"duplicated name": {
+			signers: []weave.Condition{owner},
+			msg: &CreateMarketMsg{
+				Metadata: meta,
+				Owner:    owner.Address(),
+				Name:     "taken-name",
+			},
+			wantCheckErr:   errors.ErrDuplicate,
+			wantDeliverErr: errors.ErrDuplicate,
+		},
+		"success": {
+			signers: []weave.Condition{owner},
+			msg: &CreateMarketMsg{
+				Metadata: meta,
+				Owner:    owner.Address(),
+				Name:     "new-market",
+			},
+			expected: &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				ID:       weavetest.SequenceID(2),
+				Owner:    owner.Address(),
+				Name:     "new-market",
+			},
+		},
+		"success with fees": {
+			signers: []weave.Condition{owner},
+			msg: &CreateMarketMsg{
+				Metadata:     meta,
+				Owner:        owner.Address(),
+				Name:         "fee-market",
+				MakerFee:     NewAmountp(0, 1000000),
+				TakerFee:     NewAmountp(0, 2000000),
+				FeeCollector: other.Address(),
+			},
+			expected: &Market{
+				Metadata:     &weave.Metadata{Schema: 1},
+				ID:           weavetest.SequenceID(2),
+				Owner:        owner.Address(),
+				Name:         "fee-market",
+				MakerFee:     NewAmountp(0, 1000000),
+				TakerFee:     NewAmountp(0, 2000000),
+				FeeCollector: other.Address(),
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			h := NewMarketHandler(auth)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName)
+
+			markets := NewMarketBucket()
+			err := markets.Put(kv, existing.Copy().(*Market))
+			assert.Nil(t, err)
+
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(nil, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			dres, err := h.Deliver(nil, kv, tx)
+			if !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.expected != nil {
+				var stored Market
+				err = markets.One(kv, dres.Data, &stored)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, &stored)
+			}
+		})
+	}
+}
+
+func TestUpdateMarketOwner(t *testing.T) {
+	owner := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+	// new owner is a multisig contract
+	contract := weave.NewCondition("multisig", "usage", weavetest.SequenceID(1))
+
+	meta := &weave.Metadata{Schema: 1}
+
+	market := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    owner.Address(),
+		Name:     "main-market",
+	}
+	marketID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		signers        []weave.Condition
+		msg            weave.Msg
+		expected       *Market
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: meta,
+				MarketID: marketID,
+				NewOwner: other.Address(),
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"unknown market": {
+			signers: []weave.Condition{owner},
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: meta,
+				MarketID: weavetest.SequenceID(9),
+				NewOwner: contract.Address(),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"success": {
+			signers: []weave.Condition{owner},
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: meta,
+				MarketID: marketID,
+				NewOwner: contract.Address(),
+			},
+			expected: &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				ID:       marketID,
+				Owner:    contract.Address(),
+				Name:     "main-market",
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			h := NewUpdateMarketOwnerHandler(auth)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName)
+
+			markets := NewMarketBucket()
+			err := markets.Put(kv, market.Copy().(*Market))
+			assert.Nil(t, err)
+
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(nil, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			if _, err := h.Deliver(nil, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.expected != nil {
+				var stored Market
+				err = markets.One(kv, marketID, &stored)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, &stored)
+			}
+		})
+	}
+}
+
+func TestUpdateConfiguration(t *testing.T) {
+	owner := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	conf := &Configuration{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Owner:       owner.Address(),
+		FeeTicker:   "IDEX",
+		FeeDiscount: NewAmountp(0, 250000000),
+		FeePrices:   []*FeePrice{{Ticker: "ETH", Price: NewAmountp(2, 0)}},
+	}
+
+	cases := map[string]struct {
+		signers        []weave.Condition
+		msg            weave.Msg
+		expected       *Configuration
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata:    &weave.Metadata{Schema: 1},
+					FeeDiscount: NewAmountp(0, 500000000),
+				},
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"success": {
+			signers: []weave.Condition{owner},
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata:    &weave.Metadata{Schema: 1},
+					FeeDiscount: NewAmountp(0, 500000000),
+					FeePrices: []*FeePrice{
+						{Ticker: "ETH", Price: NewAmountp(3, 0)},
+						{Ticker: "BTC", Price: NewAmountp(40, 0)},
+					},
+				},
+			},
+			expected: &Configuration{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Owner:       owner.Address(),
+				FeeTicker:   "IDEX",
+				FeeDiscount: NewAmountp(0, 500000000),
+				FeePrices: []*FeePrice{
+					{Ticker: "ETH", Price: NewAmountp(3, 0)},
+					{Ticker: "BTC", Price: NewAmountp(40, 0)},
+				},
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			h := NewConfigHandler(auth)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName)
+			assert.Nil(t, gconf.Save(kv, packageName, conf))
+
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(nil, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			if _, err := h.Deliver(nil, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.expected != nil {
+				var stored Configuration
+				assert.Nil(t, gconf.Load(kv, packageName, &stored))
+				assert.Equal(t, tc.expected, &stored)
+			}
+		})
+	}
+}
+
 func TestCreateOrderbook(t *testing.T) {
 	perm := weave.NewCondition("sig", "ed25519", []byte{1, 2, 3})
 	perm2 := weave.NewCondition("sig", "ed25519", []byte{4, 5, 6})
@@ -170,3 +471,1415 @@ func TestCreateOrderbook(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	orderBookID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		signers        []weave.Condition
+		msg            weave.Msg
+		expected       *Order
+		expectedAsks   int64
+		expectedBids   int64
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(2, 0),
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"unknown orderbook": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: weavetest.SequenceID(7),
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(2, 0),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"ticker not traded on orderbook": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "IOV"),
+				Price:       NewAmountp(2, 0),
+			},
+			wantCheckErr:   errors.ErrCurrency,
+			wantDeliverErr: errors.ErrCurrency,
+		},
+		"expiration in the past": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(2, 0),
+				ExpiresAt:   weave.AsUnixTime(now.Add(-time.Minute)),
+			},
+			wantCheckErr:   errors.ErrInput,
+			wantDeliverErr: errors.ErrInput,
+		},
+		"insufficient funds": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(5000, 0, "BTC"),
+				Price:       NewAmountp(2, 0),
+			},
+			wantDeliverErr: errors.ErrAmount,
+		},
+		"success, ask side": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(2, 500),
+			},
+			expected: &Order{
+				Metadata:       &weave.Metadata{Schema: 1},
+				ID:             weavetest.SequenceID(1),
+				Trader:         trader.Address(),
+				OrderBookID:    orderBookID,
+				Side:           Side_Ask,
+				OrderState:     OrderState_Open,
+				OriginalOffer:  coin.NewCoinp(10, 0, "BTC"),
+				RemainingOffer: coin.NewCoinp(10, 0, "BTC"),
+				Price:          NewAmountp(2, 500),
+				CreatedAt:      weave.AsUnixTime(now),
+				UpdatedAt:      weave.AsUnixTime(now),
+				Sequence:       1,
+			},
+			expectedAsks: 1,
+		},
+		"success, bid side": {
+			signers: []weave.Condition{trader},
+			msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(20, 0, "ETH"),
+				Price:       NewAmountp(0, 400000000),
+			},
+			expected: &Order{
+				Metadata:       &weave.Metadata{Schema: 1},
+				ID:             weavetest.SequenceID(1),
+				Trader:         trader.Address(),
+				OrderBookID:    orderBookID,
+				Side:           Side_Bid,
+				OrderState:     OrderState_Open,
+				OriginalOffer:  coin.NewCoinp(20, 0, "ETH"),
+				RemainingOffer: coin.NewCoinp(20, 0, "ETH"),
+				Price:          NewAmountp(0, 400000000),
+				CreatedAt:      weave.AsUnixTime(now),
+				UpdatedAt:      weave.AsUnixTime(now),
+				Sequence:       1,
+			},
+			expectedBids: 1,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbooks := NewOrderBookBucket()
+			err := orderbooks.Put(kv, orderbook.Copy().(*OrderBook))
+			assert.Nil(t, err)
+
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			dres, err := h.Deliver(ctx, kv, tx)
+			if !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.expected != nil {
+				var stored Order
+				err = NewOrderBucket().One(kv, dres.Data, &stored)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected, &stored)
+
+				escrowed, err := ctrl.Balance(kv, EscrowAddress)
+				assert.Nil(t, err)
+				if !escrowed.Contains(*tc.expected.OriginalOffer) {
+					t.Fatalf("offer not escrowed: %v", escrowed)
+				}
+
+				var ob OrderBook
+				err = orderbooks.One(kv, orderBookID, &ob)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expectedAsks, ob.TotalAskCount)
+				assert.Equal(t, tc.expectedBids, ob.TotalBidCount)
+			}
+		})
+	}
+}
+
+func TestCreatePostOnlyOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+
+	// the best ask rests at 20 ETH per BTC, so bids cross up to 0.05 BTC per ETH
+	cases := map[string]struct {
+		tickSize       *Amount
+		mode           PostOnly
+		price          *Amount
+		wantPrice      *Amount
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"not crossing": {
+			tickSize:  NewAmountp(0, 10000000),
+			mode:      PostOnly_Reject,
+			price:     NewAmountp(0, 60000000),
+			wantPrice: NewAmountp(0, 60000000),
+		},
+		"crossing is rejected": {
+			tickSize:       NewAmountp(0, 10000000),
+			mode:           PostOnly_Reject,
+			price:          NewAmountp(0, 40000000),
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"at the best price is rejected": {
+			tickSize:       NewAmountp(0, 10000000),
+			mode:           PostOnly_Reject,
+			price:          NewAmountp(0, 50000000),
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"crossing is repriced one tick away": {
+			tickSize:  NewAmountp(0, 10000000),
+			mode:      PostOnly_Reprice,
+			price:     NewAmountp(0, 40000000),
+			wantPrice: NewAmountp(0, 60000000),
+		},
+		"repriced without tick size": {
+			mode:      PostOnly_Reprice,
+			price:     NewAmountp(0, 40000000),
+			wantPrice: NewAmountp(0, 50000001),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{trader, other}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+				TickSize:  tc.tickSize,
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+			assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(5, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			_, err := h.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      other.Address(),
+				OrderBookID: orderbook.ID,
+				Offer:       coin.NewCoinp(5, 0, "BTC"),
+				Price:       NewAmountp(20, 0),
+			}})
+			assert.Nil(t, err)
+
+			tx := &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader.Address(),
+				OrderBookID: orderbook.ID,
+				Offer:       coin.NewCoinp(100, 0, "ETH"),
+				Price:       tc.price,
+				PostOnly:    tc.mode,
+			}}
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			if _, err := h.Deliver(ctx, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+
+			balance, err := ctrl.Balance(kv, trader.Address())
+			assert.Nil(t, err)
+			if tc.wantDeliverErr != nil {
+				// nothing was escrowed
+				assert.Equal(t, coin.NewCoin(100, 0, "ETH"), balanceOf(balance, "ETH"))
+				return
+			}
+
+			// the order rests on the book without any trade
+			var order Order
+			assert.Nil(t, NewOrderBucket().One(kv, weavetest.SequenceID(2), &order))
+			assert.Equal(t, OrderState_Open, order.OrderState)
+			assert.Equal(t, tc.mode, order.PostOnly)
+			assert.Equal(t, tc.wantPrice, order.Price)
+			assert.Equal(t, 0, len(order.TradeIds))
+			assert.Equal(t, coin.NewCoin(0, 0, "ETH"), balanceOf(balance, "ETH"))
+			assert.Equal(t, coin.NewCoin(0, 0, "BTC"), balanceOf(balance, "BTC"))
+		})
+	}
+}
+
+func TestCreateOrders(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	orderBookID := weavetest.SequenceID(1)
+
+	ask := func(whole int64, price *Amount) *BatchOrder {
+		return &BatchOrder{OrderBookID: orderBookID, Offer: coin.NewCoinp(whole, 0, "BTC"), Price: price}
+	}
+	bid := func(whole int64, price *Amount) *BatchOrder {
+		return &BatchOrder{OrderBookID: orderBookID, Offer: coin.NewCoinp(whole, 0, "ETH"), Price: price}
+	}
+
+	cases := map[string]struct {
+		signers        []weave.Condition
+		orders         []*BatchOrder
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+		wantAskCount   int64
+		wantBidCount   int64
+		wantTraderBTC  coin.Coin
+		wantTraderETH  coin.Coin
+	}{
+		"success": {
+			signers: []weave.Condition{trader},
+			orders: []*BatchOrder{
+				ask(1, NewAmountp(20, 0)),
+				ask(2, NewAmountp(21, 0)),
+				bid(16, NewAmountp(0, 62500000)),
+			},
+			wantAskCount:  2,
+			wantBidCount:  1,
+			wantTraderBTC: coin.NewCoin(7, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(84, 0, "ETH"),
+		},
+		"orders are placed in turn": {
+			signers: []weave.Condition{trader},
+			orders: []*BatchOrder{
+				ask(1, NewAmountp(20, 0)),
+				{OrderBookID: orderBookID, Offer: coin.NewCoinp(20, 0, "ETH"), Price: NewAmountp(0, 50000000), PostOnly: PostOnly_Reject},
+			},
+			wantDeliverErr: errors.ErrState,
+		},
+		"invalid order fails the batch": {
+			signers: []weave.Condition{trader},
+			orders: []*BatchOrder{
+				ask(1, NewAmountp(20, 0)),
+				{OrderBookID: orderBookID, Offer: coin.NewCoinp(1, 0, "DOGE"), Price: NewAmountp(20, 0)},
+			},
+			wantCheckErr:   errors.ErrCurrency,
+			wantDeliverErr: errors.ErrCurrency,
+		},
+		"insufficient funds fails the batch": {
+			signers: []weave.Condition{trader},
+			orders: []*BatchOrder{
+				ask(5, NewAmountp(20, 0)),
+				ask(6, NewAmountp(21, 0)),
+			},
+			wantDeliverErr: errors.ErrAmount,
+		},
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			orders: []*BatchOrder{
+				ask(1, NewAmountp(20, 0)),
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewCreateOrdersHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(10, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			tx := &weavetest.Tx{Msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader.Address(),
+				Orders:   tc.orders,
+			}}
+			check, err := h.Check(ctx, kv, tx)
+			if !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			if err == nil {
+				assert.Equal(t, newOrderCost*int64(len(tc.orders)), check.GasAllocated)
+			}
+
+			// a failed transaction is not written
+			cache := kv.CacheWrap()
+			res, err := h.Deliver(ctx, cache, tx)
+			if !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+			if tc.wantDeliverErr != nil {
+				cache.Discard()
+				balance, err := ctrl.Balance(kv, trader.Address())
+				assert.Nil(t, err)
+				assert.Equal(t, coin.NewCoin(10, 0, "BTC"), balanceOf(balance, "BTC"))
+				assert.Equal(t, coin.NewCoin(100, 0, "ETH"), balanceOf(balance, "ETH"))
+				return
+			}
+			assert.Nil(t, cache.Write())
+
+			// the IDs of all orders are returned in the order they were placed
+			var wantIDs []byte
+			for i := range tc.orders {
+				wantIDs = append(wantIDs, weavetest.SequenceID(uint64(i+1))...)
+			}
+			assert.Equal(t, wantIDs, res.Data)
+
+			var ob OrderBook
+			assert.Nil(t, NewOrderBookBucket().One(kv, orderBookID, &ob))
+			assert.Equal(t, tc.wantAskCount, ob.TotalAskCount)
+			assert.Equal(t, tc.wantBidCount, ob.TotalBidCount)
+
+			balance, err := ctrl.Balance(kv, trader.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTraderBTC, balanceOf(balance, "BTC"))
+			assert.Equal(t, tc.wantTraderETH, balanceOf(balance, "ETH"))
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	orderBookID := weavetest.SequenceID(1)
+
+	// the open order placed before every test case
+	openOrderID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		signers []weave.Condition
+		// cancel the open order before running the test message
+		cancelled      bool
+		msg            weave.Msg
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &CancelOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"unknown order": {
+			signers: []weave.Condition{trader},
+			msg: &CancelOrderMsg{
+				Metadata: meta,
+				OrderID:  weavetest.SequenceID(7),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"order already cancelled": {
+			signers:   []weave.Condition{trader},
+			cancelled: true,
+			msg: &CancelOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"success": {
+			signers: []weave.Condition{trader},
+			msg: &CancelOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewCancelOrderHandler(auth, ctrl)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbooks := NewOrderBookBucket()
+			assert.Nil(t, orderbooks.Put(kv, orderbook.Copy().(*OrderBook)))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "BTC")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+
+			// place the order that is cancelled
+			create := NewOrderHandler(&weavetest.Auth{Signers: []weave.Condition{trader}}, ctrl, &weavetest.Cron{})
+			_, err := create.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(20, 0),
+			}})
+			assert.Nil(t, err)
+			if tc.cancelled {
+				cancel := NewCancelOrderHandler(&weavetest.Auth{Signers: []weave.Condition{trader}}, ctrl)
+				_, err := cancel.Deliver(ctx, kv, &weavetest.Tx{Msg: &CancelOrderMsg{
+					Metadata: meta,
+					OrderID:  openOrderID,
+				}})
+				assert.Nil(t, err)
+			}
+
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			if _, err := h.Deliver(ctx, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.wantDeliverErr == nil {
+				var order Order
+				assert.Nil(t, NewOrderBucket().One(kv, openOrderID, &order))
+				assert.Equal(t, OrderState_Cancel, order.OrderState)
+				assert.Equal(t, CancelReason_Trader, order.CancelReason)
+
+				// the order is no longer listed as open
+				var open []Order
+				index, err := BuildOpenOrderIndex(&Order{OrderBookID: orderBookID, Side: Side_Ask, OrderState: OrderState_Open, Price: NewAmountp(20, 0), CreatedAt: weave.AsUnixTime(now)})
+				assert.Nil(t, err)
+				assert.Nil(t, NewOrderBucket().ByIndex(kv, "open", index, &open))
+				assert.Equal(t, 0, len(open))
+
+				balance, err := ctrl.Balance(kv, trader.Address())
+				assert.Nil(t, err)
+				assert.Equal(t, coin.NewCoin(100, 0, "BTC"), balanceOf(balance, "BTC"))
+
+				var ob OrderBook
+				assert.Nil(t, orderbooks.One(kv, orderBookID, &ob))
+				assert.Equal(t, int64(0), ob.TotalAskCount)
+			}
+		})
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+	btcBookID := weavetest.SequenceID(1)
+	lskBookID := weavetest.SequenceID(2)
+
+	// the trader has an ask and a bid on the BTC/ETH book, and an ask on the
+	// ETH/LSK book. The other trader has an ask on the BTC/ETH book
+	cases := map[string]struct {
+		signers        []weave.Condition
+		orderBookID    []byte
+		side           Side
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+		wantCancelled  [][]byte
+		wantBTCAsks    int64
+		wantBTCBids    int64
+	}{
+		"all orders": {
+			signers:       []weave.Condition{trader},
+			wantCancelled: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(2), weavetest.SequenceID(3)},
+			wantBTCAsks:   1,
+		},
+		"one orderbook": {
+			signers:       []weave.Condition{trader},
+			orderBookID:   btcBookID,
+			wantCancelled: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(2)},
+			wantBTCAsks:   1,
+		},
+		"one side of an orderbook": {
+			signers:       []weave.Condition{trader},
+			orderBookID:   btcBookID,
+			side:          Side_Bid,
+			wantCancelled: [][]byte{weavetest.SequenceID(2)},
+			wantBTCAsks:   2,
+		},
+		"one side of all orderbooks": {
+			signers:       []weave.Condition{trader},
+			side:          Side_Ask,
+			wantCancelled: [][]byte{weavetest.SequenceID(1), weavetest.SequenceID(3)},
+			wantBTCAsks:   1,
+			wantBTCBids:   1,
+		},
+		"nothing to cancel": {
+			signers:     []weave.Condition{trader},
+			orderBookID: weavetest.SequenceID(7),
+			wantBTCAsks: 2,
+			wantBTCBids: 1,
+		},
+		"unauthorized": {
+			signers:        []weave.Condition{other},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewCancelAllOrdersHandler(auth, ctrl)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbooks := NewOrderBookBucket()
+			assert.Nil(t, orderbooks.Put(kv, &OrderBook{
+				Metadata:  meta,
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}))
+			assert.Nil(t, orderbooks.Put(kv, &OrderBook{
+				Metadata:  meta,
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "ETH",
+				BidTicker: "LSK",
+			}))
+			for _, c := range []weave.Condition{trader, other} {
+				assert.Nil(t, ctrl.CoinMint(kv, c.Address(), coin.NewCoin(10, 0, "BTC")))
+				assert.Nil(t, ctrl.CoinMint(kv, c.Address(), coin.NewCoin(100, 0, "ETH")))
+			}
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			create := NewOrderHandler(&weavetest.Auth{Signers: []weave.Condition{trader, other}}, ctrl, &weavetest.Cron{})
+			book := []*CreateOrderMsg{
+				{Trader: trader.Address(), OrderBookID: btcBookID, Offer: coin.NewCoinp(1, 0, "BTC"), Price: NewAmountp(20, 0)},
+				{Trader: trader.Address(), OrderBookID: btcBookID, Offer: coin.NewCoinp(10, 0, "ETH"), Price: NewAmountp(0, 62500000)},
+				{Trader: trader.Address(), OrderBookID: lskBookID, Offer: coin.NewCoinp(1, 0, "ETH"), Price: NewAmountp(5, 0)},
+				{Trader: other.Address(), OrderBookID: btcBookID, Offer: coin.NewCoinp(1, 0, "BTC"), Price: NewAmountp(22, 0)},
+			}
+			for _, msg := range book {
+				msg.Metadata = meta
+				_, err := create.Deliver(ctx, kv, &weavetest.Tx{Msg: msg})
+				assert.Nil(t, err)
+			}
+
+			tx := &weavetest.Tx{Msg: &CancelAllOrdersMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: tc.orderBookID,
+				Side:        tc.side,
+			}}
+			check, err := h.Check(ctx, kv, tx)
+			if !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			if err == nil {
+				assert.Equal(t, newOrderCost+cancelAllOrdersCost*int64(len(tc.wantCancelled)), check.GasAllocated)
+			}
+			res, err := h.Deliver(ctx, kv, tx)
+			if !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+			if tc.wantDeliverErr != nil {
+				return
+			}
+
+			cancelled := make(map[string]bool)
+			wantIDs := []byte{}
+			for _, id := range tc.wantCancelled {
+				cancelled[string(id)] = true
+				wantIDs = append(wantIDs, id...)
+			}
+			assert.Equal(t, wantIDs, res.Data)
+
+			for i := range book {
+				id := weavetest.SequenceID(uint64(i + 1))
+				var order Order
+				assert.Nil(t, NewOrderBucket().One(kv, id, &order))
+				if cancelled[string(id)] {
+					assert.Equal(t, OrderState_Cancel, order.OrderState)
+					assert.Equal(t, CancelReason_Trader, order.CancelReason)
+				} else {
+					assert.Equal(t, OrderState_Open, order.OrderState)
+				}
+			}
+
+			var ob OrderBook
+			assert.Nil(t, orderbooks.One(kv, btcBookID, &ob))
+			assert.Equal(t, tc.wantBTCAsks, ob.TotalAskCount)
+			assert.Equal(t, tc.wantBTCBids, ob.TotalBidCount)
+		})
+	}
+}
+
+func TestReplaceOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+	orderBookID := weavetest.SequenceID(1)
+
+	// the order of the trader that is replaced, selling 10 BTC at 20 ETH. It
+	// rests before another ask at the same price and one at 21 ETH, and
+	// above a bid for 2 BTC at 16 ETH
+	orderID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		signers []weave.Condition
+		// cancel the order before running the test message
+		cancelled bool
+		// place the book and replace the order all in a single block
+		sameBlock      bool
+		price          *Amount
+		offer          *coin.Coin
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+		// open asks in the order they are matched
+		wantAsks      [][]byte
+		wantAskCount  int64
+		wantState     OrderState
+		wantRemaining coin.Coin
+		wantOriginal  coin.Coin
+		wantTrades    int
+		wantTraderBTC coin.Coin
+		wantTraderETH coin.Coin
+	}{
+		"reduce keeps priority": {
+			signers:       []weave.Condition{trader},
+			offer:         coin.NewCoinp(4, 0, "BTC"),
+			wantAsks:      [][]byte{orderID, weavetest.SequenceID(2), weavetest.SequenceID(3)},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(4, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(4, 0, "BTC"),
+			wantTraderBTC: coin.NewCoin(16, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(0, 0, "ETH"),
+		},
+		"increase loses priority": {
+			signers:       []weave.Condition{trader},
+			offer:         coin.NewCoinp(12, 0, "BTC"),
+			wantAsks:      [][]byte{weavetest.SequenceID(2), orderID, weavetest.SequenceID(3)},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(12, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(12, 0, "BTC"),
+			wantTraderBTC: coin.NewCoin(8, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(0, 0, "ETH"),
+		},
+		"increase in the same block loses priority": {
+			signers:       []weave.Condition{trader},
+			sameBlock:     true,
+			offer:         coin.NewCoinp(12, 0, "BTC"),
+			wantAsks:      [][]byte{weavetest.SequenceID(2), orderID, weavetest.SequenceID(3)},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(12, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(12, 0, "BTC"),
+			wantTraderBTC: coin.NewCoin(8, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(0, 0, "ETH"),
+		},
+		"reduce in the same block keeps priority": {
+			signers:       []weave.Condition{trader},
+			sameBlock:     true,
+			offer:         coin.NewCoinp(4, 0, "BTC"),
+			wantAsks:      [][]byte{orderID, weavetest.SequenceID(2), weavetest.SequenceID(3)},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(4, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(4, 0, "BTC"),
+			wantTraderBTC: coin.NewCoin(16, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(0, 0, "ETH"),
+		},
+		"new price loses priority": {
+			signers:       []weave.Condition{trader},
+			price:         NewAmountp(21, 0),
+			wantAsks:      [][]byte{weavetest.SequenceID(2), weavetest.SequenceID(3), orderID},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(10, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(10, 0, "BTC"),
+			wantTraderBTC: coin.NewCoin(10, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(0, 0, "ETH"),
+		},
+		"crossing price is matched": {
+			signers:       []weave.Condition{trader},
+			price:         NewAmountp(16, 0),
+			wantAsks:      [][]byte{orderID, weavetest.SequenceID(2), weavetest.SequenceID(3)},
+			wantAskCount:  3,
+			wantState:     OrderState_Open,
+			wantRemaining: coin.NewCoin(8, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(10, 0, "BTC"),
+			wantTrades:    1,
+			wantTraderBTC: coin.NewCoin(10, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(32, 0, "ETH"),
+		},
+		"crossing price and size is filled": {
+			signers:       []weave.Condition{trader},
+			price:         NewAmountp(16, 0),
+			offer:         coin.NewCoinp(1, 0, "BTC"),
+			wantAsks:      [][]byte{weavetest.SequenceID(2), weavetest.SequenceID(3)},
+			wantAskCount:  2,
+			wantState:     OrderState_Done,
+			wantRemaining: coin.NewCoin(0, 0, "BTC"),
+			wantOriginal:  coin.NewCoin(1, 0, "BTC"),
+			wantTrades:    1,
+			wantTraderBTC: coin.NewCoin(19, 0, "BTC"),
+			wantTraderETH: coin.NewCoin(16, 0, "ETH"),
+		},
+		"unchanged": {
+			signers:        []weave.Condition{trader},
+			price:          NewAmountp(20, 0),
+			offer:          coin.NewCoinp(10, 0, "BTC"),
+			wantCheckErr:   errors.ErrInput,
+			wantDeliverErr: errors.ErrInput,
+		},
+		"other ticker": {
+			signers:        []weave.Condition{trader},
+			offer:          coin.NewCoinp(10, 0, "ETH"),
+			wantCheckErr:   errors.ErrCurrency,
+			wantDeliverErr: errors.ErrCurrency,
+		},
+		"insufficient funds": {
+			signers:        []weave.Condition{trader},
+			offer:          coin.NewCoinp(30, 0, "BTC"),
+			wantDeliverErr: errors.ErrAmount,
+		},
+		"unauthorized": {
+			signers:        []weave.Condition{other},
+			price:          NewAmountp(21, 0),
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"order cancelled": {
+			signers:        []weave.Condition{trader},
+			cancelled:      true,
+			price:          NewAmountp(21, 0),
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewReplaceOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			market := &Market{
+				Metadata: meta,
+				Owner:    other.Address(),
+				Name:     "replace",
+			}
+			assert.Nil(t, NewMarketBucket().Put(kv, market))
+			orderbook := &OrderBook{
+				Metadata:  meta,
+				MarketID:  market.ID,
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(20, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(20, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(32, 0, "ETH")))
+
+			// every order is placed in its own block, unless the test
+			// runs in a single block
+			create := NewOrderHandler(&weavetest.Auth{Signers: []weave.Condition{trader, other}}, ctrl, &weavetest.Cron{})
+			book := []*CreateOrderMsg{
+				{Trader: trader.Address(), Offer: coin.NewCoinp(10, 0, "BTC"), Price: NewAmountp(20, 0)},
+				{Trader: other.Address(), Offer: coin.NewCoinp(10, 0, "BTC"), Price: NewAmountp(20, 0)},
+				{Trader: other.Address(), Offer: coin.NewCoinp(10, 0, "BTC"), Price: NewAmountp(21, 0)},
+				{Trader: other.Address(), Offer: coin.NewCoinp(32, 0, "ETH"), Price: NewAmountp(0, 62500000)},
+			}
+			for i, msg := range book {
+				msg.Metadata = meta
+				msg.OrderBookID = orderBookID
+				ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now.Add(time.Duration(i)*time.Second)), int64(i+1))
+				if tc.sameBlock {
+					ctx = weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+				}
+				_, err := create.Deliver(ctx, kv, &weavetest.Tx{Msg: msg})
+				assert.Nil(t, err)
+			}
+
+			blockTime := now.Add(time.Minute)
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), blockTime), int64(len(book)+1))
+			if tc.sameBlock {
+				blockTime = now
+				ctx = weave.WithHeight(weave.WithBlockTime(context.Background(), blockTime), 1)
+			}
+			if tc.cancelled {
+				cancel := NewCancelOrderHandler(&weavetest.Auth{Signers: []weave.Condition{trader}}, ctrl)
+				_, err := cancel.Deliver(ctx, kv, &weavetest.Tx{Msg: &CancelOrderMsg{
+					Metadata: meta,
+					OrderID:  orderID,
+				}})
+				assert.Nil(t, err)
+			}
+
+			tx := &weavetest.Tx{Msg: &ReplaceOrderMsg{
+				Metadata:       meta,
+				OrderID:        orderID,
+				Price:          tc.price,
+				RemainingOffer: tc.offer,
+			}}
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			if _, err := h.Deliver(ctx, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+			if tc.wantDeliverErr != nil {
+				return
+			}
+
+			var order Order
+			assert.Nil(t, NewOrderBucket().One(kv, orderID, &order))
+			assert.Equal(t, tc.wantState, order.OrderState)
+			assert.Equal(t, tc.wantRemaining, *order.RemainingOffer)
+			assert.Equal(t, tc.wantOriginal, *order.OriginalOffer)
+			assert.Equal(t, tc.wantTrades, len(order.TradeIds))
+			assert.Equal(t, weave.AsUnixTime(blockTime), order.UpdatedAt)
+
+			prefix, err := BuildOpenOrderPrefix(orderBookID, Side_Ask)
+			assert.Nil(t, err)
+			iter, err := NewOrderBucket().IndexScan(kv, "open", prefix, false)
+			assert.Nil(t, err)
+			defer iter.Release()
+			var asks [][]byte
+			for iter.Valid() {
+				var ask Order
+				assert.Nil(t, iter.LoadNext(&ask))
+				asks = append(asks, ask.ID)
+			}
+			assert.Equal(t, tc.wantAsks, asks)
+
+			var ob OrderBook
+			assert.Nil(t, NewOrderBookBucket().One(kv, orderBookID, &ob))
+			assert.Equal(t, tc.wantAskCount, ob.TotalAskCount)
+
+			balance, err := ctrl.Balance(kv, trader.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTraderBTC, balanceOf(balance, "BTC"))
+			assert.Equal(t, tc.wantTraderETH, balanceOf(balance, "ETH"))
+		})
+	}
+}
+
+func TestExpireOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	meta := &weave.Metadata{Schema: 1}
+
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	orderBookID := weavetest.SequenceID(1)
+
+	// the expiring order placed before every test case
+	openOrderID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		blockTime time.Time
+		// cancel the open order before running the test message
+		cancelled      bool
+		msg            weave.Msg
+		wantState      OrderState
+		wantReason     CancelReason
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unknown order": {
+			blockTime: expiresAt,
+			msg: &ExpireOrderMsg{
+				Metadata: meta,
+				OrderID:  weavetest.SequenceID(7),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"not expired yet": {
+			blockTime: expiresAt.Add(-time.Second),
+			msg: &ExpireOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"order cancelled before it expired": {
+			blockTime: expiresAt,
+			cancelled: true,
+			msg: &ExpireOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+			wantState:  OrderState_Cancel,
+			wantReason: CancelReason_Trader,
+		},
+		"success": {
+			blockTime: expiresAt,
+			msg: &ExpireOrderMsg{
+				Metadata: meta,
+				OrderID:  openOrderID,
+			},
+			wantState:  OrderState_Cancel,
+			wantReason: CancelReason_Expired,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewExpireOrderHandler(ctrl)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbooks := NewOrderBookBucket()
+			assert.Nil(t, orderbooks.Put(kv, orderbook.Copy().(*OrderBook)))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "BTC")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+
+			// place the order that expires
+			auth := &weavetest.Auth{Signers: []weave.Condition{trader}}
+			create := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+			_, err := create.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    meta,
+				Trader:      trader.Address(),
+				OrderBookID: orderBookID,
+				Offer:       coin.NewCoinp(10, 0, "BTC"),
+				Price:       NewAmountp(20, 0),
+				ExpiresAt:   weave.AsUnixTime(expiresAt),
+			}})
+			assert.Nil(t, err)
+
+			var order Order
+			assert.Nil(t, NewOrderBucket().One(kv, openOrderID, &order))
+			if len(order.ExpirationTaskID) == 0 {
+				t.Fatal("expiration task not scheduled")
+			}
+
+			if tc.cancelled {
+				cancel := NewCancelOrderHandler(auth, ctrl)
+				_, err := cancel.Deliver(ctx, kv, &weavetest.Tx{Msg: &CancelOrderMsg{
+					Metadata: meta,
+					OrderID:  openOrderID,
+				}})
+				assert.Nil(t, err)
+			}
+
+			ctx = weave.WithHeight(weave.WithBlockTime(context.Background(), tc.blockTime), 1)
+			tx := &weavetest.Tx{Msg: tc.msg}
+
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Logf("want: %+v", tc.wantCheckErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("check (%T)", tc.msg)
+			}
+			if _, err := h.Deliver(ctx, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Logf("want: %+v", tc.wantDeliverErr)
+				t.Logf("got: %+v", err)
+				t.Fatalf("deliver (%T)", tc.msg)
+			}
+
+			if tc.wantDeliverErr == nil {
+				assert.Nil(t, NewOrderBucket().One(kv, openOrderID, &order))
+				assert.Equal(t, tc.wantState, order.OrderState)
+				assert.Equal(t, tc.wantReason, order.CancelReason)
+
+				balance, err := ctrl.Balance(kv, trader.Address())
+				assert.Nil(t, err)
+				assert.Equal(t, coin.NewCoin(100, 0, "BTC"), balanceOf(balance, "BTC"))
+
+				var ob OrderBook
+				assert.Nil(t, orderbooks.One(kv, orderBookID, &ob))
+				assert.Equal(t, int64(0), ob.TotalAskCount)
+			}
+		})
+	}
+}
+
+func TestCreateStopOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+	orderBookID := weavetest.SequenceID(1)
+
+	// the last trade of the orderbook was at 20 ETH per BTC
+	cases := map[string]struct {
+		signers        []weave.Condition
+		msg            *CreateStopOrderMsg
+		wantSide       Side
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"success, buy above the last price": {
+			signers: []weave.Condition{trader},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(21, 0),
+				Offer:        coin.NewCoinp(50, 0, "ETH"),
+				Price:        NewAmountp(0, 40000000),
+			},
+			wantSide: Side_Bid,
+		},
+		"success, sell below the last price": {
+			signers: []weave.Condition{trader},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Below,
+				TriggerPrice: NewAmountp(19, 0),
+				Offer:        coin.NewCoinp(2, 0, "BTC"),
+				Price:        NewAmountp(18, 0),
+				OrderType:    OrderType_Market,
+				TimeInForce:  TimeInForce_ImmediateOrCancel,
+			},
+			wantSide: Side_Ask,
+		},
+		"triggered by the last price": {
+			signers: []weave.Condition{trader},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(20, 0),
+				Offer:        coin.NewCoinp(50, 0, "ETH"),
+				Price:        NewAmountp(0, 40000000),
+			},
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(21, 0),
+				Offer:        coin.NewCoinp(50, 0, "ETH"),
+				Price:        NewAmountp(0, 40000000),
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"unknown orderbook": {
+			signers: []weave.Condition{trader},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  weavetest.SequenceID(7),
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(21, 0),
+				Offer:        coin.NewCoinp(50, 0, "ETH"),
+				Price:        NewAmountp(0, 40000000),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"insufficient funds": {
+			signers: []weave.Condition{trader},
+			msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(21, 0),
+				Offer:        coin.NewCoinp(500, 0, "ETH"),
+				Price:        NewAmountp(0, 40000000),
+			},
+			wantDeliverErr: errors.ErrAmount,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewStopOrderHandler(auth, ctrl)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbook := &OrderBook{
+				Metadata:  meta,
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+			assert.Nil(t, NewTickerBucket().Put(kv, &Ticker{
+				Metadata:  meta,
+				ID:        orderBookID,
+				LastPrice: NewAmountp(20, 0),
+			}))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(10, 0, "BTC")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			tx := &weavetest.Tx{Msg: tc.msg}
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			res, err := h.Deliver(ctx, kv, tx)
+			if !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+			if tc.wantDeliverErr != nil {
+				return
+			}
+
+			var stop StopOrder
+			assert.Nil(t, NewStopOrderBucket().One(kv, res.Data, &stop))
+			assert.Equal(t, StopOrderState_Pending, stop.State)
+			assert.Equal(t, tc.wantSide, stop.Side)
+			assert.Equal(t, tc.msg.Direction, stop.Direction)
+			assert.Equal(t, tc.msg.TriggerPrice, stop.TriggerPrice)
+			assert.Equal(t, tc.msg.Offer, stop.Offer)
+			assert.Equal(t, weave.AsUnixTime(now), stop.CreatedAt)
+
+			// the offer is held in escrow until the stop order is triggered
+			balance, err := ctrl.Balance(kv, EscrowAddress)
+			assert.Nil(t, err)
+			assert.Equal(t, *tc.msg.Offer, balanceOf(balance, tc.msg.Offer.Ticker))
+
+			// no order is placed
+			var orders []Order
+			assert.Nil(t, NewOrderBucket().ByIndex(kv, "trader", trader.Address(), &orders))
+			assert.Equal(t, 0, len(orders))
+		})
+	}
+}
+
+func TestCancelStopOrder(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+	meta := &weave.Metadata{Schema: 1}
+	orderBookID := weavetest.SequenceID(1)
+
+	// the stop order placed before every test case
+	stopOrderID := weavetest.SequenceID(1)
+
+	cases := map[string]struct {
+		signers []weave.Condition
+		// cancel the stop order before running the test message
+		cancelled      bool
+		msg            weave.Msg
+		wantCheckErr   *errors.Error
+		wantDeliverErr *errors.Error
+	}{
+		"unauthorized": {
+			signers: []weave.Condition{other},
+			msg: &CancelStopOrderMsg{
+				Metadata:    meta,
+				StopOrderID: stopOrderID,
+			},
+			wantCheckErr:   errors.ErrUnauthorized,
+			wantDeliverErr: errors.ErrUnauthorized,
+		},
+		"unknown stop order": {
+			signers: []weave.Condition{trader},
+			msg: &CancelStopOrderMsg{
+				Metadata:    meta,
+				StopOrderID: weavetest.SequenceID(7),
+			},
+			wantCheckErr:   errors.ErrNotFound,
+			wantDeliverErr: errors.ErrNotFound,
+		},
+		"stop order already cancelled": {
+			signers:   []weave.Condition{trader},
+			cancelled: true,
+			msg: &CancelStopOrderMsg{
+				Metadata:    meta,
+				StopOrderID: stopOrderID,
+			},
+			wantCheckErr:   errors.ErrState,
+			wantDeliverErr: errors.ErrState,
+		},
+		"success": {
+			signers: []weave.Condition{trader},
+			msg: &CancelStopOrderMsg{
+				Metadata:    meta,
+				StopOrderID: stopOrderID,
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: tc.signers}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewCancelStopOrderHandler(auth, ctrl)
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			orderbook := &OrderBook{
+				Metadata:  meta,
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "BTC")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+
+			// place the stop order that is cancelled
+			traderAuth := &weavetest.Auth{Signers: []weave.Condition{trader}}
+			create := NewStopOrderHandler(traderAuth, ctrl)
+			_, err := create.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateStopOrderMsg{
+				Metadata:     meta,
+				Trader:       trader.Address(),
+				OrderBookID:  orderBookID,
+				Direction:    TriggerDirection_Below,
+				TriggerPrice: NewAmountp(15, 0),
+				Offer:        coin.NewCoinp(10, 0, "BTC"),
+				Price:        NewAmountp(14, 0),
+			}})
+			assert.Nil(t, err)
+			if tc.cancelled {
+				cancel := NewCancelStopOrderHandler(traderAuth, ctrl)
+				_, err := cancel.Deliver(ctx, kv, &weavetest.Tx{Msg: &CancelStopOrderMsg{
+					Metadata:    meta,
+					StopOrderID: stopOrderID,
+				}})
+				assert.Nil(t, err)
+			}
+
+			tx := &weavetest.Tx{Msg: tc.msg}
+			if _, err := h.Check(ctx, kv, tx); !tc.wantCheckErr.Is(err) {
+				t.Fatalf("unexpected check error: %+v", err)
+			}
+			if _, err := h.Deliver(ctx, kv, tx); !tc.wantDeliverErr.Is(err) {
+				t.Fatalf("unexpected deliver error: %+v", err)
+			}
+
+			if tc.wantDeliverErr == nil {
+				var stop StopOrder
+				assert.Nil(t, NewStopOrderBucket().One(kv, stopOrderID, &stop))
+				assert.Equal(t, StopOrderState_Cancel, stop.State)
+
+				// the stop order can no longer be triggered
+				prefix, err := BuildStopTriggerPrefix(orderBookID, TriggerDirection_Below)
+				assert.Nil(t, err)
+				iter, err := NewStopOrderBucket().IndexScan(kv, "trigger", prefix, false)
+				assert.Nil(t, err)
+				assert.Equal(t, false, iter.Valid())
+				iter.Release()
+
+				balance, err := ctrl.Balance(kv, trader.Address())
+				assert.Nil(t, err)
+				assert.Equal(t, coin.NewCoin(100, 0, "BTC"), balanceOf(balance, "BTC"))
+			}
+		})
+	}
+}
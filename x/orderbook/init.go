@@ -0,0 +1,113 @@
+package orderbook
+
+import (
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
+)
+
+// Initializer fulfils the Initializer interface to load data from the genesis
+// file
+type Initializer struct{}
+
+var _ weave.Initializer = (*Initializer)(nil)
+
+// FromGenesis will parse initial markets, along with their orderbooks,
+// and the optional configuration from genesis and save them to the database
+func (*Initializer) FromGenesis(opts weave.Options, params weave.GenesisParams, kv weave.KVStore) error {
+	if err := initConfig(kv, opts); err != nil {
+		return errors.Wrap(err, "init config")
+	}
+
+	var markets []struct {
+		Owner        weave.Address `json:"owner"`
+		Name         string        `json:"name"`
+		MakerFee     *Amount       `json:"maker_fee"`
+		TakerFee     *Amount       `json:"taker_fee"`
+		FeeCollector weave.Address `json:"fee_collector"`
+		OrderBooks   []struct {
+			AskTicker string  `json:"ask_ticker"`
+			BidTicker string  `json:"bid_ticker"`
+			TickSize  *Amount `json:"tick_size"`
+			LotSize   *Amount `json:"lot_size"`
+			MinOffer  *Amount `json:"min_offer"`
+		} `json:"orderbooks"`
+	}
+	if err := opts.ReadOptions("markets", &markets); err != nil {
+		return err
+	}
+
+	marketBucket := NewMarketBucket()
+	orderBookBucket := NewOrderBookBucket()
+	for _, m := range markets {
+		// markets from genesis must follow the same rules as created ones
+		msg := CreateMarketMsg{
+			Metadata:     &weave.Metadata{Schema: 1},
+			Owner:        m.Owner,
+			Name:         m.Name,
+			MakerFee:     m.MakerFee,
+			TakerFee:     m.TakerFee,
+			FeeCollector: m.FeeCollector,
+		}
+		if err := msg.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid market %s", m.Name)
+		}
+		market := &Market{
+			Metadata:     &weave.Metadata{Schema: 1},
+			Owner:        m.Owner,
+			Name:         m.Name,
+			MakerFee:     m.MakerFee,
+			TakerFee:     m.TakerFee,
+			FeeCollector: m.FeeCollector,
+		}
+		if err := marketBucket.Put(kv, market); err != nil {
+			return errors.Wrapf(err, "cannot save market %s", m.Name)
+		}
+
+		for _, ob := range m.OrderBooks {
+			// orderbooks from genesis must follow the same rules as created ones
+			msg := CreateOrderBookMsg{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: ob.AskTicker,
+				BidTicker: ob.BidTicker,
+				TickSize:  ob.TickSize,
+				LotSize:   ob.LotSize,
+				MinOffer:  ob.MinOffer,
+			}
+			if err := msg.Validate(); err != nil {
+				return errors.Wrapf(err, "invalid orderbook %s/%s", ob.AskTicker, ob.BidTicker)
+			}
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: ob.AskTicker,
+				BidTicker: ob.BidTicker,
+				TickSize:  ob.TickSize,
+				LotSize:   ob.LotSize,
+				MinOffer:  ob.MinOffer,
+			}
+			if err := orderBookBucket.Put(kv, orderbook); err != nil {
+				return errors.Wrapf(err, "cannot save orderbook %s/%s", ob.AskTicker, ob.BidTicker)
+			}
+		}
+	}
+	return nil
+}
+
+// initConfig saves the configuration from genesis, if there is one. It must
+// name its owner
+func initConfig(kv weave.KVStore, opts weave.Options) error {
+	var confOptions weave.Options
+	if err := opts.ReadOptions("conf", &confOptions); err != nil {
+		return errors.Wrap(err, "read conf")
+	}
+	if confOptions[packageName] == nil {
+		return nil
+	}
+	var conf Configuration
+	if err := confOptions.ReadOptions(packageName, &conf); err != nil {
+		return errors.Wrapf(err, "read configuration for %s", packageName)
+	}
+	return gconf.Save(kv, packageName, &conf)
+}
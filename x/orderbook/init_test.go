@@ -0,0 +1,195 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+func TestGenesisInitializer(t *testing.T) {
+	const genesis = `
+{
+  "markets": [
+    {
+      "owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+      "name": "main-market",
+      "orderbooks": [
+        {"ask_ticker": "BTC", "bid_ticker": "ETH"},
+        {"ask_ticker": "DEX", "bid_ticker": "IDEX"}
+      ]
+    },
+    {
+      "owner": "0000000000000000000000000000000000000001",
+      "name": "second-market"
+    }
+  ]
+}`
+
+	var opts weave.Options
+	assert.Nil(t, json.Unmarshal([]byte(genesis), &opts))
+
+	db := store.MemStore()
+	var ini Initializer
+	assert.Nil(t, ini.FromGenesis(opts, weave.GenesisParams{}, db))
+
+	var market Market
+	assert.Nil(t, NewMarketBucket().One(db, weavetest.SequenceID(1), &market))
+	assert.Equal(t, "main-market", market.Name)
+	owner, err := weave.ParseAddress("C30A2424104F542576EF01FECA2FF558F5EAA61A")
+	assert.Nil(t, err)
+	assert.Equal(t, owner, market.Owner)
+
+	var markets []Market
+	assert.Nil(t, NewMarketBucket().ByIndex(db, "name", []byte("second-market"), &markets))
+	assert.Equal(t, 1, len(markets))
+
+	var orderbooks []OrderBook
+	assert.Nil(t, NewOrderBookBucket().ByIndex(db, "market", weavetest.SequenceID(1), &orderbooks))
+	assert.Equal(t, 2, len(orderbooks))
+	assert.Equal(t, "BTC", orderbooks[0].AskTicker)
+	assert.Equal(t, "IDEX", orderbooks[1].BidTicker)
+}
+
+func TestGenesisInitializerInvalidOrderBook(t *testing.T) {
+	const genesis = `
+{
+  "markets": [
+    {
+      "owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+      "name": "main-market",
+      "orderbooks": [
+        {"ask_ticker": "ETH", "bid_ticker": "BTC"}
+      ]
+    }
+  ]
+}`
+
+	var opts weave.Options
+	assert.Nil(t, json.Unmarshal([]byte(genesis), &opts))
+
+	db := store.MemStore()
+	var ini Initializer
+	if err := ini.FromGenesis(opts, weave.GenesisParams{}, db); !errors.ErrCurrency.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestGenesisInitializerMarketFees(t *testing.T) {
+	cases := map[string]struct {
+		market           string
+		wantErr          *errors.Error
+		wantMakerFee     *Amount
+		wantTakerFee     *Amount
+		wantFeeCollector string
+	}{
+		"fees and collector": {
+			market: `{
+				"owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+				"name": "fee-market",
+				"maker_fee": {"fractional": 1000000},
+				"taker_fee": {"fractional": 2000000},
+				"fee_collector": "3B11C732B8FC1F09BEB34031302FE2AB347C5C14"
+			}`,
+			wantMakerFee:     NewAmountp(0, 1000000),
+			wantTakerFee:     NewAmountp(0, 2000000),
+			wantFeeCollector: "3B11C732B8FC1F09BEB34031302FE2AB347C5C14",
+		},
+		"no fees": {
+			market: `{
+				"owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+				"name": "free-market"
+			}`,
+		},
+		"fee of one or more": {
+			market: `{
+				"owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+				"name": "fee-market",
+				"taker_fee": {"whole": 1}
+			}`,
+			wantErr: errors.ErrInput,
+		},
+		"invalid fee collector": {
+			market: `{
+				"owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+				"name": "fee-market",
+				"maker_fee": {"fractional": 1000000},
+				"fee_collector": "3B11C7"
+			}`,
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			var opts weave.Options
+			assert.Nil(t, json.Unmarshal([]byte(`{"markets": [`+tc.market+`]}`), &opts))
+
+			db := store.MemStore()
+			var ini Initializer
+			if err := ini.FromGenesis(opts, weave.GenesisParams{}, db); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			var market Market
+			assert.Nil(t, NewMarketBucket().One(db, weavetest.SequenceID(1), &market))
+			assert.Equal(t, tc.wantMakerFee, market.MakerFee)
+			assert.Equal(t, tc.wantTakerFee, market.TakerFee)
+			if tc.wantFeeCollector == "" {
+				assert.Equal(t, 0, len(market.FeeCollector))
+			} else {
+				collector, err := weave.ParseAddress(tc.wantFeeCollector)
+				assert.Nil(t, err)
+				assert.Equal(t, collector, market.FeeCollector)
+			}
+		})
+	}
+}
+
+func TestGenesisInitializerConfiguration(t *testing.T) {
+	const genesis = `
+{
+  "conf": {
+    "orderbook": {
+      "owner": "C30A2424104F542576EF01FECA2FF558F5EAA61A",
+      "fee_ticker": "IDEX",
+      "fee_discount": {"fractional": 250000000},
+      "fee_prices": [
+        {"ticker": "ETH", "price": {"whole": 2}}
+      ]
+    }
+  }
+}`
+
+	var opts weave.Options
+	assert.Nil(t, json.Unmarshal([]byte(genesis), &opts))
+
+	db := store.MemStore()
+	var ini Initializer
+	assert.Nil(t, ini.FromGenesis(opts, weave.GenesisParams{}, db))
+
+	var conf Configuration
+	assert.Nil(t, gconf.Load(db, packageName, &conf))
+	owner, err := weave.ParseAddress("C30A2424104F542576EF01FECA2FF558F5EAA61A")
+	assert.Nil(t, err)
+	assert.Equal(t, owner, conf.Owner)
+	assert.Equal(t, "IDEX", conf.FeeTicker)
+	assert.Equal(t, NewAmountp(0, 250000000), conf.FeeDiscount)
+	assert.Equal(t, []*FeePrice{{Ticker: "ETH", Price: NewAmountp(2, 0)}}, conf.FeePrices)
+
+	// a configuration without an owner could never be updated
+	const noOwner = `{"conf": {"orderbook": {"fee_ticker": "IDEX"}}}`
+	var noOwnerOpts weave.Options
+	assert.Nil(t, json.Unmarshal([]byte(noOwner), &noOwnerOpts))
+	if err := ini.FromGenesis(noOwnerOpts, weave.GenesisParams{}, store.MemStore()); !errors.ErrEmpty.Is(err) {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
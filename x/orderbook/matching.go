@@ -0,0 +1,460 @@
+package orderbook
+
+import (
+	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/x/cash"
+)
+
+// matchingEngine settles incoming (taker) orders against the resting (maker)
+// orders of the opposite side of the orderbook using price-time priority.
+//
+// Every order is priced in the ticker of the other side, so a maker and a
+// taker cross as long as makerPrice * takerPrice <= 1. Resting orders are
+// found through the "open" index, which is ordered by price, so the best
+// offers come first and we can stop at the first price that does not cross
+// without loading the order. Orders at the same price level are ordered by their
+// creation time and then their sequential ID, which gives us FIFO within the
+// price level. Replacing an order may move it to the back of its level.
+//
+// All trades are executed at the maker price. Whatever is left of a limit,
+// good till cancel order rests on the book, any other order is refunded.
+//
+// The maker and taker fees of the market are deducted from what each side
+// receives and paid to the fee collector of the market. Orders opting in pay
+// them in the fee ticker of the configuration instead, at a discount.
+//
+// A taker never trades with a maker of the same trader. The self-trade
+// prevention mode of the taker decides which of them is cancelled, or
+// whether both are reduced by what they would have traded.
+type matchingEngine struct {
+	bank    cash.CoinMover
+	markets *MarketBucket
+	orders  *OrderBucket
+	trades  *TradeBucket
+	candles *CandleBucket
+	tickers *TickerBucket
+	stops   *StopOrderBucket
+}
+
+func newMatchingEngine(bank cash.CoinMover) matchingEngine {
+	return matchingEngine{
+		bank:    bank,
+		markets: NewMarketBucket(),
+		orders:  NewOrderBucket(),
+		trades:  NewTradeBucket(),
+		candles: NewCandleBucket(),
+		tickers: NewTickerBucket(),
+		stops:   NewStopOrderBucket(),
+	}
+}
+
+// fill is a planned execution between a taker and one resting maker order
+type fill struct {
+	maker *Order
+	// makerPaid is paid from the maker escrow to the taker (maker side ticker)
+	makerPaid coin.Coin
+	// takerPaid is paid from the taker escrow to the maker (taker side ticker)
+	takerPaid coin.Coin
+	// selfTrade fills are never executed, as maker and taker belong to the
+	// same trader. Both paid amounts are refunded to the trader instead, and
+	// the orders are cancelled once nothing remains
+	selfTrade bool
+}
+
+// matchPlan holds everything planned for a taker order
+type matchPlan struct {
+	fills []fill
+	// remaining is what would be left of the taker offer after all fills
+	remaining coin.Coin
+	// cancelTaker is set if self-trade prevention cancels the rest of the taker
+	cancelTaker bool
+	// decremented is set if self-trade prevention reduced the taker offer
+	decremented bool
+	// dust is set if the taker was filled except for a remainder too small
+	// to buy a single unit at the best crossing price. Such an order counts
+	// as filled
+	dust bool
+}
+
+// Match executes the taker order against the opposite side of the orderbook.
+// The taker order must already be stored (so it has an ID) and its offer must be
+// escrowed. The taker order, all makers and the orderbook counts are updated in place
+// and saved, and one Trade is stored and added to the candles and the ticker for
+// every fill.
+//
+// A fill or kill order that cannot be filled completely returns an error
+// without modifying anything.
+func (e matchingEngine) Match(db weave.KVStore, orderbook *OrderBook, taker *Order, now weave.UnixTime) error {
+	plan, err := e.findFills(db, taker)
+	if err != nil {
+		return err
+	}
+	// an offer reduced by self-trade prevention is not filled either
+	if taker.TimeInForce == TimeInForce_FillOrKill && ((plan.remaining.IsPositive() && !plan.dust) || plan.decremented) {
+		return errors.Wrap(errors.ErrState, "fill or kill order cannot be filled completely")
+	}
+	return e.settle(db, orderbook, taker, plan, now)
+}
+
+// findFills walks the opposite side of the book in best price order and plans fills
+// until the taker is exhausted, the prices no longer cross or self-trade prevention
+// cancels the taker.
+// Nothing is written here, so we never modify the store under an open iterator.
+func (e matchingEngine) findFills(db weave.KVStore, taker *Order) (*matchPlan, error) {
+	plan := &matchPlan{remaining: *taker.RemainingOffer}
+
+	prefix, err := BuildOpenOrderPrefix(taker.OrderBookID, taker.Side.Opposite())
+	if err != nil {
+		return nil, errors.Wrap(err, "open orders prefix")
+	}
+	iter, err := e.orders.IndexScan(db, "open", prefix, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan open orders")
+	}
+	defer iter.Release()
+
+	for plan.remaining.IsPositive() {
+		if !iter.Valid() {
+			// LoadNext returns the reason, which is fine as long as the book side is exhausted
+			if err := iter.LoadNext(&Order{}); !morm.ErrIteratorDone.Is(err) {
+				return nil, errors.Wrap(err, "load maker")
+			}
+			break
+		}
+		// the price is read from the index, so we never load a maker that does not cross
+		price, err := ParseOpenOrderPrice(iter.IndexKey())
+		if err != nil {
+			return nil, errors.Wrap(err, "maker price")
+		}
+		// all further orders are priced even worse
+		crosses, err := pricesCross(price, taker.Price)
+		if err != nil {
+			return nil, err
+		}
+		if !crosses {
+			break
+		}
+
+		var maker Order
+		if err := iter.LoadNext(&maker); err != nil {
+			return nil, errors.Wrap(err, "load maker")
+		}
+
+		if maker.Trader.Equals(taker.Trader) {
+			switch taker.SelfTradePrevention {
+			case SelfTradePrevention_CancelOldest:
+				plan.fills = append(plan.fills, cancelMaker(&maker, plan.remaining.Ticker))
+				continue
+			case SelfTradePrevention_CancelBoth:
+				plan.fills = append(plan.fills, cancelMaker(&maker, plan.remaining.Ticker))
+				plan.cancelTaker = true
+				return plan, nil
+			case SelfTradePrevention_Decrement:
+				// reduced below, just like a trade
+			default:
+				plan.cancelTaker = true
+				return plan, nil
+			}
+		}
+
+		f, err := planFill(&maker, plan.remaining)
+		if err != nil {
+			return nil, err
+		}
+		// what is left is too small to buy a single unit of the maker offer,
+		// and no later maker is priced any better
+		if f.makerPaid.IsZero() {
+			plan.dust = !plan.remaining.Equals(*taker.RemainingOffer)
+			break
+		}
+		if maker.Trader.Equals(taker.Trader) {
+			f.selfTrade = true
+			plan.decremented = true
+		}
+		plan.fills = append(plan.fills, f)
+
+		plan.remaining, err = plan.remaining.Subtract(f.takerPaid)
+		if err != nil {
+			return nil, errors.Wrap(err, "taker remaining")
+		}
+	}
+	return plan, nil
+}
+
+// cancelMaker plans a self-trade fill that cancels the whole maker order and
+// leaves the taker untouched
+func cancelMaker(maker *Order, takerTicker string) fill {
+	return fill{
+		maker:     maker,
+		makerPaid: *maker.RemainingOffer,
+		takerPaid: coin.NewCoin(0, 0, takerTicker),
+		selfTrade: true,
+	}
+}
+
+// planFill calculates how much can be exchanged between a maker and a taker with
+// the given remaining offer, at the maker price.
+// Rounding is always in favor of the maker, who is guaranteed to get the requested price.
+func planFill(maker *Order, takerRemaining coin.Coin) (fill, error) {
+	// what the taker must pay to take the whole maker order
+	makerCost, err := maker.Price.MulCoinRound(*maker.RemainingOffer, RoundUp)
+	if err != nil {
+		return fill{}, errors.Wrap(err, "maker cost")
+	}
+	makerCost.Ticker = takerRemaining.Ticker
+	if takerRemaining.IsGTE(makerCost) {
+		return fill{
+			maker:     maker,
+			makerPaid: *maker.RemainingOffer,
+			takerPaid: makerCost,
+		}, nil
+	}
+
+	makerPaid, err := maker.Price.DivideCoin(takerRemaining, RoundDown)
+	if err != nil {
+		return fill{}, errors.Wrap(err, "partial fill")
+	}
+	makerPaid.Ticker = maker.RemainingOffer.Ticker
+	return fill{
+		maker:     maker,
+		makerPaid: makerPaid,
+		takerPaid: takerRemaining,
+	}, nil
+}
+
+// PostOnlyPrice returns the price a post-only order on the given side of the
+// orderbook rests at without matching. An order that crosses the best price
+// of the opposite side is rejected, or repriced to the next tick that does
+// not cross it
+func (e matchingEngine) PostOnlyPrice(db weave.ReadOnlyKVStore, orderbook *OrderBook, side Side, price *Amount, mode PostOnly) (*Amount, error) {
+	best, err := bestPrice(db, e.orders, orderbook.ID, side.Opposite())
+	if err != nil {
+		return nil, errors.Wrap(err, "best opposite price")
+	}
+	if best == nil {
+		return price, nil
+	}
+	crosses, err := pricesCross(best, price)
+	if err != nil {
+		return nil, err
+	}
+	if !crosses {
+		return price, nil
+	}
+	if mode != PostOnly_Reprice {
+		return nil, errors.Wrap(errors.ErrState, "post-only order would match a resting order")
+	}
+
+	// orders cross as long as price <= 1 / best, so we need the next tick above it
+	limit, err := best.Inverse(RoundDown)
+	if err != nil {
+		return nil, errors.Wrap(err, "best opposite price")
+	}
+	repriced, err := limit.NextMultipleAbove(orderbook.TickSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "reprice")
+	}
+	return repriced, nil
+}
+
+// pricesCross returns true if two orders on opposite sides of the book, each
+// priced in the ticker of the other side, can be matched. That is the case as
+// long as a * b <= 1
+func pricesCross(a, b *Amount) (bool, error) {
+	// rounding up keeps a product that is just above 1 from crossing
+	product, err := a.Multiply(b, RoundUp)
+	if errors.ErrOverflow.Is(err) {
+		// way above 1
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "price product")
+	}
+	return product.Compare(NewAmountp(1, 0)) <= 0, nil
+}
+
+// settle stores the trades and moves the escrowed coins for all fills, then updates
+// the orders, the orderbook counts and the best prices of the ticker
+func (e matchingEngine) settle(db weave.KVStore, orderbook *OrderBook, taker *Order, plan *matchPlan, now weave.UnixTime) error {
+	var fees *feeCharger
+	if len(plan.fills) != 0 {
+		var market Market
+		if err := e.markets.One(db, orderbook.MarketID, &market); err != nil {
+			return errors.Wrap(err, "cannot load market")
+		}
+		fees = newFeeCharger(e.bank, &market)
+	}
+
+	for _, f := range plan.fills {
+		if f.selfTrade {
+			if err := e.preventSelfTrade(db, orderbook, taker, f, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := e.bank.MoveCoins(db, EscrowAddress, taker.Trader, f.makerPaid); err != nil {
+			return errors.Wrap(err, "cannot pay taker")
+		}
+		if err := e.bank.MoveCoins(db, EscrowAddress, f.maker.Trader, f.takerPaid); err != nil {
+			return errors.Wrap(err, "cannot pay maker")
+		}
+		// each side pays its fee out of what it received, unless it is discounted
+		makerFee, err := fees.charge(db, f.maker, fees.market.MakerFee, f.takerPaid)
+		if err != nil {
+			return errors.Wrap(err, "maker fee")
+		}
+		takerFee, err := fees.charge(db, taker, fees.market.TakerFee, f.makerPaid)
+		if err != nil {
+			return errors.Wrap(err, "taker fee")
+		}
+
+		trade := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: taker.OrderBookID,
+			OrderID:     taker.ID,
+			Taker:       taker.Trader,
+			Maker:       f.maker.Trader,
+			MakerPaid:   f.makerPaid.Clone(),
+			TakerPaid:   f.takerPaid.Clone(),
+			ExecutedAt:  now,
+			MakerFee:    makerFee,
+			TakerFee:    takerFee,
+		}
+		if err := e.trades.Put(db, trade); err != nil {
+			return errors.Wrap(err, "cannot store trade")
+		}
+		if err := e.candles.Record(db, orderbook, trade); err != nil {
+			return errors.Wrap(err, "cannot record trade in candles")
+		}
+		if err := e.tickers.Record(db, e.trades, orderbook, trade); err != nil {
+			return errors.Wrap(err, "cannot record trade in ticker")
+		}
+
+		if err := fillOrder(f.maker, f.makerPaid, trade.ID, now); err != nil {
+			return errors.Wrap(err, "maker")
+		}
+		if err := fillOrder(taker, f.takerPaid, trade.ID, now); err != nil {
+			return errors.Wrap(err, "taker")
+		}
+		if f.maker.OrderState == OrderState_Done {
+			decrementOpenCount(orderbook, f.maker.Side)
+		}
+		if err := e.orders.Put(db, f.maker); err != nil {
+			return errors.Wrap(err, "cannot update maker")
+		}
+	}
+
+	if taker.OrderState == OrderState_Open {
+		if taker.rests() && !plan.cancelTaker {
+			incrementOpenCount(orderbook, taker.Side)
+		} else {
+			if err := e.bank.MoveCoins(db, EscrowAddress, taker.Trader, *taker.RemainingOffer); err != nil {
+				return errors.Wrap(err, "cannot refund taker")
+			}
+			switch {
+			case plan.cancelTaker:
+				taker.OrderState = OrderState_Cancel
+				taker.CancelReason = CancelReason_SelfTrade
+			case plan.dust:
+				taker.OrderState = OrderState_Done
+			default:
+				taker.OrderState = OrderState_Cancel
+				taker.CancelReason = CancelReason_Unfilled
+			}
+			taker.UpdatedAt = now
+		}
+	}
+	if err := e.orders.Put(db, taker); err != nil {
+		return errors.Wrap(err, "cannot update taker")
+	}
+	if err := e.tickers.UpdateBestPrices(db, e.orders, taker.OrderBookID); err != nil {
+		return errors.Wrap(err, "cannot update ticker")
+	}
+	return nil
+}
+
+// preventSelfTrade refunds what maker and taker of a self-trade fill would have
+// paid each other to their trader, and reduces both orders by it
+func (e matchingEngine) preventSelfTrade(db weave.KVStore, orderbook *OrderBook, taker *Order, f fill, now weave.UnixTime) error {
+	for _, paid := range []coin.Coin{f.makerPaid, f.takerPaid} {
+		if !paid.IsPositive() {
+			continue
+		}
+		if err := e.bank.MoveCoins(db, EscrowAddress, taker.Trader, paid); err != nil {
+			return errors.Wrap(err, "cannot refund self-trade")
+		}
+	}
+
+	if err := decrementOrder(f.maker, f.makerPaid, now); err != nil {
+		return errors.Wrap(err, "maker")
+	}
+	if err := decrementOrder(taker, f.takerPaid, now); err != nil {
+		return errors.Wrap(err, "taker")
+	}
+	if f.maker.OrderState == OrderState_Cancel {
+		decrementOpenCount(orderbook, f.maker.Side)
+	}
+	if err := e.orders.Put(db, f.maker); err != nil {
+		return errors.Wrap(err, "cannot update maker")
+	}
+	return nil
+}
+
+// decrementOrder reduces the remaining offer of the order by what it would have
+// paid in a self-trade. The order is cancelled once nothing remains
+func decrementOrder(order *Order, paid coin.Coin, now weave.UnixTime) error {
+	remaining, err := order.RemainingOffer.Subtract(paid)
+	if err != nil {
+		return errors.Wrap(err, "remaining offer")
+	}
+	order.RemainingOffer = &remaining
+	if remaining.IsZero() {
+		order.OrderState = OrderState_Cancel
+		order.CancelReason = CancelReason_SelfTrade
+	}
+	order.UpdatedAt = now
+	return nil
+}
+
+// fillOrder reduces the remaining offer of the order by paid and records the trade.
+// The order is marked done once nothing remains
+func fillOrder(order *Order, paid coin.Coin, tradeID []byte, now weave.UnixTime) error {
+	remaining, err := order.RemainingOffer.Subtract(paid)
+	if err != nil {
+		return errors.Wrap(err, "remaining offer")
+	}
+	order.RemainingOffer = &remaining
+	if remaining.IsZero() {
+		order.OrderState = OrderState_Done
+	}
+	order.TradeIds = append(order.TradeIds, tradeID)
+	order.UpdatedAt = now
+	return nil
+}
+
+// nextSequence returns the Sequence of an order that joins the back of the
+// queue at its price
+func nextSequence(orderbook *OrderBook) uint64 {
+	orderbook.OrderSequence++
+	return orderbook.OrderSequence
+}
+
+func incrementOpenCount(orderbook *OrderBook, side Side) {
+	if side == Side_Ask {
+		orderbook.TotalAskCount++
+	} else {
+		orderbook.TotalBidCount++
+	}
+}
+
+func decrementOpenCount(orderbook *OrderBook, side Side) {
+	if side == Side_Ask {
+		orderbook.TotalAskCount--
+	} else {
+		orderbook.TotalBidCount--
+	}
+}
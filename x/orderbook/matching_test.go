@@ -0,0 +1,810 @@
+package orderbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/gconf"
+	"github.com/iov-one/weave/migration"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+	"github.com/iov-one/weave/x/cash"
+)
+
+func TestMatchOrders(t *testing.T) {
+	maker := weavetest.NewCondition()
+	taker := weavetest.NewCondition()
+
+	now := time.Now()
+
+	market := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    weavetest.NewCondition().Address(),
+		Name:     "matching",
+	}
+	// the ask side sells BTC for ETH, the bid side sells ETH for BTC
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	orderBookID := weavetest.SequenceID(1)
+
+	// resting asks, priced in ETH per BTC. Ids are assigned in this order
+	asks := []struct {
+		offer *coin.Coin
+		price *Amount
+	}{
+		{coin.NewCoinp(10, 0, "BTC"), NewAmountp(20, 0)},
+		{coin.NewCoinp(5, 0, "BTC"), NewAmountp(21, 0)},
+		{coin.NewCoinp(5, 0, "BTC"), NewAmountp(20, 0)},
+	}
+
+	cases := map[string]struct {
+		offer *coin.Coin
+		// price in BTC per ETH
+		price       *Amount
+		orderType   OrderType
+		timeInForce TimeInForce
+		wantErr     *errors.Error
+		// remaining offer of each ask, in order of creation
+		wantAsks       []*coin.Coin
+		wantTaker      *coin.Coin
+		wantTakerState OrderState
+		wantTrades     int
+		wantAskCount   int64
+		wantBidCount   int64
+		// what the taker ends up with, both tickers
+		wantTakerBTC coin.Coin
+		wantTakerETH coin.Coin
+	}{
+		"no crossing price": {
+			offer: coin.NewCoinp(100, 0, "ETH"),
+			price: NewAmountp(0, 60000000),
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(10, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(100, 0, "ETH"),
+			wantTakerState: OrderState_Open,
+			wantTrades:     0,
+			wantAskCount:   3,
+			wantBidCount:   1,
+			wantTakerBTC:   coin.NewCoin(0, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(900, 0, "ETH"),
+		},
+		"partial fill of the best ask": {
+			offer: coin.NewCoinp(100, 0, "ETH"),
+			price: NewAmountp(0, 50000000),
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(5, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(0, 0, "ETH"),
+			wantTakerState: OrderState_Done,
+			wantTrades:     1,
+			wantAskCount:   3,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(5, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(900, 0, "ETH"),
+		},
+		"sweep price levels in time priority": {
+			offer: coin.NewCoinp(400, 0, "ETH"),
+			price: NewAmountp(0, 47619047),
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 238095239, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(0, 0, "ETH"),
+			wantTakerState: OrderState_Done,
+			wantTrades:     3,
+			wantAskCount:   1,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(19, 761904761, "BTC"),
+			wantTakerETH:   coin.NewCoin(600, 0, "ETH"),
+		},
+		"fill everything and rest the remainder": {
+			offer: coin.NewCoinp(500, 0, "ETH"),
+			price: NewAmountp(0, 40000000),
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(95, 0, "ETH"),
+			wantTakerState: OrderState_Open,
+			wantTrades:     3,
+			wantAskCount:   0,
+			wantBidCount:   1,
+			wantTakerBTC:   coin.NewCoin(20, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(500, 0, "ETH"),
+		},
+		"immediate or cancel refunds the remainder": {
+			offer:       coin.NewCoinp(500, 0, "ETH"),
+			price:       NewAmountp(0, 40000000),
+			timeInForce: TimeInForce_ImmediateOrCancel,
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(95, 0, "ETH"),
+			wantTakerState: OrderState_Cancel,
+			wantTrades:     3,
+			wantAskCount:   0,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(20, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(595, 0, "ETH"),
+		},
+		"fill or kill filled completely": {
+			offer:       coin.NewCoinp(400, 0, "ETH"),
+			price:       NewAmountp(0, 47619047),
+			timeInForce: TimeInForce_FillOrKill,
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(0, 238095239, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(0, 0, "ETH"),
+			wantTakerState: OrderState_Done,
+			wantTrades:     3,
+			wantAskCount:   1,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(19, 761904761, "BTC"),
+			wantTakerETH:   coin.NewCoin(600, 0, "ETH"),
+		},
+		"fill or kill filled except for dust": {
+			offer:       coin.NewCoinp(300, 10, "ETH"),
+			price:       NewAmountp(0, 47619047),
+			timeInForce: TimeInForce_FillOrKill,
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			// too little to buy a unit at 21, refunded
+			wantTaker:      coin.NewCoinp(0, 10, "ETH"),
+			wantTakerState: OrderState_Done,
+			wantTrades:     2,
+			wantAskCount:   1,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(15, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(700, 0, "ETH"),
+		},
+		"fill or kill cannot be filled": {
+			offer:       coin.NewCoinp(500, 0, "ETH"),
+			price:       NewAmountp(0, 40000000),
+			timeInForce: TimeInForce_FillOrKill,
+			wantErr:     errors.ErrState,
+		},
+		"market order stops at the worst price": {
+			offer:       coin.NewCoinp(500, 0, "ETH"),
+			price:       NewAmountp(0, 50000000),
+			orderType:   OrderType_Market,
+			timeInForce: TimeInForce_ImmediateOrCancel,
+			wantAsks: []*coin.Coin{
+				coin.NewCoinp(0, 0, "BTC"),
+				coin.NewCoinp(5, 0, "BTC"),
+				coin.NewCoinp(0, 0, "BTC"),
+			},
+			wantTaker:      coin.NewCoinp(200, 0, "ETH"),
+			wantTakerState: OrderState_Cancel,
+			wantTrades:     2,
+			wantAskCount:   1,
+			wantBidCount:   0,
+			wantTakerBTC:   coin.NewCoin(15, 0, "BTC"),
+			wantTakerETH:   coin.NewCoin(700, 0, "ETH"),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{maker, taker}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			assert.Nil(t, NewMarketBucket().Put(kv, market.Copy().(*Market)))
+			orderbooks := NewOrderBookBucket()
+			assert.Nil(t, orderbooks.Put(kv, orderbook.Copy().(*OrderBook)))
+
+			assert.Nil(t, ctrl.CoinMint(kv, maker.Address(), coin.NewCoin(100, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, taker.Address(), coin.NewCoin(1000, 0, "ETH")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+
+			var askIDs [][]byte
+			for _, ask := range asks {
+				tx := &weavetest.Tx{Msg: &CreateOrderMsg{
+					Metadata:    &weave.Metadata{Schema: 1},
+					Trader:      maker.Address(),
+					OrderBookID: orderBookID,
+					Offer:       ask.offer,
+					Price:       ask.price,
+				}}
+				res, err := h.Deliver(ctx, kv, tx)
+				assert.Nil(t, err)
+				askIDs = append(askIDs, res.Data)
+			}
+
+			tx := &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      taker.Address(),
+				OrderBookID: orderBookID,
+				Offer:       tc.offer,
+				Price:       tc.price,
+				OrderType:   tc.orderType,
+				TimeInForce: tc.timeInForce,
+			}}
+			res, err := h.Deliver(ctx, kv, tx)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			orders := NewOrderBucket()
+			for i, id := range askIDs {
+				var ask Order
+				assert.Nil(t, orders.One(kv, id, &ask))
+				assert.Equal(t, tc.wantAsks[i], ask.RemainingOffer)
+				if ask.RemainingOffer.IsZero() {
+					assert.Equal(t, OrderState_Done, ask.OrderState)
+				}
+			}
+
+			var order Order
+			assert.Nil(t, orders.One(kv, res.Data, &order))
+			assert.Equal(t, tc.wantTaker, order.RemainingOffer)
+			assert.Equal(t, tc.wantTakerState, order.OrderState)
+			if order.OrderState == OrderState_Cancel {
+				assert.Equal(t, CancelReason_Unfilled, order.CancelReason)
+			}
+			assert.Equal(t, tc.wantTrades, len(order.TradeIds))
+
+			var trades []Trade
+			assert.Nil(t, NewTradeBucket().ByIndex(kv, "order", res.Data, &trades))
+			assert.Equal(t, tc.wantTrades, len(trades))
+
+			var ob OrderBook
+			assert.Nil(t, orderbooks.One(kv, orderBookID, &ob))
+			assert.Equal(t, tc.wantAskCount, ob.TotalAskCount)
+			assert.Equal(t, tc.wantBidCount, ob.TotalBidCount)
+
+			var ticker Ticker
+			assert.Nil(t, NewTickerBucket().One(kv, orderBookID, &ticker))
+			assert.Equal(t, int64(tc.wantTrades), ticker.DayTradeCount)
+			assert.Equal(t, tc.wantAskCount != 0, ticker.BestAsk != nil)
+			assert.Equal(t, tc.wantBidCount != 0, ticker.BestBid != nil)
+
+			balance, err := ctrl.Balance(kv, taker.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTakerBTC, balanceOf(balance, "BTC"))
+			assert.Equal(t, tc.wantTakerETH, balanceOf(balance, "ETH"))
+		})
+	}
+}
+
+// balanceOf returns the amount of the given ticker, or zero if not found
+func TestMatchFees(t *testing.T) {
+	maker := weavetest.NewCondition()
+	taker := weavetest.NewCondition()
+	owner := weavetest.NewCondition().Address()
+	collector := weavetest.NewCondition().Address()
+
+	now := time.Now()
+
+	cases := map[string]struct {
+		makerFee     *Amount
+		takerFee     *Amount
+		feeCollector weave.Address
+		// the taker buys with this offer from a single ask of 10 BTC at 20 ETH
+		offer *coin.Coin
+		// fees recorded on the trade, nil for none
+		wantMakerFee *coin.Coin
+		wantTakerFee *coin.Coin
+		// what maker and taker receive from the trade
+		wantMakerETH coin.Coin
+		wantTakerBTC coin.Coin
+		// who gets the fees
+		wantCollector weave.Address
+	}{
+		"no fees": {
+			offer:         coin.NewCoinp(200, 0, "ETH"),
+			wantMakerETH:  coin.NewCoin(200, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(10, 0, "BTC"),
+			wantCollector: owner,
+		},
+		"fees go to the owner": {
+			makerFee:      NewAmountp(0, 1000000),
+			takerFee:      NewAmountp(0, 2000000),
+			offer:         coin.NewCoinp(200, 0, "ETH"),
+			wantMakerFee:  coin.NewCoinp(0, 200000000, "ETH"),
+			wantTakerFee:  coin.NewCoinp(0, 20000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(199, 800000000, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 980000000, "BTC"),
+			wantCollector: owner,
+		},
+		"fees go to the collector": {
+			makerFee:      NewAmountp(0, 1000000),
+			takerFee:      NewAmountp(0, 2000000),
+			feeCollector:  collector,
+			offer:         coin.NewCoinp(200, 0, "ETH"),
+			wantMakerFee:  coin.NewCoinp(0, 200000000, "ETH"),
+			wantTakerFee:  coin.NewCoinp(0, 20000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(199, 800000000, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 980000000, "BTC"),
+			wantCollector: collector,
+		},
+		"fees are rounded down": {
+			makerFee:      NewAmountp(0, 1000000),
+			takerFee:      NewAmountp(0, 1000000),
+			offer:         coin.NewCoinp(0, 10000, "ETH"),
+			wantMakerFee:  coin.NewCoinp(0, 10, "ETH"),
+			wantMakerETH:  coin.NewCoin(0, 9990, "ETH"),
+			wantTakerBTC:  coin.NewCoin(0, 500, "BTC"),
+			wantCollector: owner,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{maker, taker}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			market := &Market{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Owner:        owner,
+				Name:         "fees",
+				MakerFee:     tc.makerFee,
+				TakerFee:     tc.takerFee,
+				FeeCollector: tc.feeCollector,
+			}
+			assert.Nil(t, NewMarketBucket().Put(kv, market))
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+
+			assert.Nil(t, ctrl.CoinMint(kv, maker.Address(), coin.NewCoin(10, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, taker.Address(), *tc.offer))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			for _, msg := range []*CreateOrderMsg{
+				{
+					Metadata:    &weave.Metadata{Schema: 1},
+					Trader:      maker.Address(),
+					OrderBookID: orderbook.ID,
+					Offer:       coin.NewCoinp(10, 0, "BTC"),
+					Price:       NewAmountp(20, 0),
+				},
+				{
+					Metadata:    &weave.Metadata{Schema: 1},
+					Trader:      taker.Address(),
+					OrderBookID: orderbook.ID,
+					Offer:       tc.offer,
+					Price:       NewAmountp(0, 50000000),
+					TimeInForce: TimeInForce_ImmediateOrCancel,
+				},
+			} {
+				_, err := h.Deliver(ctx, kv, &weavetest.Tx{Msg: msg})
+				assert.Nil(t, err)
+			}
+
+			var trade Trade
+			assert.Nil(t, NewTradeBucket().One(kv, weavetest.SequenceID(1), &trade))
+			assert.Equal(t, tc.wantMakerFee, trade.MakerFee)
+			assert.Equal(t, tc.wantTakerFee, trade.TakerFee)
+
+			makerBalance, err := ctrl.Balance(kv, maker.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantMakerETH, balanceOf(makerBalance, "ETH"))
+			takerBalance, err := ctrl.Balance(kv, taker.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTakerBTC, balanceOf(takerBalance, "BTC"))
+
+			wantFees := coin.Coins{}
+			for _, fee := range []*coin.Coin{tc.wantMakerFee, tc.wantTakerFee} {
+				if fee != nil {
+					wantFees = append(wantFees, fee)
+				}
+			}
+			for _, fee := range wantFees {
+				balance, err := ctrl.Balance(kv, tc.wantCollector)
+				assert.Nil(t, err)
+				assert.Equal(t, *fee, balanceOf(balance, fee.Ticker))
+			}
+			if len(wantFees) == 0 {
+				_, err := ctrl.Balance(kv, tc.wantCollector)
+				if !errors.ErrNotFound.Is(err) {
+					t.Fatalf("collector must not have an account: %+v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchDiscountedFees(t *testing.T) {
+	maker := weavetest.NewCondition()
+	taker := weavetest.NewCondition()
+	owner := weavetest.NewCondition().Address()
+
+	now := time.Now()
+
+	// 25% off, at 2 IDEX per ETH and 40 IDEX per BTC
+	conf := &Configuration{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Owner:       owner,
+		FeeTicker:   "IDEX",
+		FeeDiscount: NewAmountp(0, 250000000),
+		FeePrices: []*FeePrice{
+			{Ticker: "ETH", Price: NewAmountp(2, 0)},
+			{Ticker: "BTC", Price: NewAmountp(40, 0)},
+		},
+	}
+	noBTCPrice := &Configuration{
+		Metadata:    conf.Metadata,
+		Owner:       conf.Owner,
+		FeeTicker:   conf.FeeTicker,
+		FeeDiscount: conf.FeeDiscount,
+		FeePrices:   conf.FeePrices[:1],
+	}
+
+	cases := map[string]struct {
+		conf *Configuration
+		// the taker buys 10 BTC from a single ask at 20 ETH, both pay 1% fees
+		makerDiscount bool
+		takerDiscount bool
+		makerIDEX     *coin.Coin
+		takerIDEX     *coin.Coin
+		wantMakerFee  *coin.Coin
+		wantTakerFee  *coin.Coin
+		// what maker and taker keep from the trade
+		wantMakerETH coin.Coin
+		wantTakerBTC coin.Coin
+	}{
+		"fees paid in IDEX": {
+			conf:          conf,
+			makerDiscount: true,
+			takerDiscount: true,
+			makerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			takerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			wantMakerFee:  coin.NewCoinp(3, 0, "IDEX"),
+			wantTakerFee:  coin.NewCoinp(3, 0, "IDEX"),
+			wantMakerETH:  coin.NewCoin(200, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(10, 0, "BTC"),
+		},
+		"only orders opting in are discounted": {
+			conf:          conf,
+			makerDiscount: true,
+			makerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			takerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			wantMakerFee:  coin.NewCoinp(3, 0, "IDEX"),
+			wantTakerFee:  coin.NewCoinp(0, 100000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(200, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 900000000, "BTC"),
+		},
+		"not enough IDEX falls back to the full fee": {
+			conf:          conf,
+			makerDiscount: true,
+			takerDiscount: true,
+			takerIDEX:     coin.NewCoinp(2, 0, "IDEX"),
+			wantMakerFee:  coin.NewCoinp(2, 0, "ETH"),
+			wantTakerFee:  coin.NewCoinp(0, 100000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(198, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 900000000, "BTC"),
+		},
+		"no price falls back to the full fee": {
+			conf:          noBTCPrice,
+			makerDiscount: true,
+			takerDiscount: true,
+			makerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			takerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			wantMakerFee:  coin.NewCoinp(3, 0, "IDEX"),
+			wantTakerFee:  coin.NewCoinp(0, 100000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(200, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 900000000, "BTC"),
+		},
+		"no configuration": {
+			makerDiscount: true,
+			takerDiscount: true,
+			makerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			takerIDEX:     coin.NewCoinp(10, 0, "IDEX"),
+			wantMakerFee:  coin.NewCoinp(2, 0, "ETH"),
+			wantTakerFee:  coin.NewCoinp(0, 100000000, "BTC"),
+			wantMakerETH:  coin.NewCoin(198, 0, "ETH"),
+			wantTakerBTC:  coin.NewCoin(9, 900000000, "BTC"),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{maker, taker}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+			if tc.conf != nil {
+				assert.Nil(t, gconf.Save(kv, packageName, tc.conf))
+			}
+
+			market := &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "fees",
+				MakerFee: NewAmountp(0, 10000000),
+				TakerFee: NewAmountp(0, 10000000),
+			}
+			assert.Nil(t, NewMarketBucket().Put(kv, market))
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+
+			assert.Nil(t, ctrl.CoinMint(kv, maker.Address(), coin.NewCoin(10, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, taker.Address(), coin.NewCoin(200, 0, "ETH")))
+			if tc.makerIDEX != nil {
+				assert.Nil(t, ctrl.CoinMint(kv, maker.Address(), *tc.makerIDEX))
+			}
+			if tc.takerIDEX != nil {
+				assert.Nil(t, ctrl.CoinMint(kv, taker.Address(), *tc.takerIDEX))
+			}
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			for _, msg := range []*CreateOrderMsg{
+				{
+					Metadata:      &weave.Metadata{Schema: 1},
+					Trader:        maker.Address(),
+					OrderBookID:   orderbook.ID,
+					Offer:         coin.NewCoinp(10, 0, "BTC"),
+					Price:         NewAmountp(20, 0),
+					DiscountedFee: tc.makerDiscount,
+				},
+				{
+					Metadata:      &weave.Metadata{Schema: 1},
+					Trader:        taker.Address(),
+					OrderBookID:   orderbook.ID,
+					Offer:         coin.NewCoinp(200, 0, "ETH"),
+					Price:         NewAmountp(0, 50000000),
+					TimeInForce:   TimeInForce_ImmediateOrCancel,
+					DiscountedFee: tc.takerDiscount,
+				},
+			} {
+				_, err := h.Deliver(ctx, kv, &weavetest.Tx{Msg: msg})
+				assert.Nil(t, err)
+			}
+
+			var trade Trade
+			assert.Nil(t, NewTradeBucket().One(kv, weavetest.SequenceID(1), &trade))
+			assert.Equal(t, tc.wantMakerFee, trade.MakerFee)
+			assert.Equal(t, tc.wantTakerFee, trade.TakerFee)
+
+			makerBalance, err := ctrl.Balance(kv, maker.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantMakerETH, balanceOf(makerBalance, "ETH"))
+			takerBalance, err := ctrl.Balance(kv, taker.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTakerBTC, balanceOf(takerBalance, "BTC"))
+
+			wantFees, err := coin.CombineCoins(*tc.wantMakerFee, *tc.wantTakerFee)
+			assert.Nil(t, err)
+			fees, err := ctrl.Balance(kv, owner)
+			assert.Nil(t, err)
+			assert.Equal(t, wantFees, fees)
+		})
+	}
+}
+
+func TestSelfTradePrevention(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+
+	// the trader buys BTC at 20 ETH, where its own ask of 5 BTC rests
+	// before the ask of 5 BTC of another trader
+	cases := map[string]struct {
+		mode        SelfTradePrevention
+		timeInForce TimeInForce
+		offer       *coin.Coin
+		wantErr     *errors.Error
+		// state of the incoming bid and the own ask afterwards
+		wantBidState   OrderState
+		wantBidReason  CancelReason
+		wantBidTrades  int
+		wantBidRemains coin.Coin
+		wantAskState   OrderState
+		wantAskReason  CancelReason
+		wantAskRemains coin.Coin
+		wantTraderBTC  coin.Coin
+		wantTraderETH  coin.Coin
+		wantOpenAsks   int64
+		wantOpenBids   int64
+	}{
+		"cancel newest": {
+			mode:           SelfTradePrevention_CancelNewest,
+			offer:          coin.NewCoinp(200, 0, "ETH"),
+			wantBidState:   OrderState_Cancel,
+			wantBidReason:  CancelReason_SelfTrade,
+			wantBidRemains: coin.NewCoin(200, 0, "ETH"),
+			wantAskState:   OrderState_Open,
+			wantAskRemains: coin.NewCoin(5, 0, "BTC"),
+			wantTraderBTC:  coin.NewCoin(0, 0, "BTC"),
+			wantTraderETH:  coin.NewCoin(200, 0, "ETH"),
+			wantOpenAsks:   2,
+		},
+		"cancel oldest": {
+			mode:           SelfTradePrevention_CancelOldest,
+			offer:          coin.NewCoinp(200, 0, "ETH"),
+			wantBidState:   OrderState_Open,
+			wantBidTrades:  1,
+			wantBidRemains: coin.NewCoin(100, 0, "ETH"),
+			wantAskState:   OrderState_Cancel,
+			wantAskReason:  CancelReason_SelfTrade,
+			wantAskRemains: coin.NewCoin(0, 0, "BTC"),
+			wantTraderBTC:  coin.NewCoin(10, 0, "BTC"),
+			wantTraderETH:  coin.NewCoin(0, 0, "ETH"),
+			wantOpenBids:   1,
+		},
+		"cancel both": {
+			mode:           SelfTradePrevention_CancelBoth,
+			offer:          coin.NewCoinp(200, 0, "ETH"),
+			wantBidState:   OrderState_Cancel,
+			wantBidReason:  CancelReason_SelfTrade,
+			wantBidRemains: coin.NewCoin(200, 0, "ETH"),
+			wantAskState:   OrderState_Cancel,
+			wantAskReason:  CancelReason_SelfTrade,
+			wantAskRemains: coin.NewCoin(0, 0, "BTC"),
+			wantTraderBTC:  coin.NewCoin(5, 0, "BTC"),
+			wantTraderETH:  coin.NewCoin(200, 0, "ETH"),
+			wantOpenAsks:   1,
+		},
+		"decrement the resting order": {
+			mode:           SelfTradePrevention_Decrement,
+			offer:          coin.NewCoinp(200, 0, "ETH"),
+			wantBidState:   OrderState_Done,
+			wantBidTrades:  1,
+			wantBidRemains: coin.NewCoin(0, 0, "ETH"),
+			wantAskState:   OrderState_Cancel,
+			wantAskReason:  CancelReason_SelfTrade,
+			wantAskRemains: coin.NewCoin(0, 0, "BTC"),
+			wantTraderBTC:  coin.NewCoin(10, 0, "BTC"),
+			wantTraderETH:  coin.NewCoin(100, 0, "ETH"),
+		},
+		"decrement the incoming order": {
+			mode:           SelfTradePrevention_Decrement,
+			offer:          coin.NewCoinp(60, 0, "ETH"),
+			wantBidState:   OrderState_Cancel,
+			wantBidReason:  CancelReason_SelfTrade,
+			wantBidRemains: coin.NewCoin(0, 0, "ETH"),
+			wantAskState:   OrderState_Open,
+			wantAskRemains: coin.NewCoin(2, 0, "BTC"),
+			wantTraderBTC:  coin.NewCoin(3, 0, "BTC"),
+			wantTraderETH:  coin.NewCoin(60, 0, "ETH"),
+			wantOpenAsks:   2,
+		},
+		"fill or kill is not filled by decrementing": {
+			mode:        SelfTradePrevention_Decrement,
+			timeInForce: TimeInForce_FillOrKill,
+			offer:       coin.NewCoinp(200, 0, "ETH"),
+			wantErr:     errors.ErrState,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{trader, other}}
+			ctrl := cash.NewController(cash.NewBucket())
+			h := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			market := &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    other.Address(),
+				Name:     "self-trade",
+			}
+			assert.Nil(t, NewMarketBucket().Put(kv, market))
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(5, 0, "BTC")))
+			assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), *tc.offer))
+			assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(5, 0, "BTC")))
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			for _, ask := range []weave.Address{trader.Address(), other.Address()} {
+				msg := &CreateOrderMsg{
+					Metadata:    &weave.Metadata{Schema: 1},
+					Trader:      ask,
+					OrderBookID: orderbook.ID,
+					Offer:       coin.NewCoinp(5, 0, "BTC"),
+					Price:       NewAmountp(20, 0),
+				}
+				_, err := h.Deliver(ctx, kv, &weavetest.Tx{Msg: msg})
+				assert.Nil(t, err)
+			}
+
+			bid := &CreateOrderMsg{
+				Metadata:            &weave.Metadata{Schema: 1},
+				Trader:              trader.Address(),
+				OrderBookID:         orderbook.ID,
+				Offer:               tc.offer,
+				Price:               NewAmountp(0, 50000000),
+				TimeInForce:         tc.timeInForce,
+				SelfTradePrevention: tc.mode,
+			}
+			cache := kv.CacheWrap()
+			_, err := h.Deliver(ctx, cache, &weavetest.Tx{Msg: bid})
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			assert.Nil(t, cache.Write())
+
+			orders := NewOrderBucket()
+			var order Order
+			assert.Nil(t, orders.One(kv, weavetest.SequenceID(3), &order))
+			assert.Equal(t, tc.wantBidState, order.OrderState)
+			assert.Equal(t, tc.wantBidReason, order.CancelReason)
+			assert.Equal(t, tc.wantBidTrades, len(order.TradeIds))
+			assert.Equal(t, tc.wantBidRemains, *order.RemainingOffer)
+
+			assert.Nil(t, orders.One(kv, weavetest.SequenceID(1), &order))
+			assert.Equal(t, tc.wantAskState, order.OrderState)
+			assert.Equal(t, tc.wantAskReason, order.CancelReason)
+			assert.Equal(t, tc.wantAskRemains, *order.RemainingOffer)
+			assert.Equal(t, 0, len(order.TradeIds))
+
+			balance, err := ctrl.Balance(kv, trader.Address())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantTraderBTC, balanceOf(balance, "BTC"))
+			assert.Equal(t, tc.wantTraderETH, balanceOf(balance, "ETH"))
+
+			var stored OrderBook
+			assert.Nil(t, NewOrderBookBucket().One(kv, orderbook.ID, &stored))
+			assert.Equal(t, tc.wantOpenAsks, stored.TotalAskCount)
+			assert.Equal(t, tc.wantOpenBids, stored.TotalBidCount)
+		})
+	}
+}
+
+func balanceOf(coins coin.Coins, ticker string) coin.Coin {
+	for _, c := range coins {
+		if c.Ticker == ticker {
+			return *c
+		}
+	}
+	return coin.NewCoin(0, 0, ticker)
+}
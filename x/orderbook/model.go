@@ -4,6 +4,7 @@ import (
 	"regexp"
 
 	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
 	"github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
 	"github.com/iov-one/weave/orm"
@@ -22,10 +23,13 @@ func (m *Market) SetID(id []byte) error {
 // Copy produces a new copy to fulfill the Model interface
 func (m *Market) Copy() orm.CloneableData {
 	return &Market{
-		Metadata: m.Metadata.Copy(),
-		ID:       copyBytes(m.ID),
-		Owner:    m.Owner.Clone(),
-		Name:     m.Name,
+		Metadata:     m.Metadata.Copy(),
+		ID:           copyBytes(m.ID),
+		Owner:        m.Owner.Clone(),
+		Name:         m.Name,
+		MakerFee:     m.MakerFee.Clone(),
+		TakerFee:     m.TakerFee.Clone(),
+		FeeCollector: m.FeeCollector.Clone(),
 	}
 }
 
@@ -40,10 +44,23 @@ func (m *Market) Validate() error {
 	if !validMarketName(m.Name) {
 		errs = errors.AppendField(errs, "MarketName", errors.ErrModel)
 	}
+	errs = errors.AppendField(errs, "MakerFee", validateFeeRate(m.MakerFee))
+	errs = errors.AppendField(errs, "TakerFee", validateFeeRate(m.TakerFee))
+	if m.FeeCollector != nil {
+		errs = errors.AppendField(errs, "FeeCollector", m.FeeCollector.Validate())
+	}
 
 	return errs
 }
 
+// feeCollector returns the address receiving the fees of the market
+func (m *Market) feeCollector() weave.Address {
+	if len(m.FeeCollector) != 0 {
+		return m.FeeCollector
+	}
+	return m.Owner
+}
+
 var _ morm.Model = (*OrderBook)(nil)
 
 // SetID is a minimal implementation, useful when the ID is a separate protobuf field
@@ -62,6 +79,11 @@ func (o *OrderBook) Copy() orm.CloneableData {
 		BidTicker:     o.BidTicker,
 		TotalAskCount: o.TotalAskCount,
 		TotalBidCount: o.TotalBidCount,
+		TickSize:      o.TickSize.Clone(),
+		LotSize:       o.LotSize.Clone(),
+		MinOffer:      o.MinOffer.Clone(),
+		StopTaskID:    copyBytes(o.StopTaskID),
+		OrderSequence: o.OrderSequence,
 	}
 }
 
@@ -87,9 +109,96 @@ func (o *OrderBook) Validate() error {
 		errs = errors.AppendField(errs, "TotalBidCount", errors.ErrModel)
 	}
 
+	errs = errors.AppendField(errs, "TickSize", validateOptionalAmount(o.TickSize))
+	errs = errors.AppendField(errs, "LotSize", validateOptionalAmount(o.LotSize))
+	errs = errors.AppendField(errs, "MinOffer", validateOptionalAmount(o.MinOffer))
+
 	return errs
 }
 
+// checkOrderRules ensures a new order placed on this orderbook respects the
+// tick size, lot size and minimum offer of the orderbook.
+// The price of a market order is only a bound, so it is not checked against the tick size
+func (o *OrderBook) checkOrderRules(offer coin.Coin, price *Amount, orderType OrderType) error {
+	if err := o.checkPriceRules(price, orderType); err != nil {
+		return err
+	}
+	return o.checkOfferRules(offer)
+}
+
+// checkPriceRules ensures the price of a limit order is a multiple of the tick size
+func (o *OrderBook) checkPriceRules(price *Amount, orderType OrderType) error {
+	if orderType == OrderType_Limit && !price.IsMultipleOf(o.TickSize) {
+		return errors.Wrap(errors.ErrInput, "price must be a multiple of the tick size")
+	}
+	return nil
+}
+
+// checkOfferRules ensures the offer is a multiple of the lot size and not below
+// the minimum offer
+func (o *OrderBook) checkOfferRules(offer coin.Coin) error {
+	amount := NewAmountp(offer.Whole, offer.Fractional)
+	if !amount.IsMultipleOf(o.LotSize) {
+		return errors.Wrap(errors.ErrInput, "offer must be a multiple of the lot size")
+	}
+	if !o.MinOffer.IsZero() && amount.Compare(o.MinOffer) < 0 {
+		return errors.Wrap(errors.ErrInput, "offer is below the minimum")
+	}
+	return nil
+}
+
+// rests returns true if the unmatched part of the order stays on the book
+func (o *Order) rests() bool {
+	return o.OrderType == OrderType_Limit && o.TimeInForce == TimeInForce_GoodTillCancel
+}
+
+// validateExecution ensures the order type and time in force are known,
+// and that market orders never rest on the book
+func validateExecution(orderType OrderType, tif TimeInForce) error {
+	if _, ok := OrderType_name[int32(orderType)]; !ok {
+		return errors.Wrap(errors.ErrInput, "unknown order type")
+	}
+	if _, ok := TimeInForce_name[int32(tif)]; !ok {
+		return errors.Wrap(errors.ErrInput, "unknown time in force")
+	}
+	if orderType == OrderType_Market && tif == TimeInForce_GoodTillCancel {
+		return errors.Wrap(errors.ErrInput, "market orders must be immediate or cancel, or fill or kill")
+	}
+	return nil
+}
+
+// validateSelfTradePrevention ensures the self-trade prevention mode is known
+func validateSelfTradePrevention(mode SelfTradePrevention) error {
+	if _, ok := SelfTradePrevention_name[int32(mode)]; !ok {
+		return errors.Wrap(errors.ErrInput, "unknown self-trade prevention")
+	}
+	return nil
+}
+
+// validatePostOnly ensures the post-only mode is known, and that post-only
+// orders are able to rest on the book
+func validatePostOnly(postOnly PostOnly, orderType OrderType, tif TimeInForce) error {
+	if _, ok := PostOnly_name[int32(postOnly)]; !ok {
+		return errors.Wrap(errors.ErrInput, "unknown post-only mode")
+	}
+	if postOnly != PostOnly_Disabled && (orderType != OrderType_Limit || tif != TimeInForce_GoodTillCancel) {
+		return errors.Wrap(errors.ErrInput, "post-only orders must be good till cancel limit orders")
+	}
+	return nil
+}
+
+// Opposite returns the other side of the orderbook
+func (s Side) Opposite() Side {
+	switch s {
+	case Side_Ask:
+		return Side_Bid
+	case Side_Bid:
+		return Side_Ask
+	default:
+		return Side_Invalid
+	}
+}
+
 var _ morm.Model = (*Order)(nil)
 
 // SetID is a minimal implementation, useful when the ID is a separate protobuf field
@@ -101,18 +210,27 @@ func (o *Order) SetID(id []byte) error {
 // Copy produces a new copy to fulfill the Model interface
 func (o *Order) Copy() orm.CloneableData {
 	return &Order{
-		Metadata:       o.Metadata.Copy(),
-		ID:             copyBytes(o.ID),
-		Trader:         copyBytes(o.Trader),
-		OrderBookID:    copyBytes(o.OrderBookID),
-		Side:           o.Side,
-		OrderState:     o.OrderState,
-		OriginalOffer:  o.OriginalOffer.Clone(),
-		RemainingOffer: o.RemainingOffer.Clone(),
-		Price:          o.Price.Clone(),
-		TradeIds:       copyBytesList(o.TradeIds),
-		CreatedAt:      o.CreatedAt,
-		UpdatedAt:      o.UpdatedAt,
+		Metadata:            o.Metadata.Copy(),
+		ID:                  copyBytes(o.ID),
+		Trader:              copyBytes(o.Trader),
+		OrderBookID:         copyBytes(o.OrderBookID),
+		Side:                o.Side,
+		OrderState:          o.OrderState,
+		OriginalOffer:       o.OriginalOffer.Clone(),
+		RemainingOffer:      o.RemainingOffer.Clone(),
+		Price:               o.Price.Clone(),
+		TradeIds:            copyBytesList(o.TradeIds),
+		CreatedAt:           o.CreatedAt,
+		UpdatedAt:           o.UpdatedAt,
+		OrderType:           o.OrderType,
+		TimeInForce:         o.TimeInForce,
+		ExpiresAt:           o.ExpiresAt,
+		ExpirationTaskID:    copyBytes(o.ExpirationTaskID),
+		DiscountedFee:       o.DiscountedFee,
+		SelfTradePrevention: o.SelfTradePrevention,
+		CancelReason:        o.CancelReason,
+		PostOnly:            o.PostOnly,
+		Sequence:            o.Sequence,
 	}
 }
 
@@ -149,6 +267,16 @@ func (o *Order) Validate() error {
 		errs = errors.Append(errs,
 			errors.Field("Price", errors.ErrState, "price must be positive"))
 	}
+	errs = errors.AppendField(errs, "TimeInForce", validateExecution(o.OrderType, o.TimeInForce))
+	errs = errors.AppendField(errs, "SelfTradePrevention", validateSelfTradePrevention(o.SelfTradePrevention))
+	errs = errors.AppendField(errs, "PostOnly", validatePostOnly(o.PostOnly, o.OrderType, o.TimeInForce))
+	if _, ok := CancelReason_name[int32(o.CancelReason)]; !ok {
+		errs = errors.AppendField(errs, "CancelReason", errors.ErrState)
+	} else if o.CancelReason != CancelReason_None && o.OrderState != OrderState_Cancel {
+		errs = errors.Append(errs,
+			errors.Field("CancelReason", errors.ErrState, "only cancelled orders have a reason"))
+	}
+	errs = errors.AppendField(errs, "ExpiresAt", o.ExpiresAt.Validate())
 	// TODO: valid trade ids (also rethink how we handle this? just use index and not in model?)
 
 	if err := o.UpdatedAt.Validate(); err != nil {
@@ -166,6 +294,111 @@ func (o *Order) Validate() error {
 	return errs
 }
 
+var _ morm.Model = (*StopOrder)(nil)
+
+// SetID is a minimal implementation, useful when the ID is a separate protobuf field
+func (s *StopOrder) SetID(id []byte) error {
+	s.ID = id
+	return nil
+}
+
+// Copy produces a new copy to fulfill the Model interface
+func (s *StopOrder) Copy() orm.CloneableData {
+	return &StopOrder{
+		Metadata:            s.Metadata.Copy(),
+		ID:                  copyBytes(s.ID),
+		Trader:              s.Trader.Clone(),
+		OrderBookID:         copyBytes(s.OrderBookID),
+		Side:                s.Side,
+		State:               s.State,
+		Direction:           s.Direction,
+		TriggerPrice:        s.TriggerPrice.Clone(),
+		Offer:               s.Offer.Clone(),
+		Price:               s.Price.Clone(),
+		OrderType:           s.OrderType,
+		TimeInForce:         s.TimeInForce,
+		SelfTradePrevention: s.SelfTradePrevention,
+		DiscountedFee:       s.DiscountedFee,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+		OrderID:             copyBytes(s.OrderID),
+	}
+}
+
+// Validate ensures the stop order is consistent
+func (s *StopOrder) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "ID", isGenID(s.ID, true))
+	errs = errors.AppendField(errs, "Trader", s.Trader.Validate())
+	errs = errors.AppendField(errs, "OrderBookID", isGenID(s.OrderBookID, false))
+	if s.Side != Side_Ask && s.Side != Side_Bid {
+		errs = errors.AppendField(errs, "Side", errors.ErrState)
+	}
+	if _, ok := StopOrderState_name[int32(s.State)]; !ok || s.State == StopOrderState_Invalid {
+		errs = errors.AppendField(errs, "State", errors.ErrState)
+	} else if s.State == StopOrderState_Triggered {
+		errs = errors.AppendField(errs, "OrderID", isGenID(s.OrderID, false))
+	}
+	errs = errors.AppendField(errs, "Direction", validateTriggerDirection(s.Direction))
+	errs = errors.AppendField(errs, "TriggerPrice", validatePositiveAmount(s.TriggerPrice))
+
+	if s.Offer == nil {
+		errs = errors.AppendField(errs, "Offer", errors.ErrEmpty)
+	} else if err := s.Offer.Validate(); err != nil {
+		errs = errors.AppendField(errs, "Offer", err)
+	}
+	errs = errors.AppendField(errs, "Price", validatePositiveAmount(s.Price))
+	errs = errors.AppendField(errs, "TimeInForce", validateStopExecution(s.OrderType, s.TimeInForce))
+	errs = errors.AppendField(errs, "SelfTradePrevention", validateSelfTradePrevention(s.SelfTradePrevention))
+
+	if err := s.CreatedAt.Validate(); err != nil {
+		errs = errors.AppendField(errs, "CreatedAt", err)
+	} else if s.CreatedAt == 0 {
+		errs = errors.AppendField(errs, "CreatedAt", errors.ErrEmpty)
+	}
+	if err := s.UpdatedAt.Validate(); err != nil {
+		errs = errors.AppendField(errs, "UpdatedAt", err)
+	} else if s.UpdatedAt == 0 {
+		errs = errors.AppendField(errs, "UpdatedAt", errors.ErrEmpty)
+	}
+
+	return errs
+}
+
+// validateTriggerDirection ensures the direction of a stop order is known
+func validateTriggerDirection(direction TriggerDirection) error {
+	if direction != TriggerDirection_Above && direction != TriggerDirection_Below {
+		return errors.Wrap(errors.ErrInput, "unknown trigger direction")
+	}
+	return nil
+}
+
+// validateStopExecution ensures the order placed by a stop order is valid.
+// A fill or kill order would fail the transaction that triggers it
+func validateStopExecution(orderType OrderType, tif TimeInForce) error {
+	if err := validateExecution(orderType, tif); err != nil {
+		return err
+	}
+	if tif == TimeInForce_FillOrKill {
+		return errors.Wrap(errors.ErrInput, "stop orders cannot be fill or kill")
+	}
+	return nil
+}
+
+// triggered returns true if a stop order with the given direction and
+// trigger price is activated by the last trade price
+func triggered(direction TriggerDirection, triggerPrice, lastPrice *Amount) bool {
+	switch direction {
+	case TriggerDirection_Above:
+		return lastPrice.Compare(triggerPrice) >= 0
+	case TriggerDirection_Below:
+		return lastPrice.Compare(triggerPrice) <= 0
+	default:
+		return false
+	}
+}
+
 var _ morm.Model = (*Trade)(nil)
 
 // SetID is a minimal implementation, useful when the ID is a separate protobuf field
@@ -186,6 +419,8 @@ func (t *Trade) Copy() orm.CloneableData {
 		MakerPaid:   t.MakerPaid.Clone(),
 		TakerPaid:   t.TakerPaid.Clone(),
 		ExecutedAt:  t.ExecutedAt,
+		MakerFee:    t.MakerFee.Clone(),
+		TakerFee:    t.TakerFee.Clone(),
 	}
 }
 
@@ -213,6 +448,13 @@ func (t *Trade) Validate() error {
 		errs = errors.AppendField(errs, "TakerPaid", err)
 	}
 
+	if t.MakerFee != nil {
+		errs = errors.AppendField(errs, "MakerFee", t.MakerFee.Validate())
+	}
+	if t.TakerFee != nil {
+		errs = errors.AppendField(errs, "TakerFee", t.TakerFee.Validate())
+	}
+
 	errs = errors.AppendField(errs, "ExecutedAt", t.ExecutedAt.Validate())
 	if err := t.ExecutedAt.Validate(); err != nil {
 		errors.AppendField(errs, "ExecutedAt", t.ExecutedAt.Validate())
@@ -257,3 +499,126 @@ func copyBytesList(in [][]byte) [][]byte {
 	}
 	return cpy
 }
+
+var _ morm.Model = (*Candle)(nil)
+
+// SetID is a minimal implementation, useful when the ID is a separate protobuf field
+func (c *Candle) SetID(id []byte) error {
+	c.ID = id
+	return nil
+}
+
+// Copy produces a new copy to fulfill the Model interface
+func (c *Candle) Copy() orm.CloneableData {
+	return &Candle{
+		Metadata:    c.Metadata.Copy(),
+		ID:          copyBytes(c.ID),
+		OrderBookID: copyBytes(c.OrderBookID),
+		Interval:    c.Interval,
+		Start:       c.Start,
+		Open:        c.Open.Clone(),
+		High:        c.High.Clone(),
+		Low:         c.Low.Clone(),
+		Close:       c.Close.Clone(),
+		BaseVolume:  c.BaseVolume.Clone(),
+		QuoteVolume: c.QuoteVolume.Clone(),
+		TradeCount:  c.TradeCount,
+	}
+}
+
+// Validate ensures the candle aggregates at least one trade
+func (c *Candle) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "OrderBookID", isGenID(c.OrderBookID, false))
+	if c.Interval.Seconds() == 0 {
+		errs = errors.AppendField(errs, "Interval", errors.ErrInput)
+	}
+	if err := c.Start.Validate(); err != nil {
+		errs = errors.AppendField(errs, "Start", err)
+	}
+	errs = errors.AppendField(errs, "Open", validateOptionalAmount(c.Open))
+	errs = errors.AppendField(errs, "High", validateOptionalAmount(c.High))
+	errs = errors.AppendField(errs, "Low", validateOptionalAmount(c.Low))
+	errs = errors.AppendField(errs, "Close", validateOptionalAmount(c.Close))
+	if c.Open == nil || c.High == nil || c.Low == nil || c.Close == nil {
+		errs = errors.AppendField(errs, "Prices", errors.ErrEmpty)
+	}
+
+	if c.BaseVolume == nil {
+		errs = errors.AppendField(errs, "BaseVolume", errors.ErrEmpty)
+	} else if err := c.BaseVolume.Validate(); err != nil {
+		errs = errors.AppendField(errs, "BaseVolume", err)
+	}
+	if c.QuoteVolume == nil {
+		errs = errors.AppendField(errs, "QuoteVolume", errors.ErrEmpty)
+	} else if err := c.QuoteVolume.Validate(); err != nil {
+		errs = errors.AppendField(errs, "QuoteVolume", err)
+	}
+	if c.TradeCount <= 0 {
+		errs = errors.AppendField(errs, "TradeCount", errors.ErrInput)
+	}
+
+	return errs
+}
+
+var _ morm.Model = (*Ticker)(nil)
+
+// SetID is a minimal implementation, useful when the ID is a separate protobuf field
+func (t *Ticker) SetID(id []byte) error {
+	t.ID = id
+	return nil
+}
+
+// Copy produces a new copy to fulfill the Model interface
+func (t *Ticker) Copy() orm.CloneableData {
+	return &Ticker{
+		Metadata:       t.Metadata.Copy(),
+		ID:             copyBytes(t.ID),
+		LastPrice:      t.LastPrice.Clone(),
+		LastTradeAt:    t.LastTradeAt,
+		BestBid:        t.BestBid.Clone(),
+		BestAsk:        t.BestAsk.Clone(),
+		WindowStart:    t.WindowStart,
+		DayHigh:        t.DayHigh.Clone(),
+		DayLow:         t.DayLow.Clone(),
+		DayBaseVolume:  t.DayBaseVolume.Clone(),
+		DayQuoteVolume: t.DayQuoteVolume.Clone(),
+		DayTradeCount:  t.DayTradeCount,
+	}
+}
+
+// Validate ensures the ticker is consistent. The 24h statistics are required
+// as long as the window holds any trade
+func (t *Ticker) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "ID", isGenID(t.ID, false))
+	errs = errors.AppendField(errs, "LastPrice", validateOptionalAmount(t.LastPrice))
+	if t.LastPrice != nil {
+		if err := t.LastTradeAt.Validate(); err != nil {
+			errs = errors.AppendField(errs, "LastTradeAt", err)
+		}
+	}
+	errs = errors.AppendField(errs, "BestBid", validateOptionalAmount(t.BestBid))
+	errs = errors.AppendField(errs, "BestAsk", validateOptionalAmount(t.BestAsk))
+
+	errs = errors.AppendField(errs, "DayHigh", validateOptionalAmount(t.DayHigh))
+	errs = errors.AppendField(errs, "DayLow", validateOptionalAmount(t.DayLow))
+	if t.DayBaseVolume != nil {
+		errs = errors.AppendField(errs, "DayBaseVolume", t.DayBaseVolume.Validate())
+	}
+	if t.DayQuoteVolume != nil {
+		errs = errors.AppendField(errs, "DayQuoteVolume", t.DayQuoteVolume.Validate())
+	}
+	switch {
+	case t.DayTradeCount < 0:
+		errs = errors.AppendField(errs, "DayTradeCount", errors.ErrInput)
+	case t.DayTradeCount > 0:
+		if t.DayHigh == nil || t.DayLow == nil || t.DayBaseVolume == nil || t.DayQuoteVolume == nil {
+			errs = errors.AppendField(errs, "DayStatistics", errors.ErrEmpty)
+		}
+	}
+
+	return errs
+}
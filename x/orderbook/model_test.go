@@ -70,6 +70,27 @@ func TestValidateOrderBook(t *testing.T) {
 				"TotalBidCount": nil,
 			},
 		},
+		"failure, negative trading rules": {
+			model: &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				ID:        weavetest.SequenceID(13),
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BAR",
+				BidTicker: "FOO",
+				TickSize:  NewAmountp(0, -1000),
+				LotSize:   NewAmountp(-1, 0),
+				MinOffer:  NewAmountp(5, 0),
+			},
+			wantErrs: map[string]*errors.Error{
+				"ID":        nil,
+				"MarketID":  nil,
+				"AskTicker": nil,
+				"BidTicker": nil,
+				"TickSize":  errors.ErrInput,
+				"LotSize":   errors.ErrInput,
+				"MinOffer":  nil,
+			},
+		},
 	}
 	for testName, tc := range cases {
 		t.Run(testName, func(t *testing.T) {
@@ -81,6 +102,73 @@ func TestValidateOrderBook(t *testing.T) {
 	}
 }
 
+func TestOrderBookCheckOrderRules(t *testing.T) {
+	orderbook := &OrderBook{
+		AskTicker: "BAR",
+		BidTicker: "FOO",
+		TickSize:  NewAmountp(0, 50000000),
+		LotSize:   NewAmountp(0, 10000000),
+		MinOffer:  NewAmountp(1, 0),
+	}
+
+	cases := map[string]struct {
+		orderbook *OrderBook
+		offer     coin.Coin
+		price     *Amount
+		orderType OrderType
+		wantErr   *errors.Error
+	}{
+		"no rules": {
+			orderbook: &OrderBook{AskTicker: "BAR", BidTicker: "FOO"},
+			offer:     coin.NewCoin(0, 1, "BAR"),
+			price:     NewAmountp(0, 123456789),
+		},
+		"valid order": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(1, 20000000, "BAR"),
+			price:     NewAmountp(2, 150000000),
+		},
+		"price off tick": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(1, 20000000, "BAR"),
+			price:     NewAmountp(2, 150000001),
+			wantErr:   errors.ErrInput,
+		},
+		"market price bound off tick": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(1, 20000000, "BAR"),
+			price:     NewAmountp(2, 150000001),
+			orderType: OrderType_Market,
+		},
+		"offer off lot": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(1, 25000000, "FOO"),
+			price:     NewAmountp(2, 0),
+			wantErr:   errors.ErrInput,
+		},
+		"offer below minimum": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(0, 990000000, "FOO"),
+			price:     NewAmountp(2, 0),
+			wantErr:   errors.ErrInput,
+		},
+		"offer at minimum": {
+			orderbook: orderbook,
+			offer:     coin.NewCoin(1, 0, "FOO"),
+			price:     NewAmountp(2, 0),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			err := tc.orderbook.checkOrderRules(tc.offer, tc.price, tc.orderType)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
 func TestValidateMarket(t *testing.T) {
 	cases := map[string]struct {
 		model    morm.Model
@@ -220,6 +308,117 @@ func TestValidateOrder(t *testing.T) {
 				"CreatedAt":      errors.ErrEmpty,
 			},
 		},
+		"cancel reason of an open order": {
+			model: &Order{
+				Metadata:       &weave.Metadata{Schema: 1},
+				ID:             weavetest.SequenceID(17),
+				Trader:         weavetest.NewCondition().Address(),
+				OrderBookID:    weavetest.SequenceID(5),
+				Side:           Side_Ask,
+				OrderState:     OrderState_Open,
+				OriginalOffer:  coin.NewCoinp(100, 0, "ETH"),
+				RemainingOffer: coin.NewCoinp(50, 17, "ETH"),
+				Price:          NewAmountp(121, 0),
+				CreatedAt:      now,
+				UpdatedAt:      now,
+				CancelReason:   CancelReason_SelfTrade,
+			},
+			wantErrs: map[string]*errors.Error{
+				"OrderState":          nil,
+				"SelfTradePrevention": nil,
+				"CancelReason":        errors.ErrState,
+			},
+		},
+	}
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			err := tc.model.Validate()
+			for field, wantErr := range tc.wantErrs {
+				assert.FieldError(t, err, field, wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateStopOrder(t *testing.T) {
+	now := weave.AsUnixTime(time.Now())
+
+	cases := map[string]struct {
+		model    morm.Model
+		wantErrs map[string]*errors.Error
+	}{
+		"success, pending": {
+			model: &StopOrder{
+				Metadata:     &weave.Metadata{Schema: 1},
+				ID:           weavetest.SequenceID(3),
+				Trader:       weavetest.NewCondition().Address(),
+				OrderBookID:  weavetest.SequenceID(5),
+				Side:         Side_Ask,
+				State:        StopOrderState_Pending,
+				Direction:    TriggerDirection_Below,
+				TriggerPrice: NewAmountp(90, 0),
+				Offer:        coin.NewCoinp(100, 0, "ETH"),
+				Price:        NewAmountp(85, 0),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+			wantErrs: map[string]*errors.Error{
+				"ID":           nil,
+				"Trader":       nil,
+				"OrderBookID":  nil,
+				"Side":         nil,
+				"State":        nil,
+				"OrderID":      nil,
+				"Direction":    nil,
+				"TriggerPrice": nil,
+				"Offer":        nil,
+				"Price":        nil,
+				"TimeInForce":  nil,
+				"CreatedAt":    nil,
+				"UpdatedAt":    nil,
+			},
+		},
+		"triggered without order": {
+			model: &StopOrder{
+				Metadata:     &weave.Metadata{Schema: 1},
+				ID:           weavetest.SequenceID(3),
+				Trader:       weavetest.NewCondition().Address(),
+				OrderBookID:  weavetest.SequenceID(5),
+				Side:         Side_Ask,
+				State:        StopOrderState_Triggered,
+				Direction:    TriggerDirection_Below,
+				TriggerPrice: NewAmountp(90, 0),
+				Offer:        coin.NewCoinp(100, 0, "ETH"),
+				Price:        NewAmountp(85, 0),
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+			wantErrs: map[string]*errors.Error{
+				"State":   nil,
+				"OrderID": errors.ErrEmpty,
+			},
+		},
+		"invalid trigger": {
+			model: &StopOrder{
+				Metadata:     &weave.Metadata{Schema: 1},
+				ID:           weavetest.SequenceID(3),
+				Trader:       weavetest.NewCondition().Address(),
+				OrderBookID:  weavetest.SequenceID(5),
+				Side:         Side_Ask,
+				State:        StopOrderState_Pending,
+				TriggerPrice: NewAmountp(-90, 0),
+				Offer:        coin.NewCoinp(100, 0, "ETH"),
+				Price:        NewAmountp(85, 0),
+				TimeInForce:  TimeInForce_FillOrKill,
+			},
+			wantErrs: map[string]*errors.Error{
+				"Direction":    errors.ErrInput,
+				"TriggerPrice": errors.ErrInput,
+				"TimeInForce":  errors.ErrInput,
+				"CreatedAt":    errors.ErrEmpty,
+				"UpdatedAt":    errors.ErrEmpty,
+			},
+		},
 	}
 	for testName, tc := range cases {
 		t.Run(testName, func(t *testing.T) {
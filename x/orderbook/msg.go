@@ -1,6 +1,8 @@
 package orderbook
 
 import (
+	"fmt"
+
 	"github.com/iov-one/weave"
 	coin "github.com/iov-one/weave/coin"
 	"github.com/iov-one/weave/errors"
@@ -13,11 +15,31 @@ func init() {
 	migration.MustRegister(1, &CreateOrderBookMsg{}, migration.NoModification)
 	migration.MustRegister(1, &CreateOrderMsg{}, migration.NoModification)
 	migration.MustRegister(1, &CancelOrderMsg{}, migration.NoModification)
+	migration.MustRegister(1, &ExpireOrderMsg{}, migration.NoModification)
+	migration.MustRegister(1, &ActivateStopsMsg{}, migration.NoModification)
+	migration.MustRegister(1, &CreateMarketMsg{}, migration.NoModification)
+	migration.MustRegister(1, &UpdateMarketOwnerMsg{}, migration.NoModification)
+	migration.MustRegister(1, &UpdateConfigurationMsg{}, migration.NoModification)
+	migration.MustRegister(1, &CreateStopOrderMsg{}, migration.NoModification)
+	migration.MustRegister(1, &CancelStopOrderMsg{}, migration.NoModification)
+	migration.MustRegister(1, &ReplaceOrderMsg{}, migration.NoModification)
+	migration.MustRegister(1, &CreateOrdersMsg{}, migration.NoModification)
+	migration.MustRegister(1, &CancelAllOrdersMsg{}, migration.NoModification)
 }
 
 var _ weave.Msg = (*CreateOrderBookMsg)(nil)
 var _ weave.Msg = (*CreateOrderMsg)(nil)
 var _ weave.Msg = (*CancelOrderMsg)(nil)
+var _ weave.Msg = (*ExpireOrderMsg)(nil)
+var _ weave.Msg = (*ActivateStopsMsg)(nil)
+var _ weave.Msg = (*CreateMarketMsg)(nil)
+var _ weave.Msg = (*UpdateMarketOwnerMsg)(nil)
+var _ weave.Msg = (*UpdateConfigurationMsg)(nil)
+var _ weave.Msg = (*CreateStopOrderMsg)(nil)
+var _ weave.Msg = (*CancelStopOrderMsg)(nil)
+var _ weave.Msg = (*ReplaceOrderMsg)(nil)
+var _ weave.Msg = (*CreateOrdersMsg)(nil)
+var _ weave.Msg = (*CancelAllOrdersMsg)(nil)
 
 // ROUTING, Path method fulfills weave.Msg interface to allow routing
 
@@ -36,6 +58,56 @@ func (CancelOrderMsg) Path() string {
 	return "order/cancel"
 }
 
+// Path returns the routing path for this message.
+func (ExpireOrderMsg) Path() string {
+	return "order/expire"
+}
+
+// Path returns the routing path for this message.
+func (ActivateStopsMsg) Path() string {
+	return "order/activate_stops"
+}
+
+// Path returns the routing path for this message.
+func (CreateMarketMsg) Path() string {
+	return "order/create_market"
+}
+
+// Path returns the routing path for this message.
+func (UpdateMarketOwnerMsg) Path() string {
+	return "order/update_market_owner"
+}
+
+// Path returns the routing path for this message.
+func (UpdateConfigurationMsg) Path() string {
+	return "order/update_configuration"
+}
+
+// Path returns the routing path for this message.
+func (CreateStopOrderMsg) Path() string {
+	return "order/create_stop"
+}
+
+// Path returns the routing path for this message.
+func (CancelStopOrderMsg) Path() string {
+	return "order/cancel_stop"
+}
+
+// Path returns the routing path for this message.
+func (ReplaceOrderMsg) Path() string {
+	return "order/replace"
+}
+
+// Path returns the routing path for this message.
+func (CreateOrdersMsg) Path() string {
+	return "order/create_batch"
+}
+
+// Path returns the routing path for this message.
+func (CancelAllOrdersMsg) Path() string {
+	return "order/cancel_all"
+}
+
 // Validate ensures the CreateOrderBookMsg is valid
 func (m CreateOrderBookMsg) Validate() error {
 	var errs error
@@ -53,6 +125,10 @@ func (m CreateOrderBookMsg) Validate() error {
 		errs = errors.Append(errs,
 			errors.Field("BidTicker", errors.ErrCurrency, "ask must be before bid"))
 	}
+
+	errs = errors.AppendField(errs, "TickSize", validateOptionalAmount(m.TickSize))
+	errs = errors.AppendField(errs, "LotSize", validateOptionalAmount(m.LotSize))
+	errs = errors.AppendField(errs, "MinOffer", validateOptionalAmount(m.MinOffer))
 	return errs
 }
 
@@ -79,6 +155,17 @@ func (m CreateOrderMsg) Validate() error {
 		errs = errors.Append(errs,
 			errors.Field("Price", errors.ErrInput, "price must be positive"))
 	}
+
+	errs = errors.AppendField(errs, "TimeInForce", validateExecution(m.OrderType, m.TimeInForce))
+	errs = errors.AppendField(errs, "SelfTradePrevention", validateSelfTradePrevention(m.SelfTradePrevention))
+	errs = errors.AppendField(errs, "PostOnly", validatePostOnly(m.PostOnly, m.OrderType, m.TimeInForce))
+
+	if err := m.ExpiresAt.Validate(); err != nil {
+		errs = errors.AppendField(errs, "ExpiresAt", err)
+	} else if m.ExpiresAt != 0 && (m.OrderType != OrderType_Limit || m.TimeInForce != TimeInForce_GoodTillCancel) {
+		errs = errors.Append(errs,
+			errors.Field("ExpiresAt", errors.ErrInput, "only good till cancel limit orders can expire"))
+	}
 	return errs
 }
 
@@ -91,6 +178,192 @@ func (m CancelOrderMsg) Validate() error {
 	return errs
 }
 
+// Validate ensures the ReplaceOrderMsg is valid. At least one of price and
+// remaining offer must be set
+func (m ReplaceOrderMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "OrderID", validateID(m.OrderID))
+
+	if m.Price == nil && m.RemainingOffer == nil {
+		return errors.Append(errs,
+			errors.Field("Price", errors.ErrEmpty, "price or remaining offer required"))
+	}
+	if m.Price != nil {
+		errs = errors.AppendField(errs, "Price", validatePositiveAmount(m.Price))
+	}
+	if m.RemainingOffer != nil {
+		if err := m.RemainingOffer.Validate(); err != nil {
+			errs = errors.AppendField(errs, "RemainingOffer", err)
+		} else if !m.RemainingOffer.IsPositive() {
+			errs = errors.Append(errs,
+				errors.Field("RemainingOffer", errors.ErrInput, "remaining offer must be positive"))
+		}
+	}
+	return errs
+}
+
+// Validate ensures the CreateOrdersMsg is valid. Every order must be valid
+// as a CreateOrderMsg of the trader
+func (m CreateOrdersMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "TraderID", m.Trader.Validate())
+
+	switch n := len(m.Orders); {
+	case n == 0:
+		errs = errors.AppendField(errs, "Orders", errors.ErrEmpty)
+	case n > maxBatchOrders:
+		errs = errors.Append(errs,
+			errors.Field("Orders", errors.ErrInput, fmt.Sprintf("at most %d orders", maxBatchOrders)))
+	}
+	for i := range m.Orders {
+		if m.Orders[i] == nil {
+			errs = errors.AppendField(errs, fmt.Sprintf("Orders.%d", i), errors.ErrEmpty)
+			continue
+		}
+		errs = errors.AppendField(errs, fmt.Sprintf("Orders.%d", i), m.orderMsg(i).Validate())
+	}
+	return errs
+}
+
+// orderMsg returns the i-th order of the batch as a CreateOrderMsg
+func (m CreateOrdersMsg) orderMsg(i int) *CreateOrderMsg {
+	o := m.Orders[i]
+	return &CreateOrderMsg{
+		Metadata:            m.Metadata,
+		Trader:              m.Trader,
+		OrderBookID:         o.OrderBookID,
+		Offer:               o.Offer,
+		Price:               o.Price,
+		OrderType:           o.OrderType,
+		TimeInForce:         o.TimeInForce,
+		ExpiresAt:           o.ExpiresAt,
+		DiscountedFee:       o.DiscountedFee,
+		SelfTradePrevention: o.SelfTradePrevention,
+		PostOnly:            o.PostOnly,
+	}
+}
+
+// Validate ensures the CancelAllOrdersMsg is valid
+func (m CancelAllOrdersMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "TraderID", m.Trader.Validate())
+	if len(m.OrderBookID) != 0 {
+		errs = errors.AppendField(errs, "OrderBookID", validateID(m.OrderBookID))
+	}
+	if _, ok := Side_name[int32(m.Side)]; !ok {
+		errs = errors.AppendField(errs, "Side", errors.ErrInput)
+	}
+	return errs
+}
+
+// Validate ensures the ExpireOrderMsg is valid
+func (m ExpireOrderMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "OrderID", validateID(m.OrderID))
+	return errs
+}
+
+// Validate ensures the ActivateStopsMsg is valid
+func (m ActivateStopsMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "OrderBookID", validateID(m.OrderBookID))
+	return errs
+}
+
+// Validate ensures the CreateMarketMsg is valid
+func (m CreateMarketMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "Owner", m.Owner.Validate())
+
+	if !validMarketName(m.Name) {
+		errs = errors.Append(errs,
+			errors.Field("Name", errors.ErrInput, "invalid market name"))
+	}
+	errs = errors.AppendField(errs, "MakerFee", validateFeeRate(m.MakerFee))
+	errs = errors.AppendField(errs, "TakerFee", validateFeeRate(m.TakerFee))
+	if m.FeeCollector != nil {
+		errs = errors.AppendField(errs, "FeeCollector", m.FeeCollector.Validate())
+	}
+	return errs
+}
+
+// Validate ensures the UpdateMarketOwnerMsg is valid
+func (m UpdateMarketOwnerMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "MarketID", validateID(m.MarketID))
+	errs = errors.AppendField(errs, "NewOwner", m.NewOwner.Validate())
+	return errs
+}
+
+// Validate skips all fields of the patch that are not set, and ensures the
+// set ones are valid
+func (m UpdateConfigurationMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	c := m.Patch
+	if c == nil {
+		return errors.AppendField(errs, "Patch", errors.ErrEmpty)
+	}
+	if len(c.Owner) != 0 {
+		errs = errors.AppendField(errs, "Owner", c.Owner.Validate())
+	}
+	if c.FeeTicker != "" && !coin.IsCC(c.FeeTicker) {
+		errs = errors.AppendField(errs, "FeeTicker", errors.ErrCurrency)
+	}
+	errs = errors.AppendField(errs, "FeeDiscount", validateFeeRate(c.FeeDiscount))
+	errs = errors.AppendField(errs, "FeePrices", validateFeePrices(c.FeePrices, c.FeeTicker))
+	return errs
+}
+
+// Validate ensures the CreateStopOrderMsg is valid
+func (m CreateStopOrderMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "TraderID", m.Trader.Validate())
+	errs = errors.AppendField(errs, "OrderBookID", validateID(m.OrderBookID))
+	errs = errors.AppendField(errs, "Direction", validateTriggerDirection(m.Direction))
+	errs = errors.AppendField(errs, "TriggerPrice", validatePositiveAmount(m.TriggerPrice))
+
+	if m.Offer == nil {
+		errs = errors.AppendField(errs, "Offer", errors.ErrEmpty)
+	} else if err := m.Offer.Validate(); err != nil {
+		errs = errors.AppendField(errs, "Offer", err)
+	} else if !m.Offer.IsPositive() {
+		errs = errors.Append(errs,
+			errors.Field("Offer", errors.ErrInput, "offer must be positive"))
+	}
+
+	errs = errors.AppendField(errs, "Price", validatePositiveAmount(m.Price))
+	errs = errors.AppendField(errs, "TimeInForce", validateStopExecution(m.OrderType, m.TimeInForce))
+	errs = errors.AppendField(errs, "SelfTradePrevention", validateSelfTradePrevention(m.SelfTradePrevention))
+	return errs
+}
+
+// Validate ensures the CancelStopOrderMsg is valid
+func (m CancelStopOrderMsg) Validate() error {
+	var errs error
+
+	errs = errors.AppendField(errs, "Metadata", m.Metadata.Validate())
+	errs = errors.AppendField(errs, "StopOrderID", validateID(m.StopOrderID))
+	return errs
+}
+
 // validateID returns an error if this is not an 8-byte ID
 // as expected for orm.IDGenBucket
 func validateID(id []byte) error {
@@ -2,6 +2,7 @@ package orderbook
 
 import (
 	"testing"
+	"time"
 
 	"github.com/iov-one/weave"
 	coin "github.com/iov-one/weave/coin"
@@ -23,6 +24,38 @@ func TestValidateCreateOrderBookMsg(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"success with trading rules": {
+			msg: &CreateOrderBookMsg{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(5),
+				AskTicker: "BAR",
+				BidTicker: "FOO",
+				TickSize:  NewAmountp(0, 1000),
+				LotSize:   NewAmountp(0, 0),
+				MinOffer:  NewAmountp(10, 0),
+			},
+			wantErr: nil,
+		},
+		"negative min offer": {
+			msg: &CreateOrderBookMsg{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(5),
+				AskTicker: "BAR",
+				BidTicker: "FOO",
+				MinOffer:  NewAmountp(-10, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"invalid tick size": {
+			msg: &CreateOrderBookMsg{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(5),
+				AskTicker: "BAR",
+				BidTicker: "FOO",
+				TickSize:  NewAmountp(1, -5),
+			},
+			wantErr: errors.ErrState,
+		},
 		"missing metadata": {
 			msg: &CreateOrderBookMsg{
 				MarketID:  weavetest.SequenceID(5),
@@ -136,6 +169,264 @@ func TestValidateCancelOrderMsg(t *testing.T) {
 	}
 }
 
+func TestValidateReplaceOrderMsg(t *testing.T) {
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success, price": {
+			msg: &ReplaceOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				OrderID:  weavetest.SequenceID(5),
+				Price:    NewAmountp(11, 0),
+			},
+			wantErr: nil,
+		},
+		"success, remaining offer": {
+			msg: &ReplaceOrderMsg{
+				Metadata:       &weave.Metadata{Schema: 1},
+				OrderID:        weavetest.SequenceID(5),
+				RemainingOffer: coin.NewCoinp(100, 0, "ETH"),
+			},
+			wantErr: nil,
+		},
+		"nothing to replace": {
+			msg: &ReplaceOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				OrderID:  weavetest.SequenceID(5),
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"zero price": {
+			msg: &ReplaceOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				OrderID:  weavetest.SequenceID(5),
+				Price:    NewAmountp(0, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"zero remaining offer": {
+			msg: &ReplaceOrderMsg{
+				Metadata:       &weave.Metadata{Schema: 1},
+				OrderID:        weavetest.SequenceID(5),
+				RemainingOffer: coin.NewCoinp(0, 0, "ETH"),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"missing order id": {
+			msg: &ReplaceOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Price:    NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateOrdersMsg(t *testing.T) {
+	trader := weavetest.NewCondition().Address()
+	order := &BatchOrder{
+		OrderBookID: weavetest.SequenceID(12345),
+		Offer:       coin.NewCoinp(100, 12345, "ETH"),
+		Price:       NewAmountp(11, 0),
+	}
+	tooMany := make([]*BatchOrder, maxBatchOrders+1)
+	for i := range tooMany {
+		tooMany[i] = order
+	}
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+				Orders:   []*BatchOrder{order, order},
+			},
+			wantErr: nil,
+		},
+		"no orders": {
+			msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"too many orders": {
+			msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+				Orders:   tooMany,
+			},
+			wantErr: errors.ErrInput,
+		},
+		"invalid order": {
+			msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+				Orders: []*BatchOrder{order, {
+					OrderBookID: weavetest.SequenceID(12345),
+					Offer:       coin.NewCoinp(100, 12345, "ETH"),
+					Price:       NewAmountp(11, 0),
+					OrderType:   OrderType_Market,
+				}},
+			},
+			wantErr: errors.ErrInput,
+		},
+		"missing trader": {
+			msg: &CreateOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Orders:   []*BatchOrder{order},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCancelAllOrdersMsg(t *testing.T) {
+	trader := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &CancelAllOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+			},
+			wantErr: nil,
+		},
+		"success, one side of an orderbook": {
+			msg: &CancelAllOrdersMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(5),
+				Side:        Side_Bid,
+			},
+			wantErr: nil,
+		},
+		"bad orderbook id": {
+			msg: &CancelAllOrdersMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: []byte{0, 0, 1},
+			},
+			wantErr: errors.ErrInput,
+		},
+		"unknown side": {
+			msg: &CancelAllOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Trader:   trader,
+				Side:     Side(7),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"missing trader": {
+			msg: &CancelAllOrdersMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateExpireOrderMsg(t *testing.T) {
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &ExpireOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				OrderID:  weavetest.SequenceID(5),
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &ExpireOrderMsg{
+				OrderID: weavetest.SequenceID(5),
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"missing order id": {
+			msg: &ExpireOrderMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateActivateStopsMsg(t *testing.T) {
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &ActivateStopsMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				OrderBookID: weavetest.SequenceID(5),
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &ActivateStopsMsg{
+				OrderBookID: weavetest.SequenceID(5),
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"missing orderbook id": {
+			msg: &ActivateStopsMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
 func TestValidateCreateOrderMsg(t *testing.T) {
 	trader := weavetest.NewCondition().Address()
 
@@ -153,6 +444,99 @@ func TestValidateCreateOrderMsg(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"success, market fill or kill": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				OrderType:   OrderType_Market,
+				TimeInForce: TimeInForce_FillOrKill,
+			},
+			wantErr: nil,
+		},
+		"market order must not rest": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				OrderType:   OrderType_Market,
+			},
+			wantErr: errors.ErrInput,
+		},
+		"unknown time in force": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				TimeInForce: TimeInForce(7),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"post-only market order": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				OrderType:   OrderType_Market,
+				TimeInForce: TimeInForce_ImmediateOrCancel,
+				PostOnly:    PostOnly_Reject,
+			},
+			wantErr: errors.ErrInput,
+		},
+		"post-only immediate or cancel order": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				TimeInForce: TimeInForce_ImmediateOrCancel,
+				PostOnly:    PostOnly_Reprice,
+			},
+			wantErr: errors.ErrInput,
+		},
+		"unknown self-trade prevention": {
+			msg: &CreateOrderMsg{
+				Metadata:            &weave.Metadata{Schema: 1},
+				Trader:              trader,
+				OrderBookID:         weavetest.SequenceID(12345),
+				Offer:               coin.NewCoinp(100, 12345, "ETH"),
+				Price:               NewAmountp(11, 0),
+				SelfTradePrevention: SelfTradePrevention(9),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"success, expiring order": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				ExpiresAt:   weave.AsUnixTime(time.Now()),
+			},
+			wantErr: nil,
+		},
+		"immediate or cancel order cannot expire": {
+			msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+				TimeInForce: TimeInForce_ImmediateOrCancel,
+				ExpiresAt:   weave.AsUnixTime(time.Now()),
+			},
+			wantErr: errors.ErrInput,
+		},
 		"missing metadata": {
 			msg: &CreateOrderMsg{
 				Trader:      trader,
@@ -241,3 +625,369 @@ func TestValidateCreateOrderMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateMarketMsg(t *testing.T) {
+	owner := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "main-market",
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &CreateMarketMsg{
+				Owner: owner,
+				Name:  "main-market",
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"bad owner": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    []byte{1, 2, 3},
+				Name:     "main-market",
+			},
+			wantErr: errors.ErrInput,
+		},
+		"name too short": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "abc",
+			},
+			wantErr: errors.ErrInput,
+		},
+		"name with invalid characters": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "main market!",
+			},
+			wantErr: errors.ErrInput,
+		},
+		"with fees": {
+			msg: &CreateMarketMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Owner:        owner,
+				Name:         "main-market",
+				MakerFee:     NewAmountp(0, 1000000),
+				TakerFee:     NewAmountp(0, 2000000),
+				FeeCollector: weavetest.NewCondition().Address(),
+			},
+			wantErr: nil,
+		},
+		"fee of 100%": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "main-market",
+				TakerFee: NewAmountp(1, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"negative fee": {
+			msg: &CreateMarketMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    owner,
+				Name:     "main-market",
+				MakerFee: NewAmountp(0, -1000000),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"bad fee collector": {
+			msg: &CreateMarketMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Owner:        owner,
+				Name:         "main-market",
+				FeeCollector: []byte{1, 2, 3},
+			},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateMarketOwnerMsg(t *testing.T) {
+	owner := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				MarketID: weavetest.SequenceID(5),
+				NewOwner: owner,
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &UpdateMarketOwnerMsg{
+				MarketID: weavetest.SequenceID(5),
+				NewOwner: owner,
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"missing market id": {
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				NewOwner: owner,
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"missing owner": {
+			msg: &UpdateMarketOwnerMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				MarketID: weavetest.SequenceID(5),
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateConfigurationMsg(t *testing.T) {
+	owner := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata:    &weave.Metadata{Schema: 1},
+					FeeDiscount: NewAmountp(0, 500000000),
+					FeePrices:   []*FeePrice{{Ticker: "ETH", Price: NewAmountp(2, 0)}},
+				},
+			},
+			wantErr: nil,
+		},
+		"new owner": {
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata: &weave.Metadata{Schema: 1},
+					Owner:    owner,
+				},
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &UpdateConfigurationMsg{
+				Patch: &Configuration{
+					Metadata: &weave.Metadata{Schema: 1},
+					Owner:    owner,
+				},
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"missing patch": {
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"invalid discount": {
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata:    &weave.Metadata{Schema: 1},
+					FeeDiscount: NewAmountp(2, 0),
+				},
+			},
+			wantErr: errors.ErrInput,
+		},
+		"invalid price": {
+			msg: &UpdateConfigurationMsg{
+				Metadata: &weave.Metadata{Schema: 1},
+				Patch: &Configuration{
+					Metadata:  &weave.Metadata{Schema: 1},
+					FeePrices: []*FeePrice{{Ticker: "eth", Price: NewAmountp(2, 0)}},
+				},
+			},
+			wantErr: errors.ErrCurrency,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateStopOrderMsg(t *testing.T) {
+	trader := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+			},
+			wantErr: nil,
+		},
+		"success, market immediate or cancel": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				Direction:    TriggerDirection_Below,
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+				OrderType:    OrderType_Market,
+				TimeInForce:  TimeInForce_ImmediateOrCancel,
+			},
+			wantErr: nil,
+		},
+		"missing direction": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"missing trigger price": {
+			msg: &CreateStopOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      trader,
+				OrderBookID: weavetest.SequenceID(12345),
+				Direction:   TriggerDirection_Above,
+				Offer:       coin.NewCoinp(100, 12345, "ETH"),
+				Price:       NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrEmpty,
+		},
+		"zero trigger price": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(0, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"fill or kill": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+				TimeInForce:  TimeInForce_FillOrKill,
+			},
+			wantErr: errors.ErrInput,
+		},
+		"negative offer": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				OrderBookID:  weavetest.SequenceID(12345),
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(-100, 0, "ETH"),
+				Price:        NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrInput,
+		},
+		"missing orderbook id": {
+			msg: &CreateStopOrderMsg{
+				Metadata:     &weave.Metadata{Schema: 1},
+				Trader:       trader,
+				Direction:    TriggerDirection_Above,
+				TriggerPrice: NewAmountp(12, 0),
+				Offer:        coin.NewCoinp(100, 12345, "ETH"),
+				Price:        NewAmountp(11, 0),
+			},
+			wantErr: errors.ErrEmpty,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCancelStopOrderMsg(t *testing.T) {
+	cases := map[string]struct {
+		msg     weave.Msg
+		wantErr *errors.Error
+	}{
+		"success": {
+			msg: &CancelStopOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				StopOrderID: weavetest.SequenceID(5),
+			},
+			wantErr: nil,
+		},
+		"missing metadata": {
+			msg: &CancelStopOrderMsg{
+				StopOrderID: weavetest.SequenceID(5),
+			},
+			wantErr: errors.ErrMetadata,
+		},
+		"bad stop order id": {
+			msg: &CancelStopOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				StopOrderID: []byte{0, 0, 0, 0, 0, 1},
+			},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			if err := tc.msg.Validate(); !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,518 @@
+package orderbook
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/errors"
+)
+
+const (
+	// defaultDepthLevels is used when a DepthQuery does not request a number of levels
+	defaultDepthLevels = 20
+	// maxDepthLevels bounds the work done by a single depth query
+	maxDepthLevels = 200
+
+	// defaultCandleLimit is used when a CandleQuery does not request a limit
+	defaultCandleLimit = 100
+	// maxCandleLimit bounds the work done by a single candle query
+	maxCandleLimit = 1000
+
+	// defaultTraderLimit is used when a trader query does not request a limit
+	defaultTraderLimit = 50
+	// maxTraderLimit bounds the work done by a single trader query
+	maxTraderLimit = 500
+)
+
+// DepthQueryHandler serves the Level 2 market data of an orderbook. The open
+// orders of each side are aggregated into price levels by walking the "open"
+// order index, which yields the best priced orders first.
+//
+// Only the key mod is supported, with a serialized DepthQuery as data. The
+// result is a single model with the orderbook ID as key and the serialized
+// OrderBookDepth as value, or none if the orderbook does not exist.
+type DepthQueryHandler struct {
+	orderbooks *OrderBookBucket
+	orders     *OrderBucket
+}
+
+var _ weave.QueryHandler = (*DepthQueryHandler)(nil)
+
+// NewDepthQueryHandler creates a handler for depth queries
+func NewDepthQueryHandler() *DepthQueryHandler {
+	return &DepthQueryHandler{
+		orderbooks: NewOrderBookBucket(),
+		orders:     NewOrderBucket(),
+	}
+}
+
+func (h *DepthQueryHandler) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+	var q DepthQuery
+	if err := q.Unmarshal(data); err != nil {
+		return nil, errors.Wrap(errors.ErrInput, "cannot parse depth query")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	levels := int(q.Levels)
+	if levels == 0 {
+		levels = defaultDepthLevels
+	}
+
+	var orderbook OrderBook
+	switch err := h.orderbooks.One(db, q.OrderBookID, &orderbook); {
+	case errors.ErrNotFound.Is(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "cannot load orderbook")
+	}
+
+	depth := OrderBookDepth{OrderBookID: q.OrderBookID}
+	var err error
+	if depth.Asks, err = h.priceLevels(db, q.OrderBookID, Side_Ask, levels); err != nil {
+		return nil, errors.Wrap(err, "asks")
+	}
+	if depth.Bids, err = h.priceLevels(db, q.OrderBookID, Side_Bid, levels); err != nil {
+		return nil, errors.Wrap(err, "bids")
+	}
+	raw, err := depth.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal depth")
+	}
+	return []weave.Model{{Key: q.OrderBookID, Value: raw}}, nil
+}
+
+// priceLevels aggregates the open orders of one side of the orderbook into at
+// most max price levels, best price first
+func (h *DepthQueryHandler) priceLevels(db weave.ReadOnlyKVStore, orderBookID []byte, side Side, max int) ([]*PriceLevel, error) {
+	prefix, err := BuildOpenOrderPrefix(orderBookID, side)
+	if err != nil {
+		return nil, errors.Wrap(err, "open orders prefix")
+	}
+	iter, err := h.orders.IndexScan(db, "open", prefix, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan open orders")
+	}
+	defer iter.Release()
+
+	var levels []*PriceLevel
+	for {
+		if !iter.Valid() {
+			if err := iter.LoadNext(&Order{}); !morm.ErrIteratorDone.Is(err) {
+				return nil, errors.Wrap(err, "load order")
+			}
+			return levels, nil
+		}
+		price, err := ParseOpenOrderPrice(iter.IndexKey())
+		if err != nil {
+			return nil, errors.Wrap(err, "order price")
+		}
+		if len(levels) == 0 || !levels[len(levels)-1].Price.Equals(price) {
+			if len(levels) == max {
+				return levels, nil
+			}
+			levels = append(levels, &PriceLevel{Price: price})
+		}
+
+		var order Order
+		if err := iter.LoadNext(&order); err != nil {
+			return nil, errors.Wrap(err, "load order")
+		}
+		level := levels[len(levels)-1]
+		if level.TotalOffer == nil {
+			level.TotalOffer = order.RemainingOffer.Clone()
+		} else {
+			total, err := level.TotalOffer.Add(*order.RemainingOffer)
+			if err != nil {
+				return nil, errors.Wrap(err, "total offer")
+			}
+			level.TotalOffer = &total
+		}
+		level.OrderCount++
+	}
+}
+
+// Validate ensures the query can be served
+func (q *DepthQuery) Validate() error {
+	var errs error
+	errs = errors.AppendField(errs, "OrderBookID", isGenID(q.OrderBookID, false))
+	if q.Levels < 0 || q.Levels > maxDepthLevels {
+		errs = errors.AppendField(errs, "Levels",
+			errors.Wrapf(errors.ErrInput, "must be between 0 and %d", maxDepthLevels))
+	}
+	return errs
+}
+
+// TickerQueryHandler serves the ticker of an orderbook. The 24h statistics
+// are only rolled forward when the orderbook trades, so trades that fell out
+// of the window since are removed from a copy before it is returned, up to
+// the time given with the query.
+//
+// Only the key mod is supported, with a serialized TickerQuery as data. The
+// result is a single model with the orderbook ID as key and the serialized
+// Ticker as value, or none if the orderbook never had a ticker.
+type TickerQueryHandler struct {
+	tickers    *TickerBucket
+	orderbooks *OrderBookBucket
+	trades     *TradeBucket
+}
+
+var _ weave.QueryHandler = (*TickerQueryHandler)(nil)
+
+// NewTickerQueryHandler creates a handler for ticker queries
+func NewTickerQueryHandler() *TickerQueryHandler {
+	return &TickerQueryHandler{
+		tickers:    NewTickerBucket(),
+		orderbooks: NewOrderBookBucket(),
+		trades:     NewTradeBucket(),
+	}
+}
+
+func (h *TickerQueryHandler) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+	var q TickerQuery
+	if err := q.Unmarshal(data); err != nil {
+		return nil, errors.Wrap(errors.ErrInput, "cannot parse ticker query")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var ticker Ticker
+	switch err := h.tickers.One(db, q.OrderBookID, &ticker); {
+	case errors.ErrNotFound.Is(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "cannot load ticker")
+	}
+
+	if q.Now != 0 && ticker.DayTradeCount != 0 {
+		var orderbook OrderBook
+		if err := h.orderbooks.One(db, q.OrderBookID, &orderbook); err != nil {
+			return nil, errors.Wrap(err, "cannot load orderbook")
+		}
+		stale, err := expireTrades(db, h.trades, &orderbook, &ticker, q.Now-tickerWindow)
+		if err != nil {
+			return nil, err
+		}
+		if ticker.DayTradeCount == 0 {
+			ticker.DayHigh, ticker.DayLow = nil, nil
+		} else if stale {
+			if err := dayRange(db, h.trades, &orderbook, &ticker); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ticker.ID = nil
+	raw, err := ticker.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal ticker")
+	}
+	return []weave.Model{{Key: q.OrderBookID, Value: raw}}, nil
+}
+
+// Validate ensures the query can be served
+func (q *TickerQuery) Validate() error {
+	var errs error
+	errs = errors.AppendField(errs, "OrderBookID", isGenID(q.OrderBookID, false))
+	if q.Now < 0 {
+		errs = errors.AppendField(errs, "Now", errors.Wrap(errors.ErrInput, "must not be negative"))
+	}
+	return errs
+}
+
+// CandleQueryHandler serves the candles of one interval of an orderbook
+// within a time range, oldest first.
+//
+// Only the key mod is supported, with a serialized CandleQuery as data. The
+// result is a model for each candle, with the candle ID as key and the
+// serialized Candle as value. Intervals without trades have no candle.
+type CandleQueryHandler struct {
+	candles *CandleBucket
+}
+
+var _ weave.QueryHandler = (*CandleQueryHandler)(nil)
+
+// NewCandleQueryHandler creates a handler for candle queries
+func NewCandleQueryHandler() *CandleQueryHandler {
+	return &CandleQueryHandler{
+		candles: NewCandleBucket(),
+	}
+}
+
+func (h *CandleQueryHandler) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+	var q CandleQuery
+	if err := q.Unmarshal(data); err != nil {
+		return nil, errors.Wrap(errors.ErrInput, "cannot parse candle query")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := morm.ScanOptions{Limit: int(q.Limit)}
+	if opts.Limit == 0 {
+		opts.Limit = defaultCandleLimit
+	}
+	var err error
+	if opts.Start, err = BuildCandleID(q.OrderBookID, q.Interval, q.Since); err != nil {
+		return nil, errors.Wrap(err, "since")
+	}
+	until := q.Until
+	if until == 0 {
+		until = weave.UnixTime(math.MaxInt64)
+	}
+	if opts.End, err = BuildCandleID(q.OrderBookID, q.Interval, until); err != nil {
+		return nil, errors.Wrap(err, "until")
+	}
+
+	iter, err := h.candles.RangeScan(db, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan candles")
+	}
+	defer iter.Release()
+
+	var res []weave.Model
+	for {
+		var candle Candle
+		err := iter.LoadNext(&candle)
+		if morm.ErrIteratorDone.Is(err) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "load candle")
+		}
+		id := candle.ID
+		candle.ID = nil
+		raw, err := candle.Marshal()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal candle")
+		}
+		res = append(res, weave.Model{Key: id, Value: raw})
+	}
+}
+
+// Validate ensures the query can be served
+func (q *CandleQuery) Validate() error {
+	var errs error
+	errs = errors.AppendField(errs, "OrderBookID", isGenID(q.OrderBookID, false))
+	if q.Interval.Seconds() == 0 {
+		errs = errors.AppendField(errs, "Interval", errors.ErrInput)
+	}
+	if q.Since < 0 {
+		errs = errors.AppendField(errs, "Since", errors.Wrap(errors.ErrInput, "must not be negative"))
+	}
+	if q.Until < 0 || (q.Until != 0 && q.Until < q.Since) {
+		errs = errors.AppendField(errs, "Until", errors.Wrap(errors.ErrInput, "must not be before since"))
+	}
+	if q.Limit < 0 || q.Limit > maxCandleLimit {
+		errs = errors.AppendField(errs, "Limit",
+			errors.Wrapf(errors.ErrInput, "must be between 0 and %d", maxCandleLimit))
+	}
+	return errs
+}
+
+// TraderOrdersQueryHandler serves the orders of a trader in one state, newest
+// first, one page at a time. Orders are found through the "trader" index.
+//
+// Only the key mod is supported, with a serialized TraderOrdersQuery as data.
+// The result is a model for each order, with the order ID as key and the
+// serialized Order as value. The next page starts after the last order
+// returned.
+type TraderOrdersQueryHandler struct {
+	orders *OrderBucket
+}
+
+var _ weave.QueryHandler = (*TraderOrdersQueryHandler)(nil)
+
+// NewTraderOrdersQueryHandler creates a handler for trader order queries
+func NewTraderOrdersQueryHandler() *TraderOrdersQueryHandler {
+	return &TraderOrdersQueryHandler{
+		orders: NewOrderBucket(),
+	}
+}
+
+func (h *TraderOrdersQueryHandler) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+	var q TraderOrdersQuery
+	if err := q.Unmarshal(data); err != nil {
+		return nil, errors.Wrap(errors.ErrInput, "cannot parse trader orders query")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	start, err := BuildOrderTraderPrefix(q.Trader, q.State)
+	if err != nil {
+		return nil, errors.Wrap(err, "trader prefix")
+	}
+	last := &Order{Trader: q.Trader, OrderState: q.State, CreatedAt: weave.UnixTime(math.MaxInt64)}
+	if len(q.After) != 0 {
+		var after Order
+		if err := h.orders.One(db, q.After, &after); err != nil {
+			return nil, errors.Wrap(err, "after")
+		}
+		if !after.Trader.Equals(q.Trader) {
+			return nil, errors.Wrap(errors.ErrInput, "after must be an order of the trader")
+		}
+		// the order may have changed its state since, the page continues
+		// from where it was created
+		last.CreatedAt = after.CreatedAt
+	}
+	end, err := BuildOrderTraderIndex(last)
+	if err != nil {
+		return nil, errors.Wrap(err, "after")
+	}
+
+	return scanPage(db, h.orders, "trader", start, end, q.After, traderLimit(q.Limit), func() morm.Model { return &Order{} })
+}
+
+// Validate ensures the query can be served
+func (q *TraderOrdersQuery) Validate() error {
+	var errs error
+	errs = errors.AppendField(errs, "Trader", q.Trader.Validate())
+	if _, ok := OrderState_name[int32(q.State)]; !ok || q.State == OrderState_Invalid {
+		errs = errors.AppendField(errs, "State", errors.ErrInput)
+	}
+	errs = errors.AppendField(errs, "After", isGenID(q.After, true))
+	errs = errors.AppendField(errs, "Limit", validateTraderLimit(q.Limit))
+	return errs
+}
+
+// TraderTradesQueryHandler serves the trades of a trader, whether maker or
+// taker, newest first, one page at a time. Trades are found through the
+// "trader" index.
+//
+// Only the key mod is supported, with a serialized TraderTradesQuery as data.
+// The result is a model for each trade, with the trade ID as key and the
+// serialized Trade as value. The next page starts after the last trade
+// returned.
+type TraderTradesQueryHandler struct {
+	trades *TradeBucket
+}
+
+var _ weave.QueryHandler = (*TraderTradesQueryHandler)(nil)
+
+// NewTraderTradesQueryHandler creates a handler for trader trade queries
+func NewTraderTradesQueryHandler() *TraderTradesQueryHandler {
+	return &TraderTradesQueryHandler{
+		trades: NewTradeBucket(),
+	}
+}
+
+func (h *TraderTradesQueryHandler) Query(db weave.ReadOnlyKVStore, mod string, data []byte) ([]weave.Model, error) {
+	if mod != weave.KeyQueryMod {
+		return nil, errors.Wrapf(errors.ErrInput, "unknown mod: %s", mod)
+	}
+	var q TraderTradesQuery
+	if err := q.Unmarshal(data); err != nil {
+		return nil, errors.Wrap(errors.ErrInput, "cannot parse trader trades query")
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	start, err := BuildTradeTraderKey(q.Trader, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "trader prefix")
+	}
+	until := weave.UnixTime(math.MaxInt64)
+	if len(q.After) != 0 {
+		var after Trade
+		if err := h.trades.One(db, q.After, &after); err != nil {
+			return nil, errors.Wrap(err, "after")
+		}
+		if !after.Maker.Equals(q.Trader) && !after.Taker.Equals(q.Trader) {
+			return nil, errors.Wrap(errors.ErrInput, "after must be a trade of the trader")
+		}
+		until = after.ExecutedAt
+	}
+	end, err := BuildTradeTraderKey(q.Trader, until)
+	if err != nil {
+		return nil, errors.Wrap(err, "after")
+	}
+
+	return scanPage(db, h.trades, "trader", start, end, q.After, traderLimit(q.Limit), func() morm.Model { return &Trade{} })
+}
+
+// Validate ensures the query can be served
+func (q *TraderTradesQuery) Validate() error {
+	var errs error
+	errs = errors.AppendField(errs, "Trader", q.Trader.Validate())
+	errs = errors.AppendField(errs, "After", isGenID(q.After, true))
+	errs = errors.AppendField(errs, "Limit", validateTraderLimit(q.Limit))
+	return errs
+}
+
+func validateTraderLimit(limit int32) error {
+	if limit < 0 || limit > maxTraderLimit {
+		return errors.Wrapf(errors.ErrInput, "must be between 0 and %d", maxTraderLimit)
+	}
+	return nil
+}
+
+func traderLimit(limit int32) int {
+	if limit == 0 {
+		return defaultTraderLimit
+	}
+	return int(limit)
+}
+
+// scanPage returns at most limit models of the named index with a key in
+// [start, end], highest key first. Models sharing a key are returned in the
+// order of their IDs, so when resuming after the model with the given ID at
+// the end key, all models of that key up to this ID were returned before.
+func scanPage(db weave.ReadOnlyKVStore, bucket morm.ModelBucket, index string, start, end, after []byte, limit int, newModel func() morm.Model) ([]weave.Model, error) {
+	iter, err := bucket.IndexRangeScan(db, index, morm.ScanOptions{
+		Start:        start,
+		End:          end,
+		EndInclusive: true,
+		Reverse:      true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "scan")
+	}
+	defer iter.Release()
+
+	if len(after) != 0 {
+		for iter.Valid() && bytes.Equal(iter.IndexKey(), end) && bytes.Compare(iter.Key(), after) <= 0 {
+			if err := iter.LoadNext(newModel()); err != nil {
+				return nil, errors.Wrap(err, "skip")
+			}
+		}
+	}
+
+	var res []weave.Model
+	for len(res) < limit {
+		model := newModel()
+		err := iter.LoadNext(model)
+		if morm.ErrIteratorDone.Is(err) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "load")
+		}
+		id := model.GetID()
+		if err := model.SetID(nil); err != nil {
+			return nil, err
+		}
+		raw, err := model.Marshal()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal")
+		}
+		res = append(res, weave.Model{Key: id, Value: raw})
+	}
+	return res, nil
+}
@@ -0,0 +1,600 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+func TestDepthQuery(t *testing.T) {
+	db := store.MemStore()
+	trader := weavetest.NewCondition().Address()
+
+	orderbooks := NewOrderBookBucket()
+	for i := 0; i < 2; i++ {
+		ob := &OrderBook{
+			Metadata:  &weave.Metadata{Schema: 1},
+			MarketID:  weavetest.SequenceID(1),
+			AskTicker: "BTC",
+			BidTicker: "ETH",
+		}
+		if i == 1 {
+			ob.BidTicker = "IOV"
+		}
+		assert.Nil(t, orderbooks.Put(db, ob))
+	}
+	book := weavetest.SequenceID(1)
+
+	orders := NewOrderBucket()
+	for _, o := range []struct {
+		book  []byte
+		state OrderState
+		offer *coin.Coin
+		price *Amount
+	}{
+		{book, OrderState_Open, coin.NewCoinp(1, 0, "BTC"), NewAmountp(3, 0)},
+		{book, OrderState_Open, coin.NewCoinp(2, 0, "BTC"), NewAmountp(2, 0)},
+		{book, OrderState_Open, coin.NewCoinp(0, 500000000, "BTC"), NewAmountp(2, 0)},
+		{book, OrderState_Open, coin.NewCoinp(4, 0, "BTC"), NewAmountp(5, 0)},
+		{book, OrderState_Done, coin.NewCoinp(7, 0, "BTC"), NewAmountp(1, 0)},
+		{book, OrderState_Open, coin.NewCoinp(10, 0, "ETH"), NewAmountp(0, 400000000)},
+		{weavetest.SequenceID(2), OrderState_Open, coin.NewCoinp(9, 0, "BTC"), NewAmountp(1, 0)},
+	} {
+		side := Side_Ask
+		if o.offer.Ticker == "ETH" {
+			side = Side_Bid
+		}
+		order := &Order{
+			Metadata:       &weave.Metadata{Schema: 1},
+			Trader:         trader,
+			OrderBookID:    o.book,
+			Side:           side,
+			OrderState:     o.state,
+			OriginalOffer:  o.offer,
+			RemainingOffer: o.offer,
+			Price:          o.price,
+			CreatedAt:      weave.UnixTime(1000),
+			UpdatedAt:      weave.UnixTime(1000),
+		}
+		assert.Nil(t, orders.Put(db, order))
+	}
+
+	allAsks := []*PriceLevel{
+		{Price: NewAmountp(2, 0), TotalOffer: coin.NewCoinp(2, 500000000, "BTC"), OrderCount: 2},
+		{Price: NewAmountp(3, 0), TotalOffer: coin.NewCoinp(1, 0, "BTC"), OrderCount: 1},
+		{Price: NewAmountp(5, 0), TotalOffer: coin.NewCoinp(4, 0, "BTC"), OrderCount: 1},
+	}
+	allBids := []*PriceLevel{
+		{Price: NewAmountp(0, 400000000), TotalOffer: coin.NewCoinp(10, 0, "ETH"), OrderCount: 1},
+	}
+
+	cases := map[string]struct {
+		mod       string
+		query     *DepthQuery
+		wantDepth *OrderBookDepth
+		wantErr   *errors.Error
+	}{
+		"all levels": {
+			query:     &DepthQuery{OrderBookID: book},
+			wantDepth: &OrderBookDepth{OrderBookID: book, Asks: allAsks, Bids: allBids},
+		},
+		"best levels only": {
+			query:     &DepthQuery{OrderBookID: book, Levels: 2},
+			wantDepth: &OrderBookDepth{OrderBookID: book, Asks: allAsks[:2], Bids: allBids},
+		},
+		"empty side": {
+			query: &DepthQuery{OrderBookID: weavetest.SequenceID(2)},
+			wantDepth: &OrderBookDepth{
+				OrderBookID: weavetest.SequenceID(2),
+				Asks: []*PriceLevel{
+					{Price: NewAmountp(1, 0), TotalOffer: coin.NewCoinp(9, 0, "BTC"), OrderCount: 1},
+				},
+			},
+		},
+		"unknown orderbook": {
+			query: &DepthQuery{OrderBookID: weavetest.SequenceID(3)},
+		},
+		"missing orderbook id": {
+			query:   &DepthQuery{Levels: 2},
+			wantErr: errors.ErrEmpty,
+		},
+		"malformed orderbook id": {
+			query:   &DepthQuery{OrderBookID: []byte{0, 1}},
+			wantErr: errors.ErrInput,
+		},
+		"too many levels": {
+			query:   &DepthQuery{OrderBookID: book, Levels: maxDepthLevels + 1},
+			wantErr: errors.ErrInput,
+		},
+		"prefix mod is not supported": {
+			mod:     weave.PrefixQueryMod,
+			query:   &DepthQuery{OrderBookID: book},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/orderbooks/depth")
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			mod := tc.mod
+			if mod == "" {
+				mod = weave.KeyQueryMod
+			}
+			data, err := tc.query.Marshal()
+			assert.Nil(t, err)
+
+			models, err := h.Query(db, mod, data)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.wantDepth == nil {
+				assert.Equal(t, 0, len(models))
+				return
+			}
+			assert.Equal(t, 1, len(models))
+			assert.Equal(t, tc.query.OrderBookID, models[0].Key)
+			var depth OrderBookDepth
+			assert.Nil(t, depth.Unmarshal(models[0].Value))
+			assert.Equal(t, tc.wantDepth, &depth)
+		})
+	}
+}
+
+func TestCandleQuery(t *testing.T) {
+	db := store.MemStore()
+	book := weavetest.SequenceID(1)
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		ID:        book,
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+
+	candles := NewCandleBucket()
+	for _, at := range []weave.UnixTime{60, 130, 150, 300, 3700} {
+		trade := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: book,
+			OrderID:     weavetest.SequenceID(1),
+			MakerPaid:   coin.NewCoinp(1, 0, "BTC"),
+			TakerPaid:   coin.NewCoinp(2, 0, "ETH"),
+			ExecutedAt:  at,
+		}
+		assert.Nil(t, candles.Record(db, orderbook, trade))
+	}
+
+	cases := map[string]struct {
+		mod        string
+		query      *CandleQuery
+		wantStarts []weave.UnixTime
+		wantErr    *errors.Error
+	}{
+		"all minutes": {
+			query:      &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute},
+			wantStarts: []weave.UnixTime{60, 120, 300, 3660},
+		},
+		"all hours": {
+			query:      &CandleQuery{OrderBookID: book, Interval: CandleInterval_Hour},
+			wantStarts: []weave.UnixTime{0, 3600},
+		},
+		"time range excludes until": {
+			query:      &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute, Since: 120, Until: 300},
+			wantStarts: []weave.UnixTime{120},
+		},
+		"since within a candle": {
+			query:      &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute, Since: 100},
+			wantStarts: []weave.UnixTime{120, 300, 3660},
+		},
+		"limit": {
+			query:      &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute, Limit: 2},
+			wantStarts: []weave.UnixTime{60, 120},
+		},
+		"unknown orderbook": {
+			query: &CandleQuery{OrderBookID: weavetest.SequenceID(2), Interval: CandleInterval_Minute},
+		},
+		"missing interval": {
+			query:   &CandleQuery{OrderBookID: book},
+			wantErr: errors.ErrInput,
+		},
+		"until before since": {
+			query:   &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute, Since: 300, Until: 120},
+			wantErr: errors.ErrInput,
+		},
+		"limit too high": {
+			query:   &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute, Limit: maxCandleLimit + 1},
+			wantErr: errors.ErrInput,
+		},
+		"prefix mod is not supported": {
+			mod:     weave.PrefixQueryMod,
+			query:   &CandleQuery{OrderBookID: book, Interval: CandleInterval_Minute},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/candles/range")
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			mod := tc.mod
+			if mod == "" {
+				mod = weave.KeyQueryMod
+			}
+			data, err := tc.query.Marshal()
+			assert.Nil(t, err)
+
+			models, err := h.Query(db, mod, data)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			assert.Equal(t, len(tc.wantStarts), len(models))
+			for i, m := range models {
+				var candle Candle
+				assert.Nil(t, candle.Unmarshal(m.Value))
+				assert.Equal(t, tc.query.Interval, candle.Interval)
+				assert.Equal(t, tc.wantStarts[i], candle.Start)
+
+				wantID, err := BuildCandleID(book, tc.query.Interval, tc.wantStarts[i])
+				assert.Nil(t, err)
+				assert.Equal(t, wantID, m.Key)
+			}
+		})
+	}
+}
+
+func TestTraderOrdersQuery(t *testing.T) {
+	db := store.MemStore()
+	alice := weavetest.NewCondition().Address()
+	bob := weavetest.NewCondition().Address()
+
+	orders := NewOrderBucket()
+	for _, o := range []struct {
+		trader    weave.Address
+		state     OrderState
+		createdAt weave.UnixTime
+	}{
+		{alice, OrderState_Open, 1000},
+		{alice, OrderState_Open, 1000},
+		{alice, OrderState_Done, 1001},
+		{alice, OrderState_Open, 1002},
+		{bob, OrderState_Open, 1000},
+	} {
+		order := &Order{
+			Metadata:       &weave.Metadata{Schema: 1},
+			Trader:         o.trader,
+			OrderBookID:    weavetest.SequenceID(1),
+			Side:           Side_Ask,
+			OrderState:     o.state,
+			OriginalOffer:  coin.NewCoinp(10, 0, "BTC"),
+			RemainingOffer: coin.NewCoinp(10, 0, "BTC"),
+			Price:          NewAmountp(2, 0),
+			CreatedAt:      o.createdAt,
+			UpdatedAt:      o.createdAt,
+		}
+		assert.Nil(t, orders.Put(db, order))
+	}
+
+	cases := map[string]struct {
+		mod     string
+		query   *TraderOrdersQuery
+		wantIDs [][]byte
+		wantErr *errors.Error
+	}{
+		"newest first": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open},
+			wantIDs: [][]byte{weavetest.SequenceID(4), weavetest.SequenceID(1), weavetest.SequenceID(2)},
+		},
+		"first page": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open, Limit: 2},
+			wantIDs: [][]byte{weavetest.SequenceID(4), weavetest.SequenceID(1)},
+		},
+		"next page continues within the same creation time": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open, After: weavetest.SequenceID(1), Limit: 2},
+			wantIDs: [][]byte{weavetest.SequenceID(2)},
+		},
+		"after the last order": {
+			query: &TraderOrdersQuery{Trader: alice, State: OrderState_Open, After: weavetest.SequenceID(2)},
+		},
+		"other state": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Done},
+			wantIDs: [][]byte{weavetest.SequenceID(3)},
+		},
+		"after an order of another trader": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open, After: weavetest.SequenceID(5)},
+			wantErr: errors.ErrInput,
+		},
+		"after an unknown order": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open, After: weavetest.SequenceID(9)},
+			wantErr: errors.ErrNotFound,
+		},
+		"missing state": {
+			query:   &TraderOrdersQuery{Trader: alice},
+			wantErr: errors.ErrInput,
+		},
+		"missing trader": {
+			query:   &TraderOrdersQuery{State: OrderState_Open},
+			wantErr: errors.ErrEmpty,
+		},
+		"limit too large": {
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open, Limit: maxTraderLimit + 1},
+			wantErr: errors.ErrInput,
+		},
+		"prefix mod is not supported": {
+			mod:     weave.PrefixQueryMod,
+			query:   &TraderOrdersQuery{Trader: alice, State: OrderState_Open},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/traders/orders")
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			mod := tc.mod
+			if mod == "" {
+				mod = weave.KeyQueryMod
+			}
+			data, err := tc.query.Marshal()
+			assert.Nil(t, err)
+
+			models, err := h.Query(db, mod, data)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			var ids [][]byte
+			for _, m := range models {
+				var order Order
+				assert.Nil(t, order.Unmarshal(m.Value))
+				assert.Equal(t, tc.query.Trader, order.Trader)
+				assert.Equal(t, tc.query.State, order.OrderState)
+				ids = append(ids, m.Key)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
+
+func TestTraderTradesQuery(t *testing.T) {
+	db := store.MemStore()
+	alice := weavetest.NewCondition().Address()
+	bob := weavetest.NewCondition().Address()
+	carol := weavetest.NewCondition().Address()
+
+	trades := NewTradeBucket()
+	for _, tr := range []struct {
+		maker, taker weave.Address
+		executedAt   weave.UnixTime
+	}{
+		{alice, bob, 2000},
+		{bob, alice, 2000},
+		// stored directly, the matching engine never creates self-trades
+		{bob, bob, 2001},
+		{carol, alice, 2002},
+	} {
+		trade := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: weavetest.SequenceID(1),
+			OrderID:     weavetest.SequenceID(1),
+			Maker:       tr.maker,
+			Taker:       tr.taker,
+			MakerPaid:   coin.NewCoinp(1, 0, "BTC"),
+			TakerPaid:   coin.NewCoinp(2, 0, "ETH"),
+			ExecutedAt:  tr.executedAt,
+		}
+		assert.Nil(t, trades.Put(db, trade))
+	}
+
+	cases := map[string]struct {
+		query   *TraderTradesQuery
+		wantIDs [][]byte
+		wantErr *errors.Error
+	}{
+		"maker and taker, newest first": {
+			query:   &TraderTradesQuery{Trader: alice},
+			wantIDs: [][]byte{weavetest.SequenceID(4), weavetest.SequenceID(1), weavetest.SequenceID(2)},
+		},
+		"self-trade is listed once": {
+			query:   &TraderTradesQuery{Trader: bob},
+			wantIDs: [][]byte{weavetest.SequenceID(3), weavetest.SequenceID(1), weavetest.SequenceID(2)},
+		},
+		"first page": {
+			query:   &TraderTradesQuery{Trader: bob, Limit: 2},
+			wantIDs: [][]byte{weavetest.SequenceID(3), weavetest.SequenceID(1)},
+		},
+		"next page continues within the same execution time": {
+			query:   &TraderTradesQuery{Trader: bob, After: weavetest.SequenceID(1), Limit: 2},
+			wantIDs: [][]byte{weavetest.SequenceID(2)},
+		},
+		"after the last trade": {
+			query: &TraderTradesQuery{Trader: bob, After: weavetest.SequenceID(2)},
+		},
+		"after a trade of another trader": {
+			query:   &TraderTradesQuery{Trader: bob, After: weavetest.SequenceID(4)},
+			wantErr: errors.ErrInput,
+		},
+		"malformed after": {
+			query:   &TraderTradesQuery{Trader: bob, After: []byte{1}},
+			wantErr: errors.ErrInput,
+		},
+		"limit too large": {
+			query:   &TraderTradesQuery{Trader: bob, Limit: maxTraderLimit + 1},
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/traders/trades")
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			data, err := tc.query.Marshal()
+			assert.Nil(t, err)
+
+			models, err := h.Query(db, weave.KeyQueryMod, data)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			var ids [][]byte
+			for _, m := range models {
+				var trade Trade
+				assert.Nil(t, trade.Unmarshal(m.Value))
+				assert.Equal(t, true, trade.Maker.Equals(tc.query.Trader) || trade.Taker.Equals(tc.query.Trader))
+				ids = append(ids, m.Key)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
+
+func TestTickerQuery(t *testing.T) {
+	maker := weavetest.NewCondition().Address()
+	taker := weavetest.NewCondition().Address()
+	book := weavetest.SequenceID(1)
+
+	// trades are priced in ETH per BTC
+	type trade struct {
+		eth int64
+		at  weave.UnixTime
+	}
+
+	cases := map[string]struct {
+		trades  []trade
+		now     weave.UnixTime
+		id      []byte
+		want    *Ticker
+		wantErr *errors.Error
+	}{
+		"no time given": {
+			trades: []trade{{5, 1000}},
+			id:     book,
+			want: &Ticker{
+				LastPrice:      NewAmountp(5, 0),
+				LastTradeAt:    1000,
+				WindowStart:    1000 - tickerWindow,
+				DayHigh:        NewAmountp(5, 0),
+				DayLow:         NewAmountp(5, 0),
+				DayBaseVolume:  coin.NewCoinp(1, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(5, 0, "ETH"),
+				DayTradeCount:  1,
+			},
+		},
+		"within the window": {
+			trades: []trade{{5, 1000}},
+			now:    1000 + 3600,
+			id:     book,
+			want: &Ticker{
+				LastPrice:      NewAmountp(5, 0),
+				LastTradeAt:    1000,
+				WindowStart:    1000 - tickerWindow,
+				DayHigh:        NewAmountp(5, 0),
+				DayLow:         NewAmountp(5, 0),
+				DayBaseVolume:  coin.NewCoinp(1, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(5, 0, "ETH"),
+				DayTradeCount:  1,
+			},
+		},
+		"no trades for more than a day": {
+			trades: []trade{{5, 1000}},
+			now:    1000 + tickerWindow + 1,
+			id:     book,
+			want: &Ticker{
+				LastPrice:      NewAmountp(5, 0),
+				LastTradeAt:    1000,
+				WindowStart:    1001,
+				DayBaseVolume:  coin.NewCoinp(0, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(0, 0, "ETH"),
+				DayTradeCount:  0,
+			},
+		},
+		"expired high is found again": {
+			trades: []trade{{5, 1000}, {1, 2000}},
+			now:    1000 + tickerWindow + 1,
+			id:     book,
+			want: &Ticker{
+				LastPrice:      NewAmountp(1, 0),
+				LastTradeAt:    2000,
+				WindowStart:    1001,
+				DayHigh:        NewAmountp(1, 0),
+				DayLow:         NewAmountp(1, 0),
+				DayBaseVolume:  coin.NewCoinp(1, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(1, 0, "ETH"),
+				DayTradeCount:  1,
+			},
+		},
+		"no ticker": {
+			id: book,
+		},
+		"malformed orderbook id": {
+			id:      []byte{1},
+			wantErr: errors.ErrInput,
+		},
+		"negative time": {
+			trades:  []trade{{5, 1000}},
+			now:     -1,
+			id:      book,
+			wantErr: errors.ErrInput,
+		},
+	}
+
+	qr := weave.NewQueryRouter()
+	RegisterQuery(qr)
+	h := qr.Handler("/orderbooks/ticker")
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			db := store.MemStore()
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  weavetest.SequenceID(1),
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(db, orderbook))
+
+			trades := NewTradeBucket()
+			for _, tr := range tc.trades {
+				trade := &Trade{
+					Metadata:    &weave.Metadata{Schema: 1},
+					OrderBookID: book,
+					OrderID:     weavetest.SequenceID(1),
+					Taker:       taker,
+					Maker:       maker,
+					MakerPaid:   coin.NewCoinp(1, 0, "BTC"),
+					TakerPaid:   coin.NewCoinp(tr.eth, 0, "ETH"),
+					ExecutedAt:  tr.at,
+				}
+				assert.Nil(t, trades.Put(db, trade))
+				assert.Nil(t, NewTickerBucket().Record(db, trades, orderbook, trade))
+			}
+			query, err := (&TickerQuery{OrderBookID: tc.id, Now: tc.now}).Marshal()
+			assert.Nil(t, err)
+			models, err := h.Query(db, weave.KeyQueryMod, query)
+			if !tc.wantErr.Is(err) {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if tc.want == nil {
+				assert.Equal(t, 0, len(models))
+				return
+			}
+			assert.Equal(t, 1, len(models))
+			assert.Equal(t, book, models[0].Key)
+			var ticker Ticker
+			assert.Nil(t, ticker.Unmarshal(models[0].Value))
+			tc.want.Metadata = &weave.Metadata{Schema: 1}
+			assert.Equal(t, tc.want, &ticker)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package orderbook
+
+import (
+	"time"
+
+	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+// maxStopActivations bounds the stop orders of one orderbook activated by a
+// single ActivateStopsMsg, so a cascade of triggers cannot take over a block.
+// The rest are activated by the task scheduled for the next block
+const maxStopActivations = 20
+
+// ScheduleStops schedules an ActivateStopsMsg for the orderbook at the given
+// time if its last trade price triggers a pending stop order and no task is
+// scheduled yet. Stop orders are activated by the cron ticker, so they never
+// fail the transaction that moved the price.
+func (e matchingEngine) ScheduleStops(db weave.KVStore, scheduler weave.Scheduler, orderbook *OrderBook, now time.Time) error {
+	if len(orderbook.StopTaskID) != 0 {
+		return nil
+	}
+	ticker, err := e.tickers.load(db, orderbook.ID)
+	if err != nil {
+		return err
+	}
+	if ticker.LastPrice == nil {
+		return nil
+	}
+	stop, err := e.nextTriggered(db, orderbook.ID, ticker.LastPrice)
+	if err != nil || stop == nil {
+		return err
+	}
+
+	activate := &ActivateStopsMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		OrderBookID: orderbook.ID,
+	}
+	// activating requires no authentication, the handler is only
+	// registered for cron
+	taskID, err := scheduler.Schedule(db, now, nil, activate)
+	if err != nil {
+		return errors.Wrap(err, "cannot schedule stop activation")
+	}
+	orderbook.StopTaskID = taskID
+	return nil
+}
+
+// ActivateStops places the pending stop orders of the orderbook triggered by
+// its last trade price as orders, and matches them. Stop orders are activated
+// in the order the price crossed their triggers, those with the same trigger
+// in the order they were created. The trades of an activated order may
+// trigger further stop orders.
+//
+// Every stop order is activated in its own cache of db. One that cannot be
+// placed is refunded and marked as failed, without affecting the others.
+// At most maxStopActivations stop orders are activated, it returns how many
+// were processed.
+func (e matchingEngine) ActivateStops(db weave.CacheableKVStore, orderbook *OrderBook, now weave.UnixTime) (int, error) {
+	for n := 0; n < maxStopActivations; n++ {
+		ticker, err := e.tickers.load(db, orderbook.ID)
+		if err != nil {
+			return n, err
+		}
+		if ticker.LastPrice == nil {
+			return n, nil
+		}
+		stop, err := e.nextTriggered(db, orderbook.ID, ticker.LastPrice)
+		if err != nil {
+			return n, err
+		}
+		if stop == nil {
+			return n, nil
+		}
+
+		cache := db.CacheWrap()
+		activated := orderbook.Copy().(*OrderBook)
+		if err := e.activate(cache, activated, stop, now); err != nil {
+			cache.Discard()
+			if err := e.fail(db, stop, now); err != nil {
+				return n, errors.Wrapf(err, "stop order %X", stop.ID)
+			}
+			continue
+		}
+		if err := cache.Write(); err != nil {
+			return n, errors.Wrap(err, "cannot write stop activation")
+		}
+		*orderbook = *activated
+	}
+	return maxStopActivations, nil
+}
+
+// nextTriggered returns the pending stop order of the orderbook that the last
+// price crossed first, nil if there is none
+func (e matchingEngine) nextTriggered(db weave.ReadOnlyKVStore, orderBookID []byte, lastPrice *Amount) (*StopOrder, error) {
+	// a rising price crosses the lowest triggers above first
+	start, err := BuildStopTriggerPrefix(orderBookID, TriggerDirection_Above)
+	if err != nil {
+		return nil, err
+	}
+	end, err := BuildStopTriggerKey(orderBookID, TriggerDirection_Above, lastPrice)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := e.firstStop(db, morm.ScanOptions{Start: start, End: end, EndInclusive: true})
+	if err != nil || stop != nil {
+		return stop, err
+	}
+
+	// a falling price crosses the highest triggers below first
+	if start, err = BuildStopTriggerKey(orderBookID, TriggerDirection_Below, lastPrice); err != nil {
+		return nil, err
+	}
+	end, err = BuildStopTriggerKey(orderBookID, TriggerDirection_Below, NewAmountp(coin.MaxInt, coin.MaxFrac))
+	if err != nil {
+		return nil, err
+	}
+	return e.firstStop(db, morm.ScanOptions{Start: start, End: end, EndInclusive: true, Reverse: true})
+}
+
+// firstStop returns the first stop order of a scan over the "trigger" index,
+// nil if there is none
+func (e matchingEngine) firstStop(db weave.ReadOnlyKVStore, opts morm.ScanOptions) (*StopOrder, error) {
+	iter, err := e.stops.IndexRangeScan(db, "trigger", opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan stop orders")
+	}
+	defer iter.Release()
+
+	var stop StopOrder
+	switch err := iter.LoadNext(&stop); {
+	case morm.ErrIteratorDone.Is(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "load stop order")
+	}
+	return &stop, nil
+}
+
+// activate places the stop order on the book as an order and matches it.
+// The offer is escrowed since the stop order was created
+func (e matchingEngine) activate(db weave.KVStore, orderbook *OrderBook, stop *StopOrder, now weave.UnixTime) error {
+	order := &Order{
+		Metadata:            &weave.Metadata{Schema: 1},
+		Trader:              stop.Trader,
+		OrderBookID:         stop.OrderBookID,
+		Side:                stop.Side,
+		OrderState:          OrderState_Open,
+		OriginalOffer:       stop.Offer.Clone(),
+		RemainingOffer:      stop.Offer.Clone(),
+		Price:               stop.Price.Clone(),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		OrderType:           stop.OrderType,
+		TimeInForce:         stop.TimeInForce,
+		DiscountedFee:       stop.DiscountedFee,
+		SelfTradePrevention: stop.SelfTradePrevention,
+		Sequence:            nextSequence(orderbook),
+	}
+	if err := e.orders.Put(db, order); err != nil {
+		return errors.Wrap(err, "cannot store order")
+	}
+
+	stop.State = StopOrderState_Triggered
+	stop.OrderID = order.ID
+	stop.UpdatedAt = now
+	if err := e.stops.Put(db, stop); err != nil {
+		return errors.Wrap(err, "cannot update stop order")
+	}
+
+	return e.Match(db, orderbook, order, now)
+}
+
+// fail refunds the offer of a stop order that could not be activated, and
+// marks it as failed so it is not triggered again
+func (e matchingEngine) fail(db weave.KVStore, stop *StopOrder, now weave.UnixTime) error {
+	if err := e.bank.MoveCoins(db, EscrowAddress, stop.Trader, *stop.Offer); err != nil {
+		return errors.Wrap(err, "cannot refund offer")
+	}
+	// the order of the failed activation was discarded
+	stop.State = StopOrderState_Failed
+	stop.OrderID = nil
+	stop.UpdatedAt = now
+	if err := e.stops.Put(db, stop); err != nil {
+		return errors.Wrap(err, "cannot update stop order")
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+package orderbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+	"github.com/iov-one/weave/migration"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+	"github.com/iov-one/weave/x/cash"
+)
+
+func TestActivateStops(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+	taker := weavetest.NewCondition()
+
+	now := time.Now()
+
+	type order struct {
+		offer *coin.Coin
+		price *Amount
+	}
+	type stop struct {
+		direction TriggerDirection
+		// in ETH per BTC, as the last price
+		trigger *Amount
+		offer   *coin.Coin
+		price   *Amount
+	}
+
+	// resting orders of another trader, ids 1 to 5. Asks sell one BTC at
+	// 20, 25 and 40 ETH, bids buy one BTC at 16 and 10 ETH
+	book := []order{
+		{coin.NewCoinp(1, 0, "BTC"), NewAmountp(20, 0)},
+		{coin.NewCoinp(1, 0, "BTC"), NewAmountp(25, 0)},
+		{coin.NewCoinp(1, 0, "BTC"), NewAmountp(40, 0)},
+		{coin.NewCoinp(16, 0, "ETH"), NewAmountp(0, 62500000)},
+		{coin.NewCoinp(10, 0, "ETH"), NewAmountp(0, 100000000)},
+	}
+	// the taker order placed after the stop orders is id 6, so the orders
+	// of activated stops start at id 7
+	buy := order{coin.NewCoinp(20, 0, "ETH"), NewAmountp(0, 50000000)}
+	sell := order{coin.NewCoinp(1, 0, "BTC"), NewAmountp(16, 0)}
+
+	cases := map[string]struct {
+		stops   []stop
+		trigger order
+		// the order placed by each stop order, nil if it is still pending
+		wantOrders    [][]byte
+		wantStates    []OrderState
+		wantLastPrice *Amount
+	}{
+		"rising price triggers above": {
+			stops: []stop{
+				{TriggerDirection_Above, NewAmountp(20, 0), coin.NewCoinp(25, 0, "ETH"), NewAmountp(0, 40000000)},
+			},
+			trigger:       buy,
+			wantOrders:    [][]byte{weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Done},
+			wantLastPrice: NewAmountp(25, 0),
+		},
+		"falling price triggers below": {
+			stops: []stop{
+				{TriggerDirection_Below, NewAmountp(16, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+			},
+			trigger:       sell,
+			wantOrders:    [][]byte{weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Done},
+			wantLastPrice: NewAmountp(10, 0),
+		},
+		"not triggered": {
+			stops: []stop{
+				{TriggerDirection_Above, NewAmountp(30, 0), coin.NewCoinp(40, 0, "ETH"), NewAmountp(0, 25000000)},
+				{TriggerDirection_Below, NewAmountp(15, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+			},
+			trigger:       buy,
+			wantOrders:    [][]byte{nil, nil},
+			wantLastPrice: NewAmountp(20, 0),
+		},
+		"lowest trigger above goes first": {
+			stops: []stop{
+				{TriggerDirection_Above, NewAmountp(20, 0), coin.NewCoinp(25, 0, "ETH"), NewAmountp(0, 40000000)},
+				{TriggerDirection_Above, NewAmountp(19, 0), coin.NewCoinp(25, 0, "ETH"), NewAmountp(0, 40000000)},
+			},
+			trigger:       buy,
+			wantOrders:    [][]byte{weavetest.SequenceID(8), weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Open, OrderState_Done},
+			wantLastPrice: NewAmountp(25, 0),
+		},
+		"highest trigger below goes first": {
+			stops: []stop{
+				{TriggerDirection_Below, NewAmountp(16, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+				{TriggerDirection_Below, NewAmountp(17, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+			},
+			trigger:       sell,
+			wantOrders:    [][]byte{weavetest.SequenceID(8), weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Open, OrderState_Done},
+			wantLastPrice: NewAmountp(10, 0),
+		},
+		"same trigger in order of creation": {
+			stops: []stop{
+				{TriggerDirection_Below, NewAmountp(16, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+				{TriggerDirection_Below, NewAmountp(16, 0), coin.NewCoinp(1, 0, "BTC"), NewAmountp(10, 0)},
+			},
+			trigger:       sell,
+			wantOrders:    [][]byte{weavetest.SequenceID(7), weavetest.SequenceID(8)},
+			wantStates:    []OrderState{OrderState_Done, OrderState_Open},
+			wantLastPrice: NewAmountp(10, 0),
+		},
+		"trades of activated orders trigger further stops": {
+			stops: []stop{
+				{TriggerDirection_Above, NewAmountp(25, 0), coin.NewCoinp(40, 0, "ETH"), NewAmountp(0, 25000000)},
+				{TriggerDirection_Above, NewAmountp(20, 0), coin.NewCoinp(25, 0, "ETH"), NewAmountp(0, 40000000)},
+			},
+			trigger:       buy,
+			wantOrders:    [][]byte{weavetest.SequenceID(8), weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Done, OrderState_Done},
+			wantLastPrice: NewAmountp(40, 0),
+		},
+		"stop limit order rests on the book": {
+			stops: []stop{
+				{TriggerDirection_Above, NewAmountp(20, 0), coin.NewCoinp(15, 0, "ETH"), NewAmountp(0, 66666666)},
+			},
+			trigger:       buy,
+			wantOrders:    [][]byte{weavetest.SequenceID(7)},
+			wantStates:    []OrderState{OrderState_Open},
+			wantLastPrice: NewAmountp(20, 0),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			auth := &weavetest.Auth{Signers: []weave.Condition{trader, other, taker}}
+			ctrl := cash.NewController(cash.NewBucket())
+			orderHandler := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+			stopHandler := NewStopOrderHandler(auth, ctrl)
+			activateHandler := NewActivateStopsHandler(ctrl, &weavetest.Cron{})
+
+			kv := store.MemStore()
+			migration.MustInitPkg(kv, packageName, "cash")
+
+			market := &Market{
+				Metadata: &weave.Metadata{Schema: 1},
+				Owner:    other.Address(),
+				Name:     "stops",
+			}
+			assert.Nil(t, NewMarketBucket().Put(kv, market))
+			orderbook := &OrderBook{
+				Metadata:  &weave.Metadata{Schema: 1},
+				MarketID:  market.ID,
+				AskTicker: "BTC",
+				BidTicker: "ETH",
+			}
+			assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+
+			for _, c := range []weave.Condition{trader, other, taker} {
+				assert.Nil(t, ctrl.CoinMint(kv, c.Address(), coin.NewCoin(10, 0, "BTC")))
+				assert.Nil(t, ctrl.CoinMint(kv, c.Address(), coin.NewCoin(100, 0, "ETH")))
+			}
+
+			ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+			for _, o := range book {
+				_, err := orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+					Metadata:    &weave.Metadata{Schema: 1},
+					Trader:      other.Address(),
+					OrderBookID: orderbook.ID,
+					Offer:       o.offer,
+					Price:       o.price,
+				}})
+				assert.Nil(t, err)
+			}
+			for _, s := range tc.stops {
+				_, err := stopHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateStopOrderMsg{
+					Metadata:     &weave.Metadata{Schema: 1},
+					Trader:       trader.Address(),
+					OrderBookID:  orderbook.ID,
+					Direction:    s.direction,
+					TriggerPrice: s.trigger,
+					Offer:        s.offer,
+					Price:        s.price,
+				}})
+				assert.Nil(t, err)
+			}
+
+			_, err := orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+				Metadata:    &weave.Metadata{Schema: 1},
+				Trader:      taker.Address(),
+				OrderBookID: orderbook.ID,
+				Offer:       tc.trigger.offer,
+				Price:       tc.trigger.price,
+			}})
+			assert.Nil(t, err)
+
+			stops := NewStopOrderBucket()
+			orders := NewOrderBucket()
+
+			// stop orders are only activated by the cron ticker in the
+			// next block, the trade schedules it if any is triggered
+			var first StopOrder
+			assert.Nil(t, stops.One(kv, weavetest.SequenceID(1), &first))
+			assert.Equal(t, StopOrderState_Pending, first.State)
+			var stored OrderBook
+			assert.Nil(t, NewOrderBookBucket().One(kv, orderbook.ID, &stored))
+			if len(stored.StopTaskID) != 0 {
+				next := weave.WithHeight(weave.WithBlockTime(context.Background(), now.Add(time.Second)), 2)
+				_, err := activateHandler.Deliver(next, kv, &weavetest.Tx{Msg: &ActivateStopsMsg{
+					Metadata:    &weave.Metadata{Schema: 1},
+					OrderBookID: orderbook.ID,
+				}})
+				assert.Nil(t, err)
+			}
+			for i, want := range tc.wantOrders {
+				var stop StopOrder
+				assert.Nil(t, stops.One(kv, weavetest.SequenceID(uint64(i+1)), &stop))
+				assert.Equal(t, want, stop.OrderID)
+				if want == nil {
+					assert.Equal(t, StopOrderState_Pending, stop.State)
+					continue
+				}
+				assert.Equal(t, StopOrderState_Triggered, stop.State)
+
+				var order Order
+				assert.Nil(t, orders.One(kv, want, &order))
+				assert.Equal(t, tc.wantStates[i], order.OrderState)
+				assert.Equal(t, trader.Address(), order.Trader)
+				assert.Equal(t, *stop.Offer, *order.OriginalOffer)
+			}
+
+			ticker, err := NewTickerBucket().load(kv, orderbook.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantLastPrice, ticker.LastPrice)
+		})
+	}
+}
+
+func TestActivateStopsPerTask(t *testing.T) {
+	trader := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+
+	auth := &weavetest.Auth{Signers: []weave.Condition{trader, other}}
+	ctrl := cash.NewController(cash.NewBucket())
+	orderHandler := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+	stopHandler := NewStopOrderHandler(auth, ctrl)
+	activateHandler := NewActivateStopsHandler(ctrl, &weavetest.Cron{})
+
+	kv := store.MemStore()
+	migration.MustInitPkg(kv, packageName, "cash")
+
+	market := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    other.Address(),
+		Name:     "stops",
+	}
+	assert.Nil(t, NewMarketBucket().Put(kv, market))
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  market.ID,
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+	assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(10, 0, "BTC")))
+	assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+
+	ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+	// enough BTC at 20 ETH for every stop order
+	_, err := orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      other.Address(),
+		OrderBookID: orderbook.ID,
+		Offer:       coin.NewCoinp(10, 0, "BTC"),
+		Price:       NewAmountp(20, 0),
+	}})
+	assert.Nil(t, err)
+
+	// each stop order buys 0.05 BTC for 1 ETH
+	stops := maxStopActivations + 1
+	for i := 0; i < stops; i++ {
+		_, err := stopHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateStopOrderMsg{
+			Metadata:     &weave.Metadata{Schema: 1},
+			Trader:       trader.Address(),
+			OrderBookID:  orderbook.ID,
+			Direction:    TriggerDirection_Above,
+			TriggerPrice: NewAmountp(20, 0),
+			Offer:        coin.NewCoinp(1, 0, "ETH"),
+			Price:        NewAmountp(0, 50000000),
+		}})
+		assert.Nil(t, err)
+	}
+
+	countPending := func() int {
+		t.Helper()
+		prefix, err := BuildStopTriggerPrefix(orderbook.ID, TriggerDirection_Above)
+		assert.Nil(t, err)
+		iter, err := NewStopOrderBucket().IndexScan(kv, "trigger", prefix, false)
+		assert.Nil(t, err)
+		defer iter.Release()
+		n := 0
+		for iter.Valid() {
+			assert.Nil(t, iter.LoadNext(&StopOrder{}))
+			n++
+		}
+		return n
+	}
+	taskID := func() []byte {
+		t.Helper()
+		var stored OrderBook
+		assert.Nil(t, NewOrderBookBucket().One(kv, orderbook.ID, &stored))
+		return stored.StopTaskID
+	}
+	activate := func(height int64) {
+		t.Helper()
+		ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now.Add(time.Duration(height)*time.Second)), height)
+		_, err := activateHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &ActivateStopsMsg{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: orderbook.ID,
+		}})
+		assert.Nil(t, err)
+	}
+
+	// the trade only schedules the activation
+	_, err = orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      trader.Address(),
+		OrderBookID: orderbook.ID,
+		Offer:       coin.NewCoinp(1, 0, "ETH"),
+		Price:       NewAmountp(0, 50000000),
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, stops, countPending())
+	if len(taskID()) == 0 {
+		t.Fatal("stop activation not scheduled")
+	}
+
+	// the task activates as many as allowed and schedules the next one
+	activate(2)
+	assert.Equal(t, 1, countPending())
+	if len(taskID()) == 0 {
+		t.Fatal("remaining stop activation not scheduled")
+	}
+
+	// the next block activates the rest without any further trade
+	activate(3)
+	assert.Equal(t, 0, countPending())
+	assert.Equal(t, 0, len(taskID()))
+}
+
+// frozenMover refuses to pay the given ticker to one address
+type frozenMover struct {
+	cash.CoinMover
+	frozen weave.Address
+	ticker string
+}
+
+func (m frozenMover) MoveCoins(db weave.KVStore, src, dest weave.Address, amount coin.Coin) error {
+	if dest.Equals(m.frozen) && amount.Ticker == m.ticker {
+		return errors.Wrap(errors.ErrState, "frozen")
+	}
+	return m.CoinMover.MoveCoins(db, src, dest, amount)
+}
+
+func TestActivateStopsFailure(t *testing.T) {
+	trader := weavetest.NewCondition()
+	frozen := weavetest.NewCondition()
+	other := weavetest.NewCondition()
+
+	now := time.Now()
+
+	auth := &weavetest.Auth{Signers: []weave.Condition{trader, frozen, other}}
+	ctrl := cash.NewController(cash.NewBucket())
+	orderHandler := NewOrderHandler(auth, ctrl, &weavetest.Cron{})
+	stopHandler := NewStopOrderHandler(auth, ctrl)
+	// the frozen trader cannot receive the BTC bought by its stop order
+	activateHandler := NewActivateStopsHandler(frozenMover{ctrl, frozen.Address(), "BTC"}, &weavetest.Cron{})
+
+	kv := store.MemStore()
+	migration.MustInitPkg(kv, packageName, "cash")
+
+	market := &Market{
+		Metadata: &weave.Metadata{Schema: 1},
+		Owner:    other.Address(),
+		Name:     "stops",
+	}
+	assert.Nil(t, NewMarketBucket().Put(kv, market))
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		MarketID:  market.ID,
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+	assert.Nil(t, NewOrderBookBucket().Put(kv, orderbook))
+	assert.Nil(t, ctrl.CoinMint(kv, other.Address(), coin.NewCoin(10, 0, "BTC")))
+	assert.Nil(t, ctrl.CoinMint(kv, trader.Address(), coin.NewCoin(100, 0, "ETH")))
+	assert.Nil(t, ctrl.CoinMint(kv, frozen.Address(), coin.NewCoin(100, 0, "ETH")))
+
+	ctx := weave.WithHeight(weave.WithBlockTime(context.Background(), now), 1)
+	_, err := orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      other.Address(),
+		OrderBookID: orderbook.ID,
+		Offer:       coin.NewCoinp(10, 0, "BTC"),
+		Price:       NewAmountp(20, 0),
+	}})
+	assert.Nil(t, err)
+
+	// the stop order of the frozen trader is triggered first
+	for _, s := range []struct {
+		trader  weave.Condition
+		trigger *Amount
+	}{
+		{frozen, NewAmountp(19, 0)},
+		{trader, NewAmountp(20, 0)},
+	} {
+		_, err := stopHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateStopOrderMsg{
+			Metadata:     &weave.Metadata{Schema: 1},
+			Trader:       s.trader.Address(),
+			OrderBookID:  orderbook.ID,
+			Direction:    TriggerDirection_Above,
+			TriggerPrice: s.trigger,
+			Offer:        coin.NewCoinp(1, 0, "ETH"),
+			Price:        NewAmountp(0, 50000000),
+		}})
+		assert.Nil(t, err)
+	}
+
+	_, err = orderHandler.Deliver(ctx, kv, &weavetest.Tx{Msg: &CreateOrderMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		Trader:      trader.Address(),
+		OrderBookID: orderbook.ID,
+		Offer:       coin.NewCoinp(1, 0, "ETH"),
+		Price:       NewAmountp(0, 50000000),
+	}})
+	assert.Nil(t, err)
+
+	next := weave.WithHeight(weave.WithBlockTime(context.Background(), now.Add(time.Second)), 2)
+	_, err = activateHandler.Deliver(next, kv, &weavetest.Tx{Msg: &ActivateStopsMsg{
+		Metadata:    &weave.Metadata{Schema: 1},
+		OrderBookID: orderbook.ID,
+	}})
+	assert.Nil(t, err)
+
+	// the failed one is refunded, the other one is still activated
+	stops := NewStopOrderBucket()
+	var stop StopOrder
+	assert.Nil(t, stops.One(kv, weavetest.SequenceID(1), &stop))
+	assert.Equal(t, StopOrderState_Failed, stop.State)
+	assert.Equal(t, 0, len(stop.OrderID))
+	assert.Nil(t, stops.One(kv, weavetest.SequenceID(2), &stop))
+	assert.Equal(t, StopOrderState_Triggered, stop.State)
+
+	balance, err := ctrl.Balance(kv, frozen.Address())
+	assert.Nil(t, err)
+	assert.Equal(t, coin.Coins{coin.NewCoinp(100, 0, "ETH")}, balance)
+	balance, err = ctrl.Balance(kv, trader.Address())
+	assert.Nil(t, err)
+	assert.Equal(t, true, balance.Contains(coin.NewCoin(98, 0, "ETH")))
+	assert.Equal(t, true, balance.Contains(coin.NewCoin(0, 100000000, "BTC")))
+}
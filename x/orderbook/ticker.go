@@ -0,0 +1,248 @@
+package orderbook
+
+import (
+	"github.com/iov-one/tutorial/morm"
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/errors"
+)
+
+// tickerWindow is the length of the rolling window of the ticker statistics,
+// in seconds
+const tickerWindow = 24 * 60 * 60
+
+// load returns the ticker of the orderbook, or a new one if there is none yet
+func (b *TickerBucket) load(db weave.ReadOnlyKVStore, orderBookID []byte) (*Ticker, error) {
+	var ticker Ticker
+	switch err := b.One(db, orderBookID, &ticker); {
+	case errors.ErrNotFound.Is(err):
+		return &Ticker{
+			Metadata: &weave.Metadata{Schema: 1},
+			ID:       copyBytes(orderBookID),
+		}, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "cannot load ticker")
+	}
+	return &ticker, nil
+}
+
+// Record adds a trade of the orderbook to its ticker and rolls the 24h window
+// up to the trade execution time. Trades that fall out of the window are found
+// through the "orderbook" trade index, so the trade must already be stored.
+func (b *TickerBucket) Record(db weave.KVStore, trades *TradeBucket, orderbook *OrderBook, trade *Trade) error {
+	price, base, quote, err := tradePrice(orderbook, trade)
+	if err != nil {
+		return err
+	}
+	ticker, err := b.load(db, trade.OrderBookID)
+	if err != nil {
+		return err
+	}
+
+	cutoff := trade.ExecutedAt - tickerWindow
+	stale := false
+	if ticker.DayTradeCount == 0 {
+		// nothing to expire, start a new window
+		ticker.WindowStart = cutoff
+		ticker.DayHigh, ticker.DayLow = nil, nil
+		ticker.DayBaseVolume = coin.NewCoinp(0, 0, base.Ticker)
+		ticker.DayQuoteVolume = coin.NewCoinp(0, 0, quote.Ticker)
+	} else {
+		if stale, err = expireTrades(db, trades, orderbook, ticker, cutoff); err != nil {
+			return err
+		}
+		if ticker.DayTradeCount == 0 {
+			ticker.DayHigh, ticker.DayLow = nil, nil
+			stale = false
+		}
+	}
+
+	ticker.LastPrice = price.Clone()
+	ticker.LastTradeAt = trade.ExecutedAt
+	if ticker.DayHigh == nil || price.Compare(ticker.DayHigh) > 0 {
+		ticker.DayHigh = price.Clone()
+	}
+	if ticker.DayLow == nil || price.Compare(ticker.DayLow) < 0 {
+		ticker.DayLow = price.Clone()
+	}
+	baseVolume, err := ticker.DayBaseVolume.Add(base)
+	if err != nil {
+		return errors.Wrap(err, "base volume")
+	}
+	ticker.DayBaseVolume = &baseVolume
+	quoteVolume, err := ticker.DayQuoteVolume.Add(quote)
+	if err != nil {
+		return errors.Wrap(err, "quote volume")
+	}
+	ticker.DayQuoteVolume = &quoteVolume
+	ticker.DayTradeCount++
+
+	// an expired trade set the high or low, so they must be found again
+	if stale {
+		if err := dayRange(db, trades, orderbook, ticker); err != nil {
+			return err
+		}
+	}
+
+	if err := b.Put(db, ticker); err != nil {
+		return errors.Wrap(err, "cannot store ticker")
+	}
+	return nil
+}
+
+// expireTrades removes all trades executed after the window start up to the
+// cutoff from the 24h statistics, and moves the window start to the cutoff.
+// It returns true if the high or the low of the window was set by any of them.
+func expireTrades(db weave.ReadOnlyKVStore, trades *TradeBucket, orderbook *OrderBook, ticker *Ticker, cutoff weave.UnixTime) (bool, error) {
+	// no trade can be executed before the epoch
+	if cutoff <= ticker.WindowStart || cutoff < 0 {
+		return false, nil
+	}
+	opts, err := windowScan(ticker.ID, ticker.WindowStart, cutoff)
+	if err != nil {
+		return false, err
+	}
+	iter, err := trades.IndexRangeScan(db, "orderbook", opts)
+	if err != nil {
+		return false, errors.Wrap(err, "scan trades")
+	}
+	defer iter.Release()
+
+	stale := false
+	for {
+		var trade Trade
+		err := iter.LoadNext(&trade)
+		if morm.ErrIteratorDone.Is(err) {
+			break
+		}
+		if err != nil {
+			return false, errors.Wrap(err, "load trade")
+		}
+		price, base, quote, err := tradePrice(orderbook, &trade)
+		if err != nil {
+			return false, err
+		}
+		baseVolume, err := ticker.DayBaseVolume.Subtract(base)
+		if err != nil {
+			return false, errors.Wrap(err, "base volume")
+		}
+		ticker.DayBaseVolume = &baseVolume
+		quoteVolume, err := ticker.DayQuoteVolume.Subtract(quote)
+		if err != nil {
+			return false, errors.Wrap(err, "quote volume")
+		}
+		ticker.DayQuoteVolume = &quoteVolume
+		ticker.DayTradeCount--
+		if price.Equals(ticker.DayHigh) || price.Equals(ticker.DayLow) {
+			stale = true
+		}
+	}
+	ticker.WindowStart = cutoff
+	return stale, nil
+}
+
+// dayRange sets the high and low of the ticker from all trades of its 24h window
+func dayRange(db weave.ReadOnlyKVStore, trades *TradeBucket, orderbook *OrderBook, ticker *Ticker) error {
+	opts, err := windowScan(ticker.ID, ticker.WindowStart, ticker.LastTradeAt)
+	if err != nil {
+		return err
+	}
+	iter, err := trades.IndexRangeScan(db, "orderbook", opts)
+	if err != nil {
+		return errors.Wrap(err, "scan trades")
+	}
+	defer iter.Release()
+
+	ticker.DayHigh, ticker.DayLow = nil, nil
+	for {
+		var trade Trade
+		err := iter.LoadNext(&trade)
+		if morm.ErrIteratorDone.Is(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "load trade")
+		}
+		price, _, _, err := tradePrice(orderbook, &trade)
+		if err != nil {
+			return err
+		}
+		if ticker.DayHigh == nil || price.Compare(ticker.DayHigh) > 0 {
+			ticker.DayHigh = price
+		}
+		if ticker.DayLow == nil || price.Compare(ticker.DayLow) < 0 {
+			ticker.DayLow = price
+		}
+	}
+}
+
+// windowScan returns the scan over the "orderbook" trade index for all trades
+// of the orderbook executed after from, up to and including until
+func windowScan(orderBookID []byte, from, until weave.UnixTime) (morm.ScanOptions, error) {
+	opts := morm.ScanOptions{EndInclusive: true}
+	var err error
+	if from < 0 {
+		opts.Start, err = BuildOrderBookTimeKey(orderBookID, 0)
+	} else {
+		opts.Start, err = BuildOrderBookTimeKey(orderBookID, from)
+		opts.StartExclusive = true
+	}
+	if err != nil {
+		return opts, errors.Wrap(err, "window start")
+	}
+	if opts.End, err = BuildOrderBookTimeKey(orderBookID, until); err != nil {
+		return opts, errors.Wrap(err, "window end")
+	}
+	return opts, nil
+}
+
+// UpdateBestPrices sets the best bid and ask of the ticker from the open orders
+// of the orderbook. It must be called whenever an order is added to or removed
+// from the book.
+func (b *TickerBucket) UpdateBestPrices(db weave.KVStore, orders *OrderBucket, orderBookID []byte) error {
+	ticker, err := b.load(db, orderBookID)
+	if err != nil {
+		return err
+	}
+	if ticker.BestAsk, err = bestPrice(db, orders, orderBookID, Side_Ask); err != nil {
+		return errors.Wrap(err, "best ask")
+	}
+	bid, err := bestPrice(db, orders, orderBookID, Side_Bid)
+	if err != nil {
+		return errors.Wrap(err, "best bid")
+	}
+	// bids are priced in the ask ticker, the best one is the highest in the bid ticker
+	ticker.BestBid = nil
+	if bid != nil {
+		if ticker.BestBid, err = NewAmountp(1, 0).Divide(bid, RoundHalfUp); err != nil {
+			return errors.Wrap(err, "best bid")
+		}
+	}
+	if err := b.Put(db, ticker); err != nil {
+		return errors.Wrap(err, "cannot store ticker")
+	}
+	return nil
+}
+
+// bestPrice returns the price of the first open order of one side of the
+// orderbook, nil if there is none. The price is read from the "open" index
+// without loading the order.
+func bestPrice(db weave.ReadOnlyKVStore, orders *OrderBucket, orderBookID []byte, side Side) (*Amount, error) {
+	prefix, err := BuildOpenOrderPrefix(orderBookID, side)
+	if err != nil {
+		return nil, errors.Wrap(err, "open orders prefix")
+	}
+	iter, err := orders.IndexScan(db, "open", prefix, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "scan open orders")
+	}
+	defer iter.Release()
+
+	if !iter.Valid() {
+		if err := iter.LoadNext(&Order{}); !morm.ErrIteratorDone.Is(err) {
+			return nil, errors.Wrap(err, "load order")
+		}
+		return nil, nil
+	}
+	return ParseOpenOrderPrice(iter.IndexKey())
+}
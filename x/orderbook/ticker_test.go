@@ -0,0 +1,228 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/iov-one/weave"
+	"github.com/iov-one/weave/coin"
+	"github.com/iov-one/weave/store"
+	"github.com/iov-one/weave/weavetest"
+	"github.com/iov-one/weave/weavetest/assert"
+)
+
+func TestTickerRecord(t *testing.T) {
+	book := weavetest.SequenceID(1)
+	maker := weavetest.NewCondition().Address()
+	taker := weavetest.NewCondition().Address()
+	orderbook := &OrderBook{
+		Metadata:  &weave.Metadata{Schema: 1},
+		ID:        book,
+		MarketID:  weavetest.SequenceID(1),
+		AskTicker: "BTC",
+		BidTicker: "ETH",
+	}
+
+	// trade is priced in ETH per BTC, whoever was the maker
+	trade := func(btc, eth int64, at weave.UnixTime, makerAsks bool) *Trade {
+		t := &Trade{
+			Metadata:    &weave.Metadata{Schema: 1},
+			OrderBookID: book,
+			OrderID:     weavetest.SequenceID(1),
+			Taker:       taker,
+			Maker:       maker,
+			MakerPaid:   coin.NewCoinp(btc, 0, "BTC"),
+			TakerPaid:   coin.NewCoinp(eth, 0, "ETH"),
+			ExecutedAt:  at,
+		}
+		if !makerAsks {
+			t.MakerPaid, t.TakerPaid = t.TakerPaid, t.MakerPaid
+		}
+		return t
+	}
+
+	cases := map[string]struct {
+		trades []*Trade
+		want   *Ticker
+	}{
+		"single trade": {
+			trades: []*Trade{trade(2, 5, 1000, true)},
+			want: &Ticker{
+				LastPrice:      NewAmountp(2, 500000000),
+				LastTradeAt:    1000,
+				WindowStart:    1000 - tickerWindow,
+				DayHigh:        NewAmountp(2, 500000000),
+				DayLow:         NewAmountp(2, 500000000),
+				DayBaseVolume:  coin.NewCoinp(2, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(5, 0, "ETH"),
+				DayTradeCount:  1,
+			},
+		},
+		"trades within a day": {
+			trades: []*Trade{
+				trade(1, 3, 1000, true),
+				trade(1, 5, 2000, false),
+				trade(1, 1, 3000, true),
+			},
+			want: &Ticker{
+				LastPrice:      NewAmountp(1, 0),
+				LastTradeAt:    3000,
+				WindowStart:    1000 - tickerWindow,
+				DayHigh:        NewAmountp(5, 0),
+				DayLow:         NewAmountp(1, 0),
+				DayBaseVolume:  coin.NewCoinp(3, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(9, 0, "ETH"),
+				DayTradeCount:  3,
+			},
+		},
+		"expired high is found again": {
+			trades: []*Trade{
+				trade(1, 5, 1000, true),
+				trade(1, 1, 2000, true),
+				trade(1, 2, 2500, true),
+				trade(1, 3, 1500+tickerWindow, true),
+			},
+			want: &Ticker{
+				LastPrice:      NewAmountp(3, 0),
+				LastTradeAt:    1500 + tickerWindow,
+				WindowStart:    1500,
+				DayHigh:        NewAmountp(3, 0),
+				DayLow:         NewAmountp(1, 0),
+				DayBaseVolume:  coin.NewCoinp(3, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(6, 0, "ETH"),
+				DayTradeCount:  3,
+			},
+		},
+		"trade at the window start expires": {
+			trades: []*Trade{
+				trade(1, 5, 1000, true),
+				trade(1, 2, 1000+tickerWindow, true),
+			},
+			want: &Ticker{
+				LastPrice:      NewAmountp(2, 0),
+				LastTradeAt:    1000 + tickerWindow,
+				WindowStart:    1000,
+				DayHigh:        NewAmountp(2, 0),
+				DayLow:         NewAmountp(2, 0),
+				DayBaseVolume:  coin.NewCoinp(1, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(2, 0, "ETH"),
+				DayTradeCount:  1,
+			},
+		},
+		"window without trades starts again": {
+			trades: []*Trade{
+				trade(1, 5, 1000, true),
+				trade(2, 2, 5*tickerWindow, true),
+				trade(1, 4, 5*tickerWindow+10, false),
+			},
+			want: &Ticker{
+				LastPrice:      NewAmountp(4, 0),
+				LastTradeAt:    5*tickerWindow + 10,
+				WindowStart:    4*tickerWindow + 10,
+				DayHigh:        NewAmountp(4, 0),
+				DayLow:         NewAmountp(1, 0),
+				DayBaseVolume:  coin.NewCoinp(3, 0, "BTC"),
+				DayQuoteVolume: coin.NewCoinp(6, 0, "ETH"),
+				DayTradeCount:  2,
+			},
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			db := store.MemStore()
+			trades := NewTradeBucket()
+			tickers := NewTickerBucket()
+			for _, trade := range tc.trades {
+				assert.Nil(t, trades.Put(db, trade))
+				assert.Nil(t, tickers.Record(db, trades, orderbook, trade))
+			}
+
+			var got Ticker
+			assert.Nil(t, tickers.One(db, book, &got))
+			assert.Nil(t, got.Validate())
+
+			want := *tc.want
+			want.Metadata = &weave.Metadata{Schema: 1}
+			want.ID = book
+			assert.Equal(t, &want, &got)
+		})
+	}
+}
+
+func TestTickerBestPrices(t *testing.T) {
+	book := weavetest.SequenceID(1)
+	trader := weavetest.NewCondition().Address()
+
+	cases := map[string]struct {
+		orders   []*Order
+		wantBid  *Amount
+		wantAsk  *Amount
+		previous *Ticker
+	}{
+		"empty book": {},
+		"both sides": {
+			orders: []*Order{
+				{Side: Side_Ask, OrderState: OrderState_Open, Price: NewAmountp(3, 0)},
+				{Side: Side_Ask, OrderState: OrderState_Open, Price: NewAmountp(2, 0)},
+				{Side: Side_Ask, OrderState: OrderState_Done, Price: NewAmountp(1, 0)},
+				{Side: Side_Bid, OrderState: OrderState_Open, Price: NewAmountp(0, 500000000)},
+				{Side: Side_Bid, OrderState: OrderState_Open, Price: NewAmountp(0, 400000000)},
+			},
+			wantBid: NewAmountp(2, 500000000),
+			wantAsk: NewAmountp(2, 0),
+		},
+		"side without orders is cleared": {
+			orders: []*Order{
+				{Side: Side_Ask, OrderState: OrderState_Open, Price: NewAmountp(3, 0)},
+				{Side: Side_Bid, OrderState: OrderState_Cancel, Price: NewAmountp(0, 400000000)},
+			},
+			previous: &Ticker{
+				LastPrice:   NewAmountp(2, 0),
+				LastTradeAt: 1000,
+				BestBid:     NewAmountp(2, 0),
+				BestAsk:     NewAmountp(3, 0),
+			},
+			wantAsk: NewAmountp(3, 0),
+		},
+	}
+
+	for testName, tc := range cases {
+		t.Run(testName, func(t *testing.T) {
+			db := store.MemStore()
+			orders := NewOrderBucket()
+			for _, o := range tc.orders {
+				offer := coin.NewCoinp(1, 0, "BTC")
+				if o.Side == Side_Bid {
+					offer = coin.NewCoinp(1, 0, "ETH")
+				}
+				o.Metadata = &weave.Metadata{Schema: 1}
+				o.Trader = trader
+				o.OrderBookID = book
+				o.OriginalOffer = offer
+				o.RemainingOffer = offer
+				o.CreatedAt = 1000
+				o.UpdatedAt = 1000
+				assert.Nil(t, orders.Put(db, o))
+			}
+			tickers := NewTickerBucket()
+			want := &Ticker{}
+			if tc.previous != nil {
+				want = tc.previous
+				want.Metadata = &weave.Metadata{Schema: 1}
+				want.ID = book
+				assert.Nil(t, tickers.Put(db, want))
+			}
+
+			assert.Nil(t, tickers.UpdateBestPrices(db, orders, book))
+
+			var got Ticker
+			assert.Nil(t, tickers.One(db, book, &got))
+			assert.Nil(t, got.Validate())
+			assert.Equal(t, tc.wantBid, got.BestBid)
+			assert.Equal(t, tc.wantAsk, got.BestAsk)
+			// nothing else is touched
+			assert.Equal(t, want.LastPrice, got.LastPrice)
+			assert.Equal(t, want.LastTradeAt, got.LastTradeAt)
+		})
+	}
+}